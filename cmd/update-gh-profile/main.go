@@ -2,41 +2,295 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/watsumi/update-gh-profile/internal/config"
+	"github.com/watsumi/update-gh-profile/internal/generator"
+	"github.com/watsumi/update-gh-profile/internal/git"
+	"github.com/watsumi/update-gh-profile/internal/githubapp"
 	"github.com/watsumi/update-gh-profile/internal/logger"
+	"github.com/watsumi/update-gh-profile/internal/pullrequest"
+	"github.com/watsumi/update-gh-profile/internal/repository"
+	"github.com/watsumi/update-gh-profile/internal/snapshot"
 	"github.com/watsumi/update-gh-profile/internal/workflow"
+
+	"golang.org/x/oauth2"
+)
+
+// Commands
+// Each stage can run in a separate job, passing the metrics file (and the generated files) between them
+const (
+	commandFetch  = "fetch"  // Fetch repository data and write the metrics file
+	commandRender = "render" // Generate chart images from the metrics file
+	commandReadme = "readme" // Update README.md sections from existing chart images
+	commandCommit = "commit" // Commit and push the updated files
+	commandRun    = "run"    // All of the above (default)
 )
 
+// commandUsages descriptions of the commands shown in the usage
+var commandUsages = []struct {
+	name        string
+	description string
+}{
+	{commandFetch, "Fetch repository data and write the metrics file (--metrics)"},
+	{commandRender, "Generate chart images from the metrics file"},
+	{commandReadme, "Update README.md sections from existing chart images"},
+	{commandCommit, "Commit and push README.md, chart images and the metrics snapshot"},
+	{commandRun, "Run all of the above (default)"},
+}
+
 func main() {
+	// The first argument selects the command (flags only = run)
+	command, args := commandRun, os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	flags := flag.NewFlagSet("update-gh-profile "+command, flag.ExitOnError)
+	flags.Usage = func() {
+		printUsage(flags.Output())
+		fmt.Fprintf(flags.Output(), "\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	if !isCommand(command) {
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", command)
+		printUsage(os.Stderr)
+		os.Exit(2)
+	}
+
 	// Parse command line arguments
+	// All commands accept the same flags, flags that don't apply to a stage are ignored
 	var (
-		excludeForksStr     = flag.String("exclude-forks", "true", "Whether to exclude forked repositories (true/false)")
-		excludeLanguagesStr = flag.String("exclude-languages", "", "Language names to exclude from ranking (comma-separated, e.g., JSON,Markdown,Text)")
+		excludeForksStr     = flags.String("exclude-forks", "true", "Whether to exclude forked repositories (true/false)")
+		excludeLanguagesStr = flags.String("exclude-languages", "", "Language names to exclude from ranking (comma-separated, e.g., JSON,Markdown,Text)")
+		templatePath        = flags.String("template", "", "README template path (default: README.tmpl.md next to README.md if it exists)")
+		pngScale            = flags.Float64("png-scale", 0, "Also write PNG versions of charts at this scale (e.g., 2; at most 8; 0 = only sections with format=png)")
+		skipUnchanged       = flags.Bool("skip-unchanged", true, "Skip commit if metrics didn't change materially since the last snapshot")
+		changePercent       = flags.Float64("change-threshold-percent", snapshot.DefaultThresholds().LanguagePercentage, "Minimum change of a language percentage (points) regarded as material")
+		changeCount         = flags.Int("change-threshold-count", snapshot.DefaultThresholds().Count, "Minimum change of stars, repositories, commits or PRs regarded as material")
+		pullRequest         = flags.Bool("pull-request", false, "Commit to a dedicated branch and open/update a pull request instead of pushing directly")
+		pullRequestBranch   = flags.String("pull-request-branch", pullrequest.DefaultBranch, "Branch used in pull request mode")
+		pullRequestBase     = flags.String("pull-request-base", "", "Base branch of the pull request (default: current branch)")
+		gitBackend          = flags.String("git-backend", git.BackendAuto, "Git implementation: auto (git binary if installed), exec (git binary) or native (built-in)")
+		commitMessage       = flags.String("commit-message", workflow.DefaultCommitMessage, "Commit message template (Go text/template, e.g., \"chore: update metrics ({{.Deltas}})\")")
+		commitAuthor        = flags.String("commit-author", "", "Commit author in \"Name <email>\" format (default: git config user.name/user.email or GITHUB_ACTOR)")
+		commitCommitter     = flags.String("commit-committer", "", "Commit committer in \"Name <email>\" format (default: git config user.name/user.email or GITHUB_ACTOR)")
+		signingFormat       = flags.String("signing-format", "", "Sign commits with gpg or ssh (default: not signed)")
+		signingKey          = flags.String("signing-key", "", "Signing key: GPG key ID or SSH key path (exec backend), private key file (native backend)")
+		pushAttempts        = flags.Int("push-attempts", git.DefaultPushAttempts, "Maximum push attempts; if the remote branch moved, outputs are regenerated on top of it before retrying")
+		assetsBranch        = flags.String("assets-branch", "", "Push images to this branch (e.g., profile-assets) and reference them by raw URLs (default: commit them with README.md)")
+		assetsRepo          = flags.String("assets-repo", "", "Repository of the assets branch (owner/repo, default: this repository)")
+		outputFormat        = flags.String("format", workflow.OutputFormatSVG, "Output format: svg (generate charts and update README.md) or text (print charts to stdout)")
+		readmePath          = flags.String("readme", "", "README.md path relative to the repository (default: README.md, or profile/README.md with --org)")
+		outputDir           = flags.String("output-dir", ".", "Output directory for chart images (relative to the directory of README.md)")
+		timezone            = flags.String("timezone", "UTC", "Timezone of dates in README.md and commit messages (e.g., Asia/Tokyo)")
+		maxRepos            = flags.Int("max-repos", 0, "Maximum number of repositories to aggregate (0 = all)")
+		charts              = flags.String("charts", "", "Charts to generate (comma-separated: language,commit_history,commit_time,commit_languages,summary,contributors,leaderboard; default: all)")
+		theme               = flags.String("theme", "dark", "Default chart theme: dark or light (section attributes take precedence)")
+		dryRun              = flags.Bool("dry-run", false, "Generate charts and update README.md without committing or pushing")
+		logFormat           = flags.String("log-format", os.Getenv("LOG_FORMAT"), "Log format: auto (actions in GitHub Actions, text otherwise), text, json or actions")
+		apiURL              = flags.String("api-url", os.Getenv("GITHUB_API_URL"), "GitHub REST API URL (e.g., https://github.example.com/api/v3; default: derived from graphql-url, or https://api.github.com/)")
+		graphQLURL          = flags.String("graphql-url", os.Getenv("GITHUB_GRAPHQL_URL"), "GitHub GraphQL API URL (default: derived from api-url, or https://api.github.com/graphql)")
+		serverURL           = flags.String("server-url", os.Getenv("GITHUB_SERVER_URL"), "GitHub server URL used for links and raw image URLs (default: https://github.com)")
+		caBundle            = flags.String("ca-bundle", "", "PEM file of CA certificates trusted for the GitHub API in addition to the system roots")
+		proxy               = flags.String("proxy", "", "Proxy URL for the GitHub API (default: HTTPS_PROXY/HTTP_PROXY environment variables)")
+		gitLabURL           = flags.String("gitlab-url", os.Getenv("GITLAB_URL"), "GitLab REST API URL used when GITLAB_TOKEN is set (default: https://gitlab.com/api/v4)")
+		localRepos          = flags.String("local-repos", "", "Directory of local git clones whose commits are aggregated too (no network access)")
+		localEmails         = flags.String("local-emails", "", "Author emails of your commits in local-repos (comma-separated)")
+		giteaURL            = flags.String("gitea-url", os.Getenv("GITEA_URL"), "Gitea or Forgejo server URL (e.g., https://forge.example.com), required when GITEA_TOKEN is set")
+		team                = flags.String("team", "", "GitHub users ranked on the team leaderboard (comma-separated, e.g., alice,bob)")
+		teamDays            = flags.Int("team-days", workflow.DefaultTeamDays, "Period of the team leaderboard in days (1-365)")
+		organization        = flags.String("org", "", "Aggregate the public repositories and members of this organization instead of the authenticated user")
+		metricsPath         = flags.String("metrics", filepath.Join(os.TempDir(), workflow.DefaultMetricsFile), "Metrics file written by fetch and read by render, readme and commit")
 	)
-	flag.Parse()
+	flags.Parse(args)
+
+	// Configure log format first so that every message uses it
+	formatter, err := logger.NewFormatter(*logFormat)
+	if err != nil {
+		logger.Error("Invalid log-format value: %v", err)
+		os.Exit(1)
+	}
+	logger.DefaultLogger.SetFormatter(formatter)
+	if _, ok := formatter.(*logger.ActionsFormatter); ok {
+		// Workflow commands are read from stdout
+		logger.DefaultLogger.SetOutput(os.Stdout)
+	}
+
+	if !(*pngScale >= 0 && *pngScale <= generator.MaxPNGScale) {
+		logger.Error("Invalid png-scale value (%g). Use 0 or a number up to %g", *pngScale, generator.MaxPNGScale)
+		os.Exit(1)
+	}
+
+	if *outputFormat != workflow.OutputFormatSVG && *outputFormat != workflow.OutputFormatText {
+		logger.Error("Invalid format value (%s). Use svg or text", *outputFormat)
+		os.Exit(1)
+	}
+
+	if *maxRepos < 0 {
+		logger.Error("Invalid max-repos value (%d). Use 0 or a positive number", *maxRepos)
+		os.Exit(1)
+	}
+
+	chartNames, err := workflow.ParseCharts(*charts)
+	if err != nil {
+		logger.Error("Invalid charts value: %v", err)
+		os.Exit(1)
+	}
+
+	if _, err := generator.ThemeByName(*theme); err != nil {
+		logger.Error("Invalid theme value (%s). Use dark or light", *theme)
+		os.Exit(1)
+	}
+
+	if _, err := time.LoadLocation(*timezone); err != nil {
+		logger.Error("Invalid timezone value (%s): %v", *timezone, err)
+		os.Exit(1)
+	}
+
+	if *assetsBranch != "" && (command == commandRender || command == commandReadme || command == commandCommit) {
+		logger.Error("Assets-branch is only supported by the run command")
+		os.Exit(1)
+	}
+
+	if *assetsRepo != "" && *assetsBranch == "" {
+		logger.Error("Assets-repo requires assets-branch")
+		os.Exit(1)
+	}
+
+	if _, err := (repository.ClientConfig{CABundle: *caBundle, Proxy: *proxy}).HTTPClient(); err != nil {
+		logger.Error("Invalid GitHub API connection settings: %v", err)
+		os.Exit(1)
+	}
+
+	var localEmailList []string
+	if *localRepos != "" {
+		if info, err := os.Stat(*localRepos); err != nil || !info.IsDir() {
+			logger.Error("Invalid local-repos value (%s). Use a directory of git clones", *localRepos)
+			os.Exit(1)
+		}
+		localEmailList = parseList(*localEmails)
+		if len(localEmailList) == 0 {
+			logger.Error("Local-emails is required when local-repos is set")
+			os.Exit(1)
+		}
+	}
+
+	// contributionsCollection covers at most one year
+	if *teamDays < 1 || *teamDays > 365 {
+		logger.Error("Invalid team-days value (%d). Use 1 to 365", *teamDays)
+		os.Exit(1)
+	}
+
+	if _, err := git.NewBackend(*gitBackend); err != nil {
+		logger.Error("Invalid git-backend value (%s). Use auto, exec or native", *gitBackend)
+		os.Exit(1)
+	}
 
-	fmt.Println("update-gh-profile: GitHub profile auto-update tool")
-	fmt.Println("Initialization complete")
+	author, err := git.ParseIdentity(*commitAuthor)
+	if err != nil {
+		logger.Error("Invalid commit-author value: %v", err)
+		os.Exit(1)
+	}
 
-	// Load configuration
-	cfg, err := config.Load()
+	committer, err := git.ParseIdentity(*commitCommitter)
 	if err != nil {
-		fmt.Printf("Error: failed to load configuration: %v\n", err)
+		logger.Error("Invalid commit-committer value: %v", err)
 		os.Exit(1)
 	}
 
-	if err := cfg.Validate(); err != nil {
-		fmt.Printf("Error: failed to validate configuration: %v\n", err)
+	commitOptions := git.CommitOptions{
+		Author:            author,
+		Committer:         committer,
+		SigningFormat:     *signingFormat,
+		SigningKey:        *signingKey,
+		SigningPassphrase: os.Getenv("SIGNING_KEY_PASSPHRASE"),
+	}
+	if err := commitOptions.Validate(); err != nil {
+		logger.Error("Invalid commit signing configuration: %v", err)
 		os.Exit(1)
 	}
 
-	fmt.Println("✓ GitHub Token is set")
+	logger.Print("update-gh-profile: GitHub profile auto-update tool")
+	logger.Print("Initialization complete")
+
+	// Load configuration (render and readme only work on local files and don't need a token)
+	var token, gitLabToken, giteaToken string
+	var tokenSource oauth2.TokenSource
+	var accounts []workflow.Account
+	if command != commandRender && command != commandReadme {
+		cfg, err := config.Load()
+		if err != nil {
+			logger.Error("Failed to load configuration: %v", err)
+			os.Exit(1)
+		}
+
+		// Mask the token and configured secrets in every log message
+		logger.AddSecrets(cfg.GitHubToken, cfg.GitLabToken, cfg.GiteaToken, cfg.AppPrivateKey)
+		logger.AddSecrets(cfg.Secrets...)
+		for _, account := range cfg.Accounts {
+			logger.AddSecrets(account.Token)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			logger.Error("Failed to validate configuration: %v", err)
+			os.Exit(1)
+		}
+
+		if cfg.UsesApp() {
+			// Installation tokens act as the app, not as a user, so the profile must be an organization
+			if *organization == "" {
+				logger.Error("GitHub App authentication requires --org (installation tokens don't belong to a user)")
+				os.Exit(1)
+			}
+			source, err := newAppTokenSource(cfg, *apiURL, *graphQLURL, *caBundle, *proxy)
+			if err != nil {
+				logger.Error("Failed to configure GitHub App: %v", err)
+				os.Exit(1)
+			}
+			appToken, err := source.Token()
+			if err != nil {
+				logger.Error("Failed to authenticate as GitHub App: %v", err)
+				os.Exit(1)
+			}
+			logger.Print("✓ GitHub App is set (installation token expires at %s)", appToken.Expiry.Format(time.RFC3339))
+			token = appToken.AccessToken
+			tokenSource = source
+		} else {
+			logger.Print("✓ GitHub Token is set")
+			token = cfg.GitHubToken
+		}
+
+		for _, account := range cfg.Accounts {
+			accounts = append(accounts, workflow.Account{Token: account.Token, APIURL: account.APIURL})
+		}
+		if len(accounts) > 0 {
+			logger.Print("✓ %d additional GitHub accounts are set", len(accounts))
+		}
+
+		if cfg.GitLabToken != "" {
+			logger.Print("✓ GitLab Token is set")
+			gitLabToken = cfg.GitLabToken
+		}
+
+		if cfg.GiteaToken != "" {
+			if *giteaURL == "" {
+				logger.Error("Gitea-url is required when GITEA_TOKEN is set")
+				os.Exit(1)
+			}
+			logger.Print("✓ Gitea Token is set")
+			giteaToken = cfg.GiteaToken
+		}
+	}
 
 	// Create context
 	ctx := context.Background()
@@ -46,7 +300,7 @@ func main() {
 	// Configure fork exclusion
 	excludeForks, err := strconv.ParseBool(*excludeForksStr)
 	if err != nil {
-		fmt.Printf("Warning: invalid exclude-forks value (%s). Using default value true\n", *excludeForksStr)
+		logger.Warning("Invalid exclude-forks value (%s). Using default value true", *excludeForksStr)
 		excludeForks = true
 	}
 
@@ -54,13 +308,13 @@ func main() {
 	var excludedLanguages []string
 	if excludeLanguagesEnv := os.Getenv("EXCLUDE_LANGUAGES"); excludeLanguagesEnv != "" {
 		// Load from environment variable
-		excludedLanguages = parseLanguageList(excludeLanguagesEnv)
+		excludedLanguages = parseList(excludeLanguagesEnv)
 	} else if *excludeLanguagesStr != "" {
 		// Load from command line argument
-		excludedLanguages = parseLanguageList(*excludeLanguagesStr)
+		excludedLanguages = parseList(*excludeLanguagesStr)
 	}
 
-	fmt.Println("\n✅ GitHub API client initialization successful!")
+	logger.Print("✅ GitHub API client initialization successful!")
 
 	// Configure log level (load from environment variable)
 	logLevelStr := os.Getenv("LOG_LEVEL")
@@ -72,30 +326,144 @@ func main() {
 	// Workflow configuration
 	// Set RepoPath to empty string to automatically use GITHUB_WORKSPACE in GitHub Actions environment
 	workflowConfig := workflow.Config{
-		RepoPath:          "",                                     // Empty string = automatically use GITHUB_WORKSPACE in GitHub Actions environment
-		SVGOutputDir:      ".",                                    // Output directory for SVG files
-		Timezone:          "UTC",                                  // Timezone
-		CommitMessage:     "chore: update GitHub profile metrics", // Git commit message
-		MaxRepositories:   0,                                      // 0 = all repositories
+		RepoPath:          "",             // Empty string = automatically use GITHUB_WORKSPACE in GitHub Actions environment
+		ReadmePath:        *readmePath,    // README.md path relative to the repository
+		SVGOutputDir:      *outputDir,     // Output directory for SVG files (relative to README.md)
+		Timezone:          *timezone,      // Timezone
+		CommitMessage:     *commitMessage, // Git commit message template
+		MaxRepositories:   *maxRepos,      // 0 = all repositories
 		ExcludeForks:      excludeForks,
 		ExcludedLanguages: excludedLanguages, // List of languages to exclude
 		LogLevel:          logLevel,          // Log level
+		TemplatePath:      *templatePath,     // README template (empty = auto-detect README.tmpl.md)
+		OutputFormat:      *outputFormat,     // svg or text
+		PNGScale:          *pngScale,         // PNG scale (0 = only sections with format=png)
+		SkipUnchanged:     *skipUnchanged,    // Skip commit if metrics didn't change materially
+		ChangeThresholds: snapshot.Thresholds{
+			LanguagePercentage: *changePercent,
+			Count:              *changeCount,
+		},
+		PullRequest:       *pullRequest,       // Open/update a pull request instead of pushing directly
+		PullRequestBranch: *pullRequestBranch, // Branch used in pull request mode
+		PullRequestBase:   *pullRequestBase,   // Base branch (empty = current branch)
+		GitHubAPIURL:      *apiURL,            // Set automatically in GitHub Actions (empty = https://api.github.com/)
+		GitHubGraphQLURL:  *graphQLURL,        // Set automatically in GitHub Actions (empty = derived from the REST API URL)
+		TokenSource:       tokenSource,        // Refreshes GitHub App installation tokens (nil = static token)
+		CABundle:          *caBundle,          // Additional CA certificates (e.g., GitHub Enterprise Server with an internal CA)
+		Proxy:             *proxy,             // Proxy for the GitHub API (empty = environment variables)
+		GitBackend:        *gitBackend,        // auto, exec or native
+		CommitOptions:     commitOptions,      // Commit identity and signing
+		PushAttempts:      *pushAttempts,      // Retry pushes rejected as non-fast-forward
+		AssetsBranch:      *assetsBranch,      // Branch for images (empty = commit with README.md)
+		AssetsRepository:  *assetsRepo,        // Repository of the assets branch (empty = this repository)
+		GitHubServerURL:   *serverURL,         // Set automatically in GitHub Actions (empty = https://github.com)
+		GitLabURL:         *gitLabURL,         // GitLab API (empty = gitlab.com)
+		GitLabToken:       gitLabToken,        // Aggregate GitLab projects too (empty = GitHub only)
+		Accounts:          accounts,           // Additional GitHub accounts merged into the profile
+		Organization:      *organization,      // Organization profile mode (empty = authenticated user)
+		Team:              parseList(*team),   // Team leaderboard users (empty = no leaderboard)
+		TeamDays:          *teamDays,          // Team leaderboard period
+		GiteaURL:          *giteaURL,          // Gitea or Forgejo server
+		GiteaToken:        giteaToken,         // Aggregate Gitea repositories too (empty = GitHub only)
+		LocalRepositories: *localRepos,        // Scan local clones too (empty = GitHub only)
+		LocalEmails:       localEmailList,     // Author emails in local clones
+		Charts:            chartNames,         // Charts to generate (empty = all)
+		Theme:             *theme,             // Default chart theme
+		DryRun:            *dryRun,            // Don't commit or push
 	}
 
 	// Execute workflow
-	fmt.Println("\n🚀 Starting main workflow...")
-	err = workflow.Run(ctx, cfg.GitHubToken, workflowConfig)
+	logger.Group("🚀 Starting main workflow...")
+	err = runCommand(ctx, command, token, *metricsPath, workflowConfig)
 	if err != nil {
-		fmt.Printf("Error: failed to execute workflow: %v\n", err)
+		logger.Error("Failed to execute workflow: %v", err)
 		os.Exit(1)
 	}
 
-	fmt.Println("\n✅ All processing completed!")
+	logger.EndGroup()
+	logger.Print("✅ All processing completed!")
 	os.Exit(0)
 }
 
-// parseLanguageList converts a comma-separated language name string to a slice
-func parseLanguageList(languagesStr string) []string {
+// newAppTokenSource creates the installation token source of the configured GitHub App
+// Without an installation ID, the installation is looked up from GITHUB_REPOSITORY
+func newAppTokenSource(cfg *config.Config, apiURL, graphQLURL, caBundle, proxy string) (*githubapp.TokenSource, error) {
+	httpClient, err := repository.ClientConfig{CABundle: caBundle, Proxy: proxy}.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	restURL, _ := repository.ClientConfig{RESTURL: apiURL, GraphQLURL: graphQLURL}.Endpoints()
+
+	return githubapp.NewTokenSource(githubapp.Config{
+		AppID:          cfg.AppID,
+		PrivateKey:     cfg.AppPrivateKey,
+		InstallationID: cfg.AppInstallationID,
+		Repository:     os.Getenv("GITHUB_REPOSITORY"),
+		APIURL:         restURL,
+		HTTPClient:     httpClient,
+	})
+}
+
+// runCommand executes the stages of a command
+func runCommand(ctx context.Context, command, token, metricsPath string, workflowConfig workflow.Config) error {
+	switch command {
+	case commandFetch:
+		result, err := workflow.Fetch(ctx, token, workflowConfig)
+		if err != nil {
+			return err
+		}
+		if err := workflow.SaveFetchResult(metricsPath, result); err != nil {
+			return err
+		}
+		logger.Print("✅ Saved metrics to %s", metricsPath)
+		return nil
+	case commandRender:
+		result, err := workflow.LoadFetchResult(metricsPath)
+		if err != nil {
+			return err
+		}
+		return workflow.Render(workflowConfig, result)
+	case commandReadme:
+		// Metrics are optional, they are only needed by text sections and README templates
+		result, err := workflow.LoadFetchResult(metricsPath)
+		if errors.Is(err, workflow.ErrMetricsNotFound) {
+			logger.Info("Metrics file not found, only embedding chart images: %s", metricsPath)
+			result = nil
+		} else if err != nil {
+			return err
+		}
+		return workflow.UpdateReadme(workflowConfig, result)
+	case commandCommit:
+		result, err := workflow.LoadFetchResult(metricsPath)
+		if err != nil {
+			return err
+		}
+		return workflow.Commit(ctx, token, workflowConfig, result)
+	default:
+		return workflow.Run(ctx, token, workflowConfig)
+	}
+}
+
+// isCommand checks if name is a known command
+func isCommand(name string) bool {
+	for _, usage := range commandUsages {
+		if usage.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// printUsage writes the list of commands
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: update-gh-profile [command] [flags]\n\nCommands:\n")
+	for _, usage := range commandUsages {
+		fmt.Fprintf(w, "  %-8s %s\n", usage.name, usage.description)
+	}
+}
+
+// parseList converts a comma-separated string (language names, emails) to a slice
+func parseList(languagesStr string) []string {
 	if languagesStr == "" {
 		return []string{}
 	}
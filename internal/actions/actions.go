@@ -0,0 +1,92 @@
+package actions
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Output step output written to $GITHUB_OUTPUT
+type Output struct {
+	Name  string
+	Value string
+}
+
+// SetOutputs writes step outputs to the file of $GITHUB_OUTPUT
+//
+// Preconditions:
+// - outputs have names declared in action.yml
+//
+// Postconditions:
+// - Outputs are appended in order (multi-line values use the delimiter syntax)
+// - Does nothing if $GITHUB_OUTPUT is not set (not running in GitHub Actions)
+//
+// Invariants:
+// - Existing content of the file is kept
+func SetOutputs(outputs []Output) error {
+	path := os.Getenv("GITHUB_OUTPUT")
+	if path == "" {
+		return nil
+	}
+
+	var content strings.Builder
+	for _, output := range outputs {
+		if !strings.ContainsAny(output.Value, "\r\n") {
+			fmt.Fprintf(&content, "%s=%s\n", output.Name, output.Value)
+			continue
+		}
+
+		delimiter, err := newDelimiter()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&content, "%s<<%s\n%s\n%s\n", output.Name, delimiter, output.Value, delimiter)
+	}
+
+	return appendFile(path, content.String())
+}
+
+// AppendSummary appends Markdown to the job summary ($GITHUB_STEP_SUMMARY)
+//
+// Preconditions:
+// - markdown is GitHub Flavored Markdown
+//
+// Postconditions:
+// - markdown is appended to the job summary
+// - Does nothing if $GITHUB_STEP_SUMMARY is not set (not running in GitHub Actions)
+func AppendSummary(markdown string) error {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return nil
+	}
+
+	if !strings.HasSuffix(markdown, "\n") {
+		markdown += "\n"
+	}
+	return appendFile(path, markdown)
+}
+
+// newDelimiter returns a random delimiter of multi-line output values
+func newDelimiter() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate output delimiter: %w", err)
+	}
+	return "ghadelimiter_" + hex.EncodeToString(b), nil
+}
+
+// appendFile appends content to a file written by GitHub Actions
+func appendFile(path, content string) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(content); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package actions
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestSetOutputs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "output")
+	if err := os.WriteFile(path, []byte("existing=1\n"), 0644); err != nil {
+		t.Fatalf("failed to create output file: %v", err)
+	}
+	t.Setenv("GITHUB_OUTPUT", path)
+
+	err := SetOutputs([]Output{
+		{Name: "changed", Value: "true"},
+		{Name: "top_language", Value: "Go"},
+		{Name: "changes", Value: "stars +3\ncommits +42"},
+	})
+	if err != nil {
+		t.Fatalf("SetOutputs() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	want := regexp.MustCompile(`^existing=1\nchanged=true\ntop_language=Go\nchanges<<(ghadelimiter_[0-9a-f]{32})\nstars \+3\ncommits \+42\n(ghadelimiter_[0-9a-f]{32})\n$`)
+	matches := want.FindStringSubmatch(string(content))
+	if matches == nil || matches[1] != matches[2] {
+		t.Errorf("output file = %q", content)
+	}
+}
+
+func TestAppendSummary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv("GITHUB_STEP_SUMMARY", path)
+
+	for _, markdown := range []string{"## Profile metrics", "| Stars | 10 |\n"} {
+		if err := AppendSummary(markdown); err != nil {
+			t.Fatalf("AppendSummary() error = %v", err)
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read summary file: %v", err)
+	}
+	if want := "## Profile metrics\n| Stars | 10 |\n"; string(content) != want {
+		t.Errorf("summary = %q, want %q", content, want)
+	}
+}
+
+func TestNotInActions(t *testing.T) {
+	t.Setenv("GITHUB_OUTPUT", "")
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+
+	if err := SetOutputs([]Output{{Name: "changed", Value: "true"}}); err != nil {
+		t.Errorf("SetOutputs() error = %v", err)
+	}
+	if err := AppendSummary("## Profile metrics"); err != nil {
+		t.Errorf("AppendSummary() error = %v", err)
+	}
+}
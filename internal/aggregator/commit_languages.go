@@ -1,9 +1,10 @@
 package aggregator
 
 import (
-	"log"
 	"sort"
 	"strings"
+
+	"github.com/watsumi/update-gh-profile/internal/logger"
 )
 
 // AggregateCommitLanguages aggregates top 5 languages used per commit
@@ -21,7 +22,7 @@ import (
 // - Sorted by usage count in descending order, top 5 are returned
 // - Excluded languages are excluded from aggregation
 func AggregateCommitLanguages(commitLanguages map[string]map[string]int, excludedLanguages []string) map[string]int {
-	log.Printf("Starting aggregation of language usage per commit: %d commits", len(commitLanguages))
+	logger.Info("Starting aggregation of language usage per commit: %d commits", len(commitLanguages))
 
 	// Convert exclusion list to map for case-insensitive comparison
 	excludedMap := make(map[string]bool)
@@ -33,7 +34,7 @@ func AggregateCommitLanguages(commitLanguages map[string]map[string]int, exclude
 		}
 	}
 	if len(excludedMap) > 0 {
-		log.Printf("Excluded languages (normalized): %v", excludedMap)
+		logger.Info("Excluded languages (normalized): %v", excludedMap)
 	}
 
 	// Map to aggregate usage count per language
@@ -46,24 +47,24 @@ func AggregateCommitLanguages(commitLanguages map[string]map[string]int, exclude
 		if len(commitSHA) > 7 {
 			shaDisplay = commitSHA[:7]
 		}
-		log.Printf("  Commit %s: %d languages used", shaDisplay, len(langs))
+		logger.Info("  Commit %s: %d languages used", shaDisplay, len(langs))
 		for lang, count := range langs {
 			// Skip excluded languages (case-insensitive comparison)
 			normalized := strings.ToLower(strings.TrimSpace(lang))
 			if excludedMap[normalized] {
-				log.Printf("    Excluding language: %s (normalized: %s)", lang, normalized)
+				logger.Info("    Excluding language: %s (normalized: %s)", lang, normalized)
 				continue
 			}
 			languageCounts[lang] += count
 		}
 	}
 
-	log.Printf("Language usage count aggregation completed: %d languages", len(languageCounts))
+	logger.Info("Language usage count aggregation completed: %d languages", len(languageCounts))
 
 	// Sort by usage count and extract top 5
 	top5 := extractTop5Languages(languageCounts)
 
-	log.Printf("Top 5 languages by commit aggregation completed: %d languages", len(top5))
+	logger.Info("Top 5 languages by commit aggregation completed: %d languages", len(top5))
 	return top5
 }
 
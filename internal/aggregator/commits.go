@@ -1,8 +1,9 @@
 package aggregator
 
 import (
-	"log"
 	"sort"
+
+	"github.com/watsumi/update-gh-profile/internal/logger"
 )
 
 // AggregateCommitHistory aggregates commit counts by date
@@ -17,20 +18,20 @@ import (
 // Invariants:
 // - Commit counts per date from all repositories are summed
 func AggregateCommitHistory(commitHistories map[string]map[string]int) map[string]int {
-	log.Printf("Starting commit history aggregation: %d repositories", len(commitHistories))
+	logger.Info("Starting commit history aggregation: %d repositories", len(commitHistories))
 
 	// Map to store total commit counts per date
 	aggregated := make(map[string]int)
 
 	// Aggregate commit history for each repository
 	for repoName, history := range commitHistories {
-		log.Printf("  %s: aggregating commit history for %d days", repoName, len(history))
+		logger.Info("  %s: aggregating commit history for %d days", repoName, len(history))
 		for date, count := range history {
 			aggregated[date] += count
 		}
 	}
 
-	log.Printf("Commit history aggregation completed: %d days", len(aggregated))
+	logger.Info("Commit history aggregation completed: %d days", len(aggregated))
 	return aggregated
 }
 
@@ -85,25 +86,25 @@ type DateCommitPair struct {
 // Invariants:
 // - Commit counts per time slot from all repositories are summed
 func AggregateCommitTimeDistribution(timeDistributions map[string]map[int]int) map[int]int {
-	log.Printf("Starting commit time distribution aggregation: %d repositories", len(timeDistributions))
+	logger.Info("Starting commit time distribution aggregation: %d repositories", len(timeDistributions))
 
 	// Map to store total commit counts per time slot (0-23 hours)
 	aggregated := make(map[int]int)
 
 	// Aggregate time distribution for each repository
 	for repoName, distribution := range timeDistributions {
-		log.Printf("  %s: aggregating data for %d time slots", repoName, len(distribution))
+		logger.Info("  %s: aggregating data for %d time slots", repoName, len(distribution))
 		for hour, count := range distribution {
 			// Verify time slot is within 0-23 range
 			if hour < 0 || hour > 23 {
-				log.Printf("Warning: time slot %d for repository %s is out of range. Skipping", hour, repoName)
+				logger.Warning("time slot %d for repository %s is out of range. Skipping", hour, repoName)
 				continue
 			}
 			aggregated[hour] += count
 		}
 	}
 
-	log.Printf("Commit time distribution aggregation completed: %d time slots", len(aggregated))
+	logger.Info("Commit time distribution aggregation completed: %d time slots", len(aggregated))
 	return aggregated
 }
 
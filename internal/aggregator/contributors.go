@@ -0,0 +1,48 @@
+package aggregator
+
+import (
+	"sort"
+
+	"github.com/watsumi/update-gh-profile/internal/logger"
+)
+
+// RankContributors ranks contributors by their total contributions
+//
+// Preconditions:
+// - contributors is a slice of contributor statistics (order does not matter)
+// - maxItems is the maximum number of contributors returned (0 = all)
+//
+// Postconditions:
+// - Returns contributors sorted by total contributions (commits + pull requests + reviews) in descending order
+// - Contributors without contributions are excluded
+//
+// Invariants:
+// - When totals are the same, sorted by commits, then by login in dictionary order
+// - The input slice is not modified
+func RankContributors(contributors []ContributorStat, maxItems int) []ContributorStat {
+	logger.Info("Starting contributor ranking: %d contributors", len(contributors))
+
+	ranked := make([]ContributorStat, 0, len(contributors))
+	for _, contributor := range contributors {
+		if contributor.Total() > 0 {
+			ranked = append(ranked, contributor)
+		}
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Total() != ranked[j].Total() {
+			return ranked[i].Total() > ranked[j].Total()
+		}
+		if ranked[i].Commits != ranked[j].Commits {
+			return ranked[i].Commits > ranked[j].Commits
+		}
+		return ranked[i].Login < ranked[j].Login
+	})
+
+	if maxItems > 0 && len(ranked) > maxItems {
+		ranked = ranked[:maxItems]
+	}
+
+	logger.Info("Contributor ranking completed: %d contributors", len(ranked))
+	return ranked
+}
@@ -0,0 +1,50 @@
+package aggregator
+
+import (
+	"testing"
+)
+
+func TestRankContributors(t *testing.T) {
+	contributors := []ContributorStat{
+		{Login: "carol", Commits: 2, PullRequests: 1, Reviews: 1},
+		{Login: "alice", Commits: 10, PullRequests: 3, Reviews: 5},
+		{Login: "idle"},
+		{Login: "bob", Commits: 3, PullRequests: 1},
+		{Login: "dave", Reviews: 4},
+	}
+
+	tests := []struct {
+		name      string
+		maxItems  int
+		wantOrder []string
+	}{
+		{
+			name:      "All contributors",
+			maxItems:  0,
+			wantOrder: []string{"alice", "bob", "carol", "dave"},
+		},
+		{
+			name:      "Top 2",
+			maxItems:  2,
+			wantOrder: []string{"alice", "bob"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RankContributors(contributors, tt.maxItems)
+			if len(got) != len(tt.wantOrder) {
+				t.Fatalf("RankContributors() returned %d contributors, want %d", len(got), len(tt.wantOrder))
+			}
+			for i, login := range tt.wantOrder {
+				if got[i].Login != login {
+					t.Errorf("RankContributors()[%d] = %s, want %s", i, got[i].Login, login)
+				}
+			}
+		})
+	}
+
+	if contributors[0].Login != "carol" {
+		t.Error("RankContributors() should not modify the input")
+	}
+}
@@ -2,10 +2,11 @@ package aggregator
 
 import (
 	"fmt"
-	"log"
 	"sort"
 	"strings"
 
+	"github.com/watsumi/update-gh-profile/internal/logger"
+
 	"github.com/google/go-github/v76/github"
 )
 
@@ -23,7 +24,7 @@ import (
 // - Data for the same language is summed
 // - Forked repositories are excluded
 func AggregateLanguages(repositories []*github.Repository, languageData map[string]map[string]int) map[string]int {
-	log.Printf("Starting language data aggregation: %d repositories", len(repositories))
+	logger.Info("Starting language data aggregation: %d repositories", len(repositories))
 
 	// Map to aggregate total bytes per language
 	languageTotals := make(map[string]int)
@@ -57,7 +58,7 @@ func AggregateLanguages(repositories []*github.Repository, languageData map[stri
 		}
 	}
 
-	log.Printf("Language data aggregation completed: %d languages", len(languageTotals))
+	logger.Info("Language data aggregation completed: %d languages", len(languageTotals))
 	return languageTotals
 }
 
@@ -72,6 +73,7 @@ func AggregateLanguages(repositories []*github.Repository, languageData map[stri
 //
 // Invariants:
 // - Total percentage equals 100% (excluding rounding errors)
+// - Languages with the same bytes are sorted by name (order doesn't depend on map iteration)
 func RankLanguages(languageTotals map[string]int) []LanguageStat {
 	if len(languageTotals) == 0 {
 		return []LanguageStat{}
@@ -84,7 +86,7 @@ func RankLanguages(languageTotals map[string]int) []LanguageStat {
 	}
 
 	if totalBytes == 0 {
-		log.Printf("Warning: total bytes is 0")
+		logger.Warning("total bytes is 0")
 		return []LanguageStat{}
 	}
 
@@ -99,12 +101,15 @@ func RankLanguages(languageTotals map[string]int) []LanguageStat {
 		})
 	}
 
-	// Sort by bytes in descending order
+	// Sort by bytes in descending order (ascending by language name when bytes are equal)
 	sort.Slice(ranked, func(i, j int) bool {
-		return ranked[i].Bytes > ranked[j].Bytes
+		if ranked[i].Bytes != ranked[j].Bytes {
+			return ranked[i].Bytes > ranked[j].Bytes
+		}
+		return ranked[i].Language < ranked[j].Language
 	})
 
-	log.Printf("Language ranking generation completed: %d languages (total bytes: %d)", len(ranked), totalBytes)
+	logger.Info("Language ranking generation completed: %d languages (total bytes: %d)", len(ranked), totalBytes)
 	return ranked
 }
 
@@ -121,7 +126,7 @@ func RankLanguages(languageTotals map[string]int) []LanguageStat {
 // - Original slice order is preserved
 func FilterMinorLanguages(rankedLanguages []LanguageStat, threshold float64) []LanguageStat {
 	if threshold < 0 || threshold > 100 {
-		log.Printf("Warning: threshold is out of range (%f). Including all languages", threshold)
+		logger.Warning("threshold is out of range (%f). Including all languages", threshold)
 		return rankedLanguages
 	}
 
@@ -132,7 +137,7 @@ func FilterMinorLanguages(rankedLanguages []LanguageStat, threshold float64) []L
 		}
 	}
 
-	log.Printf("Filtering by threshold (%.2f%%) completed: %d languages → %d languages", threshold, len(rankedLanguages), len(filtered))
+	logger.Info("Filtering by threshold (%.2f%%) completed: %d languages → %d languages", threshold, len(rankedLanguages), len(filtered))
 	return filtered
 }
 
@@ -172,6 +177,6 @@ func FilterExcludedLanguages(rankedLanguages []LanguageStat, excludedLanguages [
 		}
 	}
 
-	log.Printf("Filtering by excluded languages completed: %d languages → %d languages (excluded: %v)", len(rankedLanguages), len(filtered), excludedLanguages)
+	logger.Info("Filtering by excluded languages completed: %d languages → %d languages (excluded: %v)", len(rankedLanguages), len(filtered), excludedLanguages)
 	return filtered
 }
@@ -2,27 +2,49 @@ package aggregator
 
 // LanguageStat language statistics
 type LanguageStat struct {
-	Language        string  // Language name
-	Bytes           int     // Total bytes
-	Percentage      float64 // Percentage of total
-	RepositoryCount int     // Number of repositories where used
+	Language        string  `json:"language"`                   // Language name
+	Bytes           int     `json:"bytes"`                      // Total bytes
+	Percentage      float64 `json:"percentage"`                 // Percentage of total
+	RepositoryCount int     `json:"repository_count,omitempty"` // Number of repositories where used
 }
 
 // SummaryStats summary statistics
 type SummaryStats struct {
-	TotalStars        int // Total stars
-	RepositoryCount   int // Repository count
-	TotalCommits      int // Total commits
-	TotalPullRequests int // Total pull requests
+	TotalStars        int `json:"total_stars"`         // Total stars
+	RepositoryCount   int `json:"repository_count"`    // Repository count
+	TotalCommits      int `json:"total_commits"`       // Total commits
+	TotalPullRequests int `json:"total_pull_requests"` // Total pull requests
+}
+
+// ContributorStat contribution statistics of a contributor
+type ContributorStat struct {
+	Login        string `json:"login"`         // User name
+	Commits      int    `json:"commits"`       // Commit contributions
+	PullRequests int    `json:"pull_requests"` // Pull requests opened
+	Reviews      int    `json:"reviews"`       // Pull request reviews
+}
+
+// Total returns the sum of commits, pull requests and reviews
+func (c ContributorStat) Total() int {
+	return c.Commits + c.PullRequests + c.Reviews
+}
+
+// Leaderboard team leaderboard over a period
+type Leaderboard struct {
+	Days    int               `json:"days"`             // Length of the period in days (ending at the fetch time)
+	Entries []ContributorStat `json:"entries"`          // Ranked users
+	Failed  []string          `json:"failed,omitempty"` // Users whose contributions could not be fetched
 }
 
 // AggregatedMetrics aggregated metrics
 type AggregatedMetrics struct {
-	Languages              []LanguageStat // Ranked language slice
-	TotalBytes             int            // Total bytes for all languages
-	RepositoryCount        int            // Number of target repositories
-	CommitHistory          map[string]int // Commit count per date
-	CommitTimeDistribution map[int]int    // Commit count per time slot
-	CommitLanguages        map[string]int // Top 5 languages by commit
-	SummaryStats           SummaryStats   // Summary statistics
+	Languages              []LanguageStat    `json:"languages"`                // Ranked language slice
+	TotalBytes             int               `json:"total_bytes"`              // Total bytes for all languages
+	RepositoryCount        int               `json:"repository_count"`         // Number of target repositories
+	CommitHistory          map[string]int    `json:"commit_history"`           // Commit count per date
+	CommitTimeDistribution map[int]int       `json:"commit_time_distribution"` // Commit count per time slot
+	CommitLanguages        map[string]int    `json:"commit_languages"`         // Top 5 languages by commit
+	SummaryStats           SummaryStats      `json:"summary"`                  // Summary statistics
+	Contributors           []ContributorStat `json:"contributors,omitempty"`   // Ranked contributors (organization mode only)
+	Leaderboard            *Leaderboard      `json:"leaderboard,omitempty"`    // Team leaderboard (only when a team is configured)
 }
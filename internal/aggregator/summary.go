@@ -1,7 +1,7 @@
 package aggregator
 
 import (
-	"log"
+	"github.com/watsumi/update-gh-profile/internal/logger"
 
 	"github.com/google/go-github/v76/github"
 )
@@ -20,7 +20,7 @@ import (
 // - Sums values from all repositories
 // - Fork repositories are excluded (assumes already excluded in repositories)
 func AggregateSummaryStats(repositories []*github.Repository, totalCommits, totalPRs int) SummaryStats {
-	log.Printf("Starting summary statistics aggregation: %d repositories", len(repositories))
+	logger.Info("Starting summary statistics aggregation: %d repositories", len(repositories))
 
 	var stats SummaryStats
 
@@ -42,11 +42,11 @@ func AggregateSummaryStats(repositories []*github.Repository, totalCommits, tota
 	stats.TotalCommits = totalCommits
 	stats.TotalPullRequests = totalPRs
 
-	log.Printf("Summary statistics aggregation completed:")
-	log.Printf("  - Total stars: %d", stats.TotalStars)
-	log.Printf("  - Repository count: %d", stats.RepositoryCount)
-	log.Printf("  - Total commits: %d", stats.TotalCommits)
-	log.Printf("  - Total pull requests: %d", stats.TotalPullRequests)
+	logger.Info("Summary statistics aggregation completed:")
+	logger.Info("  - Total stars: %d", stats.TotalStars)
+	logger.Info("  - Repository count: %d", stats.RepositoryCount)
+	logger.Info("  - Total commits: %d", stats.TotalCommits)
+	logger.Info("  - Total pull requests: %d", stats.TotalPullRequests)
 
 	return stats
 }
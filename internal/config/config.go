@@ -3,8 +3,11 @@ package config
 import (
 	"errors"
 	"fmt"
-	"log"
 	"os"
+	"strconv"
+	"strings"
+
+	"github.com/watsumi/update-gh-profile/internal/logger"
 )
 
 // Config struct to hold application configuration
@@ -13,6 +16,38 @@ type Config struct {
 	// GitHubToken authentication token for GitHub API
 	// Requires permission to read all repositories
 	GitHubToken string
+
+	// AppID App ID (or client ID) of a GitHub App to authenticate as instead of GitHubToken (optional)
+	// When set, installation tokens are minted with AppPrivateKey (see UsesApp)
+	AppID string
+
+	// AppPrivateKey PEM encoded private key of the GitHub App
+	AppPrivateKey string
+
+	// AppInstallationID installation ID of the GitHub App (0 = looked up from the repository)
+	AppInstallationID int64
+
+	// GitLabToken authentication token for GitLab API (optional)
+	// When set, GitLab projects are aggregated together with GitHub repositories
+	GitLabToken string
+
+	// GiteaToken authentication token for Gitea or Forgejo API (optional)
+	// When set, repositories on the Gitea server are aggregated as well
+	GiteaToken string
+
+	// Accounts additional GitHub accounts merged into the profile (e.g. a work account)
+	// Loaded from the GITHUB_ACCOUNTS environment variable (see parseAccounts)
+	Accounts []Account
+
+	// Secrets additional values masked in log output (e.g. tokens of other services)
+	// Loaded from the MASK_SECRETS environment variable (comma or newline separated)
+	Secrets []string
+}
+
+// Account additional GitHub account
+type Account struct {
+	Token  string // GitHub token of the account
+	APIURL string // GitHub API URL (empty = https://api.github.com/)
 }
 
 // Load loads configuration from environment variables
@@ -22,21 +57,54 @@ func Load() (*Config, error) {
 	// In Go, it's common to return structs by pointer
 	cfg := &Config{}
 
-	// Load GITHUB_TOKEN environment variable
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		return nil, errors.New("GITHUB_TOKEN environment variable is not set")
+	// Load GITHUB_TOKEN environment variable (not required when authenticating as a GitHub App)
+	cfg.GitHubToken = os.Getenv("GITHUB_TOKEN")
+	cfg.AppID = os.Getenv("GITHUB_APP_ID")
+	cfg.AppPrivateKey = os.Getenv("GITHUB_APP_PRIVATE_KEY")
+	if cfg.GitHubToken == "" && !cfg.UsesApp() {
+		return nil, errors.New("GITHUB_TOKEN environment variable is not set (or set GITHUB_APP_ID and GITHUB_APP_PRIVATE_KEY)")
+	}
+	if value := os.Getenv("GITHUB_APP_INSTALLATION_ID"); value != "" {
+		installationID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || installationID <= 0 {
+			return nil, fmt.Errorf("invalid GITHUB_APP_INSTALLATION_ID: %s", value)
+		}
+		cfg.AppInstallationID = installationID
+	}
+	cfg.GitLabToken = os.Getenv("GITLAB_TOKEN")
+	cfg.GiteaToken = os.Getenv("GITEA_TOKEN")
+	accounts, err := parseAccounts(os.Getenv("GITHUB_ACCOUNTS"))
+	if err != nil {
+		return nil, err
 	}
-	cfg.GitHubToken = token
+	cfg.Accounts = accounts
+	cfg.Secrets = parseSecrets(os.Getenv("MASK_SECRETS"))
 
 	// Log output: configuration load success (INFO level equivalent)
-	log.Printf("Configuration loaded: token=set (authenticated user will be automatically fetched)")
+	if cfg.UsesApp() {
+		logger.Info("Configuration loaded: GitHub App=%s (installation token will be minted)", cfg.AppID)
+	} else {
+		logger.Info("Configuration loaded: token=set (authenticated user will be automatically fetched)")
+	}
 
 	return cfg, nil
 }
 
+// UsesApp reports whether to authenticate as a GitHub App
+// The GitHub App takes precedence over GitHubToken
+func (c *Config) UsesApp() bool {
+	return c.AppID != ""
+}
+
 // Validate validates configuration values
 func (c *Config) Validate() error {
+	if c.UsesApp() {
+		if strings.TrimSpace(c.AppPrivateKey) == "" {
+			return errors.New("GITHUB_APP_PRIVATE_KEY is required when GITHUB_APP_ID is set")
+		}
+		return nil
+	}
+
 	if c.GitHubToken == "" {
 		return errors.New("GitHubToken is not set")
 	}
@@ -48,3 +116,35 @@ func (c *Config) Validate() error {
 
 	return nil
 }
+
+// parseAccounts parses one account per line: "<token>" or "<token> <api-url>"
+// Empty lines are skipped
+func parseAccounts(value string) ([]Account, error) {
+	var accounts []Account
+	for i, line := range strings.Split(value, "\n") {
+		fields := strings.Fields(line)
+		switch len(fields) {
+		case 0:
+			continue
+		case 1:
+			accounts = append(accounts, Account{Token: fields[0]})
+		case 2:
+			accounts = append(accounts, Account{Token: fields[0], APIURL: fields[1]})
+		default:
+			// The line may contain a token, so it is not included in the message
+			return nil, fmt.Errorf("invalid GITHUB_ACCOUNTS line %d: use \"<token>\" or \"<token> <api-url>\"", i+1)
+		}
+	}
+	return accounts, nil
+}
+
+// parseSecrets splits a comma or newline separated list of secrets
+func parseSecrets(value string) []string {
+	var secrets []string
+	for _, secret := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' || r == '\r' }) {
+		if secret = strings.TrimSpace(secret); secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets
+}
@@ -19,15 +19,39 @@ import (
 // Invariants:
 // - SVG has appropriate size and styling
 func GenerateCommitHistoryChart(commitHistory map[string]int) (string, error) {
+	return GenerateCommitHistoryChartWithOptions(commitHistory, DefaultChartOptions())
+}
+
+// GenerateCommitHistoryChartWithOptions generates a commit history SVG with custom options
+//
+// Preconditions:
+// - commitHistory is in the format map[string]int{date: commit count}
+// - opts are chart options (Theme, MaxItems)
+//
+// Postconditions:
+// - Returns a valid SVG string
+// - If opts.MaxItems is set, only the most recent MaxItems dates are displayed
+//
+// Invariants:
+// - Dates are displayed in ascending order
+func GenerateCommitHistoryChartWithOptions(commitHistory map[string]int, opts ChartOptions) (string, error) {
+	opts = opts.withDefaults()
+	theme := opts.Theme
+
 	if len(commitHistory) == 0 {
-		return generateEmptyChart("Commit History", "No data available"), nil
+		return generateEmptyChartWithTheme("Commit History", "No data available", theme), nil
 	}
 
 	// Sort by date
 	sortedPairs := aggregator.SortCommitHistoryByDate(commitHistory)
 
 	if len(sortedPairs) == 0 {
-		return generateEmptyChart("Commit History", "No data available"), nil
+		return generateEmptyChartWithTheme("Commit History", "No data available", theme), nil
+	}
+
+	// Keep only the most recent dates
+	if opts.MaxItems > 0 && len(sortedPairs) > opts.MaxItems {
+		sortedPairs = sortedPairs[len(sortedPairs)-opts.MaxItems:]
 	}
 
 	// Set SVG size
@@ -80,12 +104,12 @@ func GenerateCommitHistoryChart(commitHistory map[string]int) (string, error) {
 `)
 
 	// Background (with border)
-	svg.WriteString(fmt.Sprintf(`  <rect width="%d" height="%d" fill="%s" rx="10" stroke="#30363d" stroke-width="1"/>
-`, width, height, DefaultBackgroundColor))
+	svg.WriteString(fmt.Sprintf(`  <rect width="%d" height="%d" fill="%s" rx="10" stroke="%s" stroke-width="1"/>
+`, width, height, theme.Background, theme.Border))
 
 	// Title (decorated)
 	svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="20" font-weight="700" fill="%s" text-anchor="middle">📈 Commit History</text>
-`, width/2, 32, AccentColor))
+`, width/2, 32, theme.Accent))
 
 	// Y-axis grid lines and labels
 	gridLines := 5
@@ -95,13 +119,13 @@ func GenerateCommitHistoryChart(commitHistory map[string]int) (string, error) {
 
 		// Grid line
 		if i < gridLines {
-			svg.WriteString(fmt.Sprintf(`  <line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#21262d" stroke-width="1"/>
-`, padding, y, width-padding, y))
+			svg.WriteString(fmt.Sprintf(`  <line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="1"/>
+`, padding, y, width-padding, y, theme.Grid))
 		}
 
 		// Y-axis label
 		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="11" fill="%s" text-anchor="end">%d</text>
-`, padding-10, y+4, DefaultTextColor, value))
+`, padding-10, y+4, theme.Text, value))
 	}
 
 	// Calculate bar chart layout
@@ -156,7 +180,7 @@ func GenerateCommitHistoryChart(commitHistory map[string]int) (string, error) {
 			dateLabel := dateParts[1] + "/" + dateParts[2]
 
 			svg.WriteString(fmt.Sprintf(`  <text x="%.1f" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="10" fill="%s" text-anchor="middle">%s</text>
-`, p.X, height-padding+20, DefaultTextColor, dateLabel))
+`, p.X, height-padding+20, theme.Text, dateLabel))
 		}
 	}
 
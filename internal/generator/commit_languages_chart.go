@@ -18,8 +18,27 @@ import (
 // Invariants:
 // - Only top 5 languages are displayed
 func GenerateCommitLanguagesChart(commitLanguages map[string]int) (string, error) {
+	return GenerateCommitLanguagesChartWithOptions(commitLanguages, DefaultChartOptions())
+}
+
+// GenerateCommitLanguagesChartWithOptions generates a top languages by commit SVG with custom options
+//
+// Preconditions:
+// - commitLanguages is in the format map[string]int{language name: usage count}
+// - opts are chart options (Theme, MaxItems)
+//
+// Postconditions:
+// - Returns a valid SVG string
+// - If opts.MaxItems is set, up to MaxItems languages are displayed (default 5)
+//
+// Invariants:
+// - Chart height grows to fit the number of displayed languages
+func GenerateCommitLanguagesChartWithOptions(commitLanguages map[string]int, opts ChartOptions) (string, error) {
+	opts = opts.withDefaults()
+	theme := opts.Theme
+
 	if len(commitLanguages) == 0 {
-		return generateEmptyChart("Top 5 Languages by Commit", "No data available"), nil
+		return generateEmptyChartWithTheme("Top 5 Languages by Commit", "No data available", theme), nil
 	}
 
 	// Sort by usage count and extract top 5
@@ -32,24 +51,38 @@ func GenerateCommitLanguagesChart(commitLanguages map[string]int) (string, error
 		langList = append(langList, langCount{lang: lang, count: count})
 	}
 
-	// Sort in descending order by usage count
+	// Sort in descending order by usage count (ascending by language name when counts are equal)
 	sort.Slice(langList, func(i, j int) bool {
-		return langList[i].count > langList[j].count
+		if langList[i].count != langList[j].count {
+			return langList[i].count > langList[j].count
+		}
+		return langList[i].lang < langList[j].lang
 	})
 
 	// Get top 5
 	maxItems := 5
+	if opts.MaxItems > 0 {
+		maxItems = opts.MaxItems
+	}
 	if len(langList) > maxItems {
 		langList = langList[:maxItems]
 	}
 
 	if len(langList) == 0 {
-		return generateEmptyChart("Top 5 Languages by Commit", "No data available"), nil
+		return generateEmptyChartWithTheme("Top 5 Languages by Commit", "No data available", theme), nil
 	}
 
-	// Set SVG size
+	// Bar layout
+	barHeight := 30
+	barSpacing := 45
+	startY := 70
+
+	// Set SVG size (grow beyond the default height when more than 5 languages are displayed)
 	width := DefaultSVGWidth
 	height := 280
+	if requiredHeight := startY + (len(langList)-1)*barSpacing + 30; requiredHeight > height {
+		height = requiredHeight
+	}
 	padding := 20
 	chartWidth := width - padding*2
 
@@ -94,17 +127,14 @@ func GenerateCommitLanguagesChart(commitLanguages map[string]int) (string, error
 `)
 
 	// Background (with border)
-	svg.WriteString(fmt.Sprintf(`  <rect width="%d" height="%d" fill="%s" rx="10" stroke="#30363d" stroke-width="1"/>
-`, width, height, DefaultBackgroundColor))
+	svg.WriteString(fmt.Sprintf(`  <rect width="%d" height="%d" fill="%s" rx="10" stroke="%s" stroke-width="1"/>
+`, width, height, theme.Background, theme.Border))
 
 	// Title (decorated)
 	svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="20" font-weight="700" fill="%s" text-anchor="middle">💻 Top 5 Languages by Commit</text>
-`, width/2, 37, AccentColor))
+`, width/2, 37, theme.Accent))
 
 	// Display bar chart
-	barHeight := 30
-	barSpacing := 45
-	startY := 70
 	barMaxWidth := chartWidth - 200 // Reserve space for language name and count
 
 	for i, item := range langList {
@@ -115,12 +145,12 @@ func GenerateCommitLanguagesChart(commitLanguages map[string]int) (string, error
 
 		// Language name
 		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="14" fill="%s">%s</text>
-`, padding, yPos+5, DefaultTextColor, escapeXML(item.lang)))
+`, padding, yPos+5, theme.Text, escapeXML(item.lang)))
 
 		// Bar background
 		barX := 140
-		svg.WriteString(fmt.Sprintf(`  <rect x="%d" y="%d" width="%d" height="%d" fill="#161b22" rx="6" stroke="#30363d" stroke-width="1"/>
-`, barX, yPos-12, barMaxWidth, barHeight))
+		svg.WriteString(fmt.Sprintf(`  <rect x="%d" y="%d" width="%d" height="%d" fill="%s" rx="6" stroke="%s" stroke-width="1"/>
+`, barX, yPos-12, barMaxWidth, barHeight, theme.Surface, theme.Border))
 
 		// Bar (gradient + shadow)
 		if barWidth > 0 {
@@ -133,7 +163,7 @@ func GenerateCommitLanguagesChart(commitLanguages map[string]int) (string, error
 		countText := fmt.Sprintf("%d files", item.count)
 		textX := barX + barMaxWidth + 10
 		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="12" fill="%s">%s</text>
-`, textX, yPos+5, DefaultTextColor, countText))
+`, textX, yPos+5, theme.Text, countText))
 	}
 
 	// Footer
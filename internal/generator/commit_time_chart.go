@@ -19,8 +19,26 @@ import (
 // Invariants:
 // - All 24 hours are displayed (time slots with no data are shown as 0)
 func GenerateCommitTimeChart(timeDistribution map[int]int) (string, error) {
+	return GenerateCommitTimeChartWithOptions(timeDistribution, DefaultChartOptions())
+}
+
+// GenerateCommitTimeChartWithOptions generates a commit time distribution SVG with custom options
+//
+// Preconditions:
+// - timeDistribution is in the format map[int]int{time slot: commit count}
+// - opts are chart options (Theme)
+//
+// Postconditions:
+// - Returns a valid SVG string
+//
+// Invariants:
+// - All 24 hours are displayed (time slots with no data are shown as 0)
+func GenerateCommitTimeChartWithOptions(timeDistribution map[int]int, opts ChartOptions) (string, error) {
+	opts = opts.withDefaults()
+	theme := opts.Theme
+
 	if len(timeDistribution) == 0 {
-		return generateEmptyChart("Commit Time Distribution", "No data available"), nil
+		return generateEmptyChartWithTheme("Commit Time Distribution", "No data available", theme), nil
 	}
 
 	// Sort by time slot
@@ -80,12 +98,12 @@ func GenerateCommitTimeChart(timeDistribution map[int]int) (string, error) {
 `)
 
 	// Background (with border)
-	svg.WriteString(fmt.Sprintf(`  <rect width="%d" height="%d" fill="%s" rx="10" stroke="#30363d" stroke-width="1"/>
-`, width, height, DefaultBackgroundColor))
+	svg.WriteString(fmt.Sprintf(`  <rect width="%d" height="%d" fill="%s" rx="10" stroke="%s" stroke-width="1"/>
+`, width, height, theme.Background, theme.Border))
 
 	// Title (decorated)
 	svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="20" font-weight="700" fill="%s" text-anchor="middle">🕐 Commit Time Distribution (UTC)</text>
-`, width/2, 37, AccentColor))
+`, width/2, 37, theme.Accent))
 
 	// Display in heatmap format
 	barWidth := float64(chartWidth) / 24.0
@@ -115,7 +133,7 @@ func GenerateCommitTimeChart(timeDistribution map[int]int) (string, error) {
 		} else if intensity > 0 {
 			baseColor = "#b1ddff" // Lightest
 		} else {
-			baseColor = "#21262d" // No data
+			baseColor = theme.Grid // No data
 		}
 
 		// Draw bar
@@ -132,7 +150,7 @@ func GenerateCommitTimeChart(timeDistribution map[int]int) (string, error) {
 		// Time slot label (every 6 hours)
 		if hour%6 == 0 {
 			svg.WriteString(fmt.Sprintf(`  <text x="%.1f" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="10" fill="%s" text-anchor="middle">%02d:00</text>
-`, x+barWidth/2, height-padding+15, DefaultTextColor, hour))
+`, x+barWidth/2, height-padding+15, theme.Text, hour))
 		}
 
 		// Display count if greater than 0 (small text)
@@ -142,14 +160,14 @@ func GenerateCommitTimeChart(timeDistribution map[int]int) (string, error) {
 				textY = y + 12 // Display below if there's no space above the bar
 			}
 			svg.WriteString(fmt.Sprintf(`  <text x="%.1f" y="%.1f" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="8" fill="%s" text-anchor="middle" opacity="0.8">%d</text>
-`, x+barWidth/2, textY, DefaultTextColor, count))
+`, x+barWidth/2, textY, theme.Text, count))
 		}
 	}
 
 	// Legend (color explanation sorted by commit count)
 	legendY := height - padding - chartHeight - 25
 	svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="11" fill="%s">High</text>
-`, padding, legendY, DefaultTextColor))
+`, padding, legendY, theme.Text))
 
 	// Display color bar
 	for i := 0; i < 5; i++ {
@@ -160,7 +178,7 @@ func GenerateCommitTimeChart(timeDistribution map[int]int) (string, error) {
 	}
 
 	svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="11" fill="%s">Low</text>
-`, padding+40+125, legendY, DefaultTextColor))
+`, padding+40+125, legendY, theme.Text))
 
 	// Footer
 	svg.WriteString(SVGFooter)
@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+)
+
+// contributorColors colors of the commit, pull request and review segments of contributor bars
+var contributorColors = []string{"#58a6ff", "#7c3aed", "#56d364"}
+
+// GenerateContributorsChart generates an SVG displaying the top contributors
+//
+// Preconditions:
+// - contributors is a slice of ranked contributors (see aggregator.RankContributors)
+//
+// Postconditions:
+// - Returns a valid SVG string
+// - SVG displays up to 10 contributors with their commits, pull requests and reviews
+func GenerateContributorsChart(contributors []aggregator.ContributorStat) (string, error) {
+	return GenerateContributorsChartWithOptions(contributors, DefaultChartOptions())
+}
+
+// GenerateContributorsChartWithOptions generates a top contributors SVG with custom options
+//
+// Preconditions:
+// - contributors is a slice of ranked contributors (see aggregator.RankContributors)
+// - opts are chart options (Theme, MaxItems)
+//
+// Postconditions:
+// - Returns a valid SVG string
+// - If opts.MaxItems is set, up to MaxItems contributors are displayed (default 10)
+//
+// Invariants:
+// - Contributors are displayed in the order of contributors
+// - Each bar is split into commits, pull requests and reviews
+// - Chart height grows to fit the number of displayed contributors
+func GenerateContributorsChartWithOptions(contributors []aggregator.ContributorStat, opts ChartOptions) (string, error) {
+	opts = opts.withDefaults()
+	theme := opts.Theme
+
+	maxItems := 10
+	if opts.MaxItems > 0 {
+		maxItems = opts.MaxItems
+	}
+	if len(contributors) > maxItems {
+		contributors = contributors[:maxItems]
+	}
+
+	// Get maximum total contributions
+	maxTotal := 0
+	for _, contributor := range contributors {
+		maxTotal = max(maxTotal, contributor.Total())
+	}
+	if maxTotal == 0 {
+		return generateEmptyChartWithTheme("Top Contributors", "No data available", theme), nil
+	}
+
+	// Bar layout
+	barHeight := 20
+	barSpacing := 32
+	startY := 75
+
+	width := DefaultSVGWidth
+	height := startY + len(contributors)*barSpacing + 30
+	padding := 20
+	barX := 160
+	barMaxWidth := width - barX - padding - 110 // Reserve space for the total
+
+	var svg strings.Builder
+
+	// Header
+	svg.WriteString(fmt.Sprintf(SVGHeader, width, height, width, height))
+
+	// Background (with border)
+	svg.WriteString(fmt.Sprintf(`  <rect width="%d" height="%d" fill="%s" rx="10" stroke="%s" stroke-width="1"/>
+`, width, height, theme.Background, theme.Border))
+
+	// Title
+	svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="20" font-weight="700" fill="%s" text-anchor="middle">👥 Top Contributors</text>
+`, width/2, 37, theme.Accent))
+
+	// Legend
+	legendX := padding
+	for i, label := range []string{"Commits", "Pull Requests", "Reviews"} {
+		svg.WriteString(fmt.Sprintf(`  <rect x="%d" y="%d" width="10" height="10" fill="%s" rx="2"/>
+  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="11" fill="%s">%s</text>
+`, legendX, 52, contributorColors[i], legendX+14, 61, theme.Text, label))
+		legendX += 110
+	}
+
+	for i, contributor := range contributors {
+		yPos := startY + i*barSpacing
+
+		// Login
+		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="14" fill="%s">%s</text>
+`, padding, yPos+barHeight-5, theme.Text, escapeXML(contributor.Login)))
+
+		// Bar background
+		svg.WriteString(fmt.Sprintf(`  <rect x="%d" y="%d" width="%d" height="%d" fill="%s" rx="4" stroke="%s" stroke-width="1"/>
+`, barX, yPos, barMaxWidth, barHeight, theme.Surface, theme.Border))
+
+		// Segments (commits, pull requests, reviews)
+		segmentX := barX
+		for j, count := range []int{contributor.Commits, contributor.PullRequests, contributor.Reviews} {
+			segmentWidth := int(float64(barMaxWidth) * float64(count) / float64(maxTotal))
+			if segmentWidth <= 0 {
+				continue
+			}
+			svg.WriteString(fmt.Sprintf(`  <rect x="%d" y="%d" width="%d" height="%d" fill="%s" opacity="0.95"/>
+`, segmentX, yPos, segmentWidth, barHeight, contributorColors[j]))
+			segmentX += segmentWidth
+		}
+
+		// Total (right side of bar)
+		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="12" fill="%s">%d contributions</text>
+`, barX+barMaxWidth+10, yPos+barHeight-5, theme.Text, contributor.Total()))
+	}
+
+	// Footer
+	svg.WriteString(SVGFooter)
+
+	return svg.String(), nil
+}
@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+)
+
+func TestGenerateContributorsChart(t *testing.T) {
+	tests := []struct {
+		name            string
+		contributors    []aggregator.ContributorStat
+		opts            ChartOptions
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name: "Normal case: contributors with breakdown",
+			contributors: []aggregator.ContributorStat{
+				{Login: "alice", Commits: 30, PullRequests: 5, Reviews: 8},
+				{Login: "bob", Commits: 12, PullRequests: 2},
+			},
+			opts: DefaultChartOptions(),
+			wantContains: []string{
+				"Top Contributors",
+				"alice",
+				"bob",
+				"43 contributions",
+				"14 contributions",
+				"Pull Requests",
+			},
+		},
+		{
+			name:         "Empty data",
+			contributors: nil,
+			opts:         DefaultChartOptions(),
+			wantContains: []string{
+				"Top Contributors",
+				"No data available",
+			},
+		},
+		{
+			name: "MaxItems limits contributors",
+			contributors: []aggregator.ContributorStat{
+				{Login: "alice", Commits: 3},
+				{Login: "bob", Commits: 2},
+			},
+			opts:            ChartOptions{MaxItems: 1},
+			wantContains:    []string{"alice"},
+			wantNotContains: []string{"bob"},
+		},
+		{
+			name: "Logins are escaped",
+			contributors: []aggregator.ContributorStat{
+				{Login: "a<b", Commits: 1},
+			},
+			opts:            DefaultChartOptions(),
+			wantContains:    []string{"a&lt;b"},
+			wantNotContains: []string{"a<b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svg, err := GenerateContributorsChartWithOptions(tt.contributors, tt.opts)
+			if err != nil {
+				t.Fatalf("GenerateContributorsChartWithOptions() error = %v", err)
+			}
+
+			if !strings.HasPrefix(svg, "<?xml") || !strings.Contains(svg, "<svg") {
+				t.Errorf("GenerateContributorsChartWithOptions() should return an SVG document")
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(svg, want) {
+					t.Errorf("GenerateContributorsChartWithOptions() should contain %q", want)
+				}
+			}
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(svg, notWant) {
+					t.Errorf("GenerateContributorsChartWithOptions() should not contain %q", notWant)
+				}
+			}
+		})
+	}
+}
@@ -22,8 +22,32 @@ import (
 // - SVG has appropriate size and styling
 // - Text is displayed in a readable format
 func GenerateLanguageChart(rankedLanguages []aggregator.LanguageStat, maxItems int) (string, error) {
+	return GenerateLanguageChartWithOptions(rankedLanguages, DefaultChartOptions())
+}
+
+// GenerateLanguageChartWithOptions generates a language distribution SVG with custom options
+//
+// Preconditions:
+// - rankedLanguages is a slice of ranked languages
+// - opts are chart options (Theme, MaxItems, Layout)
+//
+// Postconditions:
+// - Returns a valid SVG string
+// - If opts.MaxItems is set, languages beyond the limit are grouped into "Other"
+// - If opts.Layout is "donut", the chart is drawn as a donut chart
+//
+// Invariants:
+// - Percentages are normalized so that slices cover the full circle
+func GenerateLanguageChartWithOptions(rankedLanguages []aggregator.LanguageStat, opts ChartOptions) (string, error) {
+	opts = opts.withDefaults()
+	theme := opts.Theme
+
 	if len(rankedLanguages) == 0 {
-		return generateEmptyChart("Language Distribution", "No data available"), nil
+		return generateEmptyChartWithTheme("Language Distribution", "No data available", theme), nil
+	}
+
+	if opts.MaxItems > 0 {
+		rankedLanguages = groupOtherLanguages(rankedLanguages, opts.MaxItems)
 	}
 
 	width := DefaultSVGWidth
@@ -68,12 +92,12 @@ func GenerateLanguageChart(rankedLanguages []aggregator.LanguageStat, maxItems i
 `)
 
 	// Background (with border)
-	svg.WriteString(fmt.Sprintf(`  <rect width="%d" height="%d" fill="%s" rx="10" stroke="#30363d" stroke-width="1"/>
-`, width, height, DefaultBackgroundColor))
+	svg.WriteString(fmt.Sprintf(`  <rect width="%d" height="%d" fill="%s" rx="10" stroke="%s" stroke-width="1"/>
+`, width, height, theme.Background, theme.Border))
 
 	// Title
 	svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="20" font-weight="700" fill="%s" text-anchor="middle" filter="url(#shadow)">🗂️ Language Distribution</text>
-`, width/2, 32, AccentColor))
+`, width/2, 32, theme.Accent))
 
 	// Calculate total percentage for normalization (in case sum is not 100%)
 	totalPercentage := 0.0
@@ -120,12 +144,18 @@ func GenerateLanguageChart(rankedLanguages []aggregator.LanguageStat, maxItems i
 
 			// Draw slice
 			svg.WriteString(fmt.Sprintf(`  <path d="%s" fill="%s" stroke="%s" stroke-width="2" opacity="0.9" filter="url(#shadow)"/>
-`, path, color, DefaultBackgroundColor))
+`, path, color, theme.Background))
 
 			currentAngle = endAngle
 		}
 	}
 
+	// Cut out the center for donut layout
+	if opts.Layout == LayoutDonut {
+		svg.WriteString(fmt.Sprintf(`  <circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s"/>
+`, centerX, centerY, radius*0.55, theme.Background))
+	}
+
 	// Draw legend and labels on the left side
 	legendX := padding + 10 // Start legend from left with padding
 	legendY := titleHeight + 25
@@ -151,7 +181,7 @@ func GenerateLanguageChart(rankedLanguages []aggregator.LanguageStat, maxItems i
 			langText = langText[:17] + "..."
 		}
 		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="11" fill="%s">%s</text>
-`, legendX+18, y, DefaultTextColor, langText))
+`, legendX+18, y, theme.Text, langText))
 
 		// Percentage (right-aligned within legend area)
 		percentage := lang.Percentage
@@ -161,14 +191,14 @@ func GenerateLanguageChart(rankedLanguages []aggregator.LanguageStat, maxItems i
 		percentageText := fmt.Sprintf("%.1f%%", percentage)
 		percentageX := legendX + maxLegendWidth - 10 // Right-align within legend area
 		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="11" fill="%s" font-weight="600" text-anchor="end">%s</text>
-`, percentageX, y, AccentColor, percentageText))
+`, percentageX, y, theme.Accent, percentageText))
 	}
 
 	// If there are more than 15 languages, show count
 	if len(rankedLanguages) > 15 {
 		remaining := len(rankedLanguages) - 15
 		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="10" fill="%s" font-style="italic">+%d more languages</text>
-`, legendX, legendY+(15*legendItemHeight), DefaultTextColor, remaining))
+`, legendX, legendY+(15*legendItemHeight), theme.Text, remaining))
 	}
 
 	// Footer
@@ -177,19 +207,42 @@ func GenerateLanguageChart(rankedLanguages []aggregator.LanguageStat, maxItems i
 	return svg.String(), nil
 }
 
+// groupOtherLanguages keeps the top maxItems languages and groups the rest into "Other"
+func groupOtherLanguages(rankedLanguages []aggregator.LanguageStat, maxItems int) []aggregator.LanguageStat {
+	if len(rankedLanguages) <= maxItems {
+		return rankedLanguages
+	}
+
+	grouped := make([]aggregator.LanguageStat, 0, maxItems+1)
+	grouped = append(grouped, rankedLanguages[:maxItems]...)
+
+	other := aggregator.LanguageStat{Language: "Other"}
+	for _, lang := range rankedLanguages[maxItems:] {
+		other.Bytes += lang.Bytes
+		other.Percentage += lang.Percentage
+	}
+
+	return append(grouped, other)
+}
+
 // generateEmptyChart generates a chart for empty data
 func generateEmptyChart(title, message string) string {
+	return generateEmptyChartWithTheme(title, message, DarkTheme)
+}
+
+// generateEmptyChartWithTheme generates a chart for empty data using the specified theme
+func generateEmptyChartWithTheme(title, message string, theme Theme) string {
 	width := DefaultSVGWidth
 	height := 200
 
 	var svg strings.Builder
 	svg.WriteString(fmt.Sprintf(SVGHeader, width, height, width, height))
 	svg.WriteString(fmt.Sprintf(`  <rect width="%d" height="%d" fill="%s" rx="8"/>
-`, width, height, DefaultBackgroundColor))
+`, width, height, theme.Background))
 	svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="18" font-weight="600" fill="%s">%s</text>
-`, width/2, 60, DefaultTextColor, title))
+`, width/2, 60, theme.Text, title))
 	svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="14" fill="%s">%s</text>
-`, width/2, 100, DefaultTextColor, message))
+`, width/2, 100, theme.Text, message))
 	svg.WriteString(SVGFooter)
 
 	return svg.String()
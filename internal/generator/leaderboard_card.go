@@ -0,0 +1,163 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+)
+
+// leaderboardMedals medals displayed instead of the rank of the top 3 users
+var leaderboardMedals = []string{"🥇", "🥈", "🥉"}
+
+// GenerateLeaderboardCard generates an SVG team leaderboard
+//
+// Preconditions:
+// - leaderboard has ranked entries (see aggregator.RankContributors)
+//
+// Postconditions:
+// - Returns a valid SVG string
+// - SVG displays up to 10 users with their commits, pull requests, reviews and total
+func GenerateLeaderboardCard(leaderboard aggregator.Leaderboard) (string, error) {
+	return GenerateLeaderboardCardWithOptions(leaderboard, DefaultChartOptions())
+}
+
+// GenerateLeaderboardCardWithOptions generates a team leaderboard SVG with custom options
+//
+// Preconditions:
+// - leaderboard has ranked entries (see aggregator.RankContributors)
+// - opts are chart options (Theme, MaxItems)
+//
+// Postconditions:
+// - Returns a valid SVG string
+// - If opts.MaxItems is set, up to MaxItems users are displayed (default 10)
+// - Users whose contributions could not be fetched are counted in a footnote
+// - If no user could be fetched, the card shows "No data available" with the footnote
+//
+// Invariants:
+// - Users are displayed in the order of leaderboard.Entries
+// - Card height grows to fit the number of displayed users
+func GenerateLeaderboardCardWithOptions(leaderboard aggregator.Leaderboard, opts ChartOptions) (string, error) {
+	opts = opts.withDefaults()
+	theme := opts.Theme
+
+	entries := leaderboard.Entries
+	if len(entries) == 0 && len(leaderboard.Failed) == 0 {
+		return generateEmptyChartWithTheme("Team Leaderboard", "No data available", theme), nil
+	}
+
+	maxItems := 10
+	if opts.MaxItems > 0 {
+		maxItems = opts.MaxItems
+	}
+	if len(entries) > maxItems {
+		entries = entries[:maxItems]
+	}
+
+	// Table layout
+	rowHeight := 30
+	headerY := 85
+	startY := headerY + 12
+	padding := 20
+
+	width := DefaultSVGWidth
+	rows := max(len(entries), 1) // An empty leaderboard has a message row
+	height := startY + rows*rowHeight + 15
+	if len(leaderboard.Failed) > 0 {
+		height += 20
+	}
+
+	// Column positions (numbers are right-aligned)
+	rankX := padding + 12
+	loginX := padding + 35
+	columns := []struct {
+		label string
+		x     int
+	}{
+		{"Commits", 290},
+		{"PRs", 345},
+		{"Reviews", 405},
+		{"Total", width - padding - 5},
+	}
+
+	var svg strings.Builder
+
+	// Header
+	svg.WriteString(fmt.Sprintf(SVGHeader, width, height, width, height))
+
+	// Background (with border)
+	svg.WriteString(fmt.Sprintf(`  <rect width="%d" height="%d" fill="%s" rx="10" stroke="%s" stroke-width="1"/>
+`, width, height, theme.Background, theme.Border))
+
+	// Title and period
+	svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="20" font-weight="700" fill="%s" text-anchor="middle">🏆 Team Leaderboard</text>
+`, width/2, 37, theme.Accent))
+	if leaderboard.Days > 0 {
+		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="12" fill="%s" text-anchor="middle" opacity="0.8">Last %d days</text>
+`, width/2, 57, theme.Text, leaderboard.Days))
+	}
+
+	// Column headers
+	svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="11" font-weight="600" fill="%s" opacity="0.8">User</text>
+`, loginX, headerY, theme.Text))
+	for _, column := range columns {
+		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="11" font-weight="600" fill="%s" text-anchor="end" opacity="0.8">%s</text>
+`, column.x, headerY, theme.Text, column.label))
+	}
+
+	if len(entries) == 0 {
+		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="14" fill="%s" text-anchor="middle">No data available</text>
+`, width/2, startY+rowHeight/2+5, theme.Text))
+	}
+
+	for i, entry := range entries {
+		yPos := startY + i*rowHeight
+
+		// Alternate row background
+		if i%2 == 0 {
+			svg.WriteString(fmt.Sprintf(`  <rect x="%d" y="%d" width="%d" height="%d" fill="%s" rx="4"/>
+`, padding-5, yPos, width-padding*2+10, rowHeight, theme.Surface))
+		}
+
+		textY := yPos + rowHeight/2 + 5
+
+		// Rank (medals for the top 3)
+		rank := fmt.Sprintf("%d", i+1)
+		if i < len(leaderboardMedals) {
+			rank = leaderboardMedals[i]
+		}
+		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="14" fill="%s" text-anchor="middle">%s</text>
+`, rankX, textY, theme.Text, rank))
+
+		// Login
+		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="14" fill="%s">%s</text>
+`, loginX, textY, theme.Text, escapeXML(entry.Login)))
+
+		// Counts
+		for j, count := range []int{entry.Commits, entry.PullRequests, entry.Reviews, entry.Total()} {
+			fill := theme.Text
+			weight := "400"
+			if j == len(columns)-1 {
+				fill = theme.Accent
+				weight = "700"
+			}
+			svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="14" font-weight="%s" fill="%s" text-anchor="end">%d</text>
+`, columns[j].x, textY, weight, fill, count))
+		}
+	}
+
+	// Footnote for users that could not be fetched
+	if len(leaderboard.Failed) > 0 {
+		noun := "users"
+		if len(leaderboard.Failed) == 1 {
+			noun = "user"
+		}
+		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="11" fill="%s" opacity="0.7">%d %s unavailable</text>
+`, padding, height-15, theme.Text, len(leaderboard.Failed), noun))
+	}
+
+	// Footer
+	svg.WriteString(SVGFooter)
+
+	return svg.String(), nil
+}
@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+)
+
+func TestGenerateLeaderboardCard(t *testing.T) {
+	entries := []aggregator.ContributorStat{
+		{Login: "alice", Commits: 20, PullRequests: 4, Reviews: 6},
+		{Login: "bob", Commits: 5, PullRequests: 1},
+		{Login: "carol", Commits: 3},
+		{Login: "dave", Reviews: 2},
+	}
+
+	tests := []struct {
+		name            string
+		leaderboard     aggregator.Leaderboard
+		opts            ChartOptions
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name:        "Normal case: ranked users",
+			leaderboard: aggregator.Leaderboard{Days: 30, Entries: entries},
+			opts:        DefaultChartOptions(),
+			wantContains: []string{
+				"Team Leaderboard",
+				"Last 30 days",
+				"🥇", "🥈", "🥉",
+				"alice", "dave",
+				">30<",
+			},
+			wantNotContains: []string{"unavailable"},
+		},
+		{
+			name:         "Failed users are counted",
+			leaderboard:  aggregator.Leaderboard{Days: 7, Entries: entries[:1], Failed: []string{"ghost", "broken"}},
+			opts:         DefaultChartOptions(),
+			wantContains: []string{"2 users unavailable"},
+		},
+		{
+			name:            "MaxItems limits users",
+			leaderboard:     aggregator.Leaderboard{Entries: entries},
+			opts:            ChartOptions{MaxItems: 2},
+			wantContains:    []string{"alice", "bob"},
+			wantNotContains: []string{"carol", "Last 0 days"},
+		},
+		{
+			name:         "Every user failed",
+			leaderboard:  aggregator.Leaderboard{Days: 30, Failed: []string{"ghost"}},
+			opts:         DefaultChartOptions(),
+			wantContains: []string{"Team Leaderboard", "Last 30 days", "No data available", "1 user unavailable"},
+		},
+		{
+			name:            "Empty data",
+			leaderboard:     aggregator.Leaderboard{},
+			opts:            DefaultChartOptions(),
+			wantContains:    []string{"Team Leaderboard", "No data available"},
+			wantNotContains: []string{"unavailable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svg, err := GenerateLeaderboardCardWithOptions(tt.leaderboard, tt.opts)
+			if err != nil {
+				t.Fatalf("GenerateLeaderboardCardWithOptions() error = %v", err)
+			}
+
+			if !strings.HasPrefix(svg, "<?xml") || !strings.Contains(svg, "<svg") {
+				t.Errorf("GenerateLeaderboardCardWithOptions() should return an SVG document")
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(svg, want) {
+					t.Errorf("GenerateLeaderboardCardWithOptions() should contain %q", want)
+				}
+			}
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(svg, notWant) {
+					t.Errorf("GenerateLeaderboardCardWithOptions() should not contain %q", notWant)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package generator
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// coordinatePrecision Number of decimal places kept for coordinates and sizes
+const coordinatePrecision = 1
+
+// geometryAttributes attributes whose numbers are rounded to coordinatePrecision
+// (opacity and gradient offsets are kept as is)
+var geometryAttributes = map[string]bool{
+	"x": true, "y": true, "x1": true, "y1": true, "x2": true, "y2": true,
+	"cx": true, "cy": true, "r": true, "rx": true, "ry": true,
+	"dx": true, "dy": true, "width": true, "height": true,
+	"d": true, "points": true, "viewBox": true,
+	"stroke-width": true, "font-size": true, "stdDeviation": true,
+}
+
+// definitionElements elements in <defs> that are only rendered when referenced by id
+var definitionElements = map[string]bool{
+	"filter": true, "linearGradient": true, "radialGradient": true,
+	"clipPath": true, "mask": true, "pattern": true, "marker": true, "symbol": true,
+}
+
+var (
+	// numberPattern matches numbers in attribute values (including exponents)
+	numberPattern = regexp.MustCompile(`-?(?:\d+\.?\d*|\.\d+)(?:[eE][-+]?\d+)?`)
+
+	// referencePattern matches url(#id) references
+	referencePattern = regexp.MustCompile(`url\(#([^)]+)\)`)
+)
+
+// xmlElement element of an SVG document with attribute order preserved
+type xmlElement struct {
+	Name     string
+	Attrs    []xml.Attr
+	Children []xmlContent
+}
+
+// xmlContent child of an element (either an element or text)
+type xmlContent struct {
+	Element *xmlElement
+	Text    string
+}
+
+// OptimizeSVG minifies SVG content and normalizes it into a deterministic form
+//
+// Preconditions:
+// - svgContent is a valid SVG string
+//
+// Postconditions:
+// - Returns the optimized SVG content
+// - Returns error if the SVG cannot be parsed
+//
+// Invariants:
+// - Byte-identical input yields byte-identical output
+// - Optimizing already optimized content doesn't change it
+// - Whitespace between elements and comments are removed
+// - Coordinates are rounded to 1 decimal place and trailing zeros are removed
+// - Unused and duplicated definitions (filters, gradients) are removed, and definitions are sorted by id
+// - Attributes are sorted by name
+// - Drawing order of elements is preserved
+func OptimizeSVG(svgContent string) (string, error) {
+	root, err := parseXMLTree(svgContent)
+	if err != nil {
+		return "", err
+	}
+
+	mergeDuplicateDefinitions(root)
+	for removeUnusedDefinitions(root, collectReferences(root)) {
+		// Repeat until removed definitions no longer reference other definitions
+	}
+	normalizeElement(root)
+
+	var svg strings.Builder
+	svg.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	writeXMLElement(&svg, root)
+	svg.WriteString("\n")
+
+	return svg.String(), nil
+}
+
+// parseXMLTree parses SVG content into an element tree
+// Namespace prefixes are kept as written
+func parseXMLTree(content string) (*xmlElement, error) {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+
+	var root *xmlElement
+	var stack []*xmlElement
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SVG: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			element := &xmlElement{Name: qualifiedName(t.Name)}
+			for _, attr := range t.Attr {
+				element.Attrs = append(element.Attrs, xml.Attr{Name: xml.Name{Local: qualifiedName(attr.Name)}, Value: attr.Value})
+			}
+
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, xmlContent{Element: element})
+			} else if root == nil {
+				root = element
+			}
+			stack = append(stack, element)
+		case xml.EndElement:
+			// RawToken doesn't check that start and end tags match
+			if len(stack) == 0 || stack[len(stack)-1].Name != qualifiedName(t.Name) {
+				return nil, fmt.Errorf("failed to parse SVG: unexpected end tag </%s>", qualifiedName(t.Name))
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			// Whitespace between elements is not significant
+			if len(stack) > 0 && strings.TrimSpace(string(t)) != "" {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, xmlContent{Text: string(t)})
+			}
+		}
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("failed to parse SVG: unclosed element <%s>", stack[len(stack)-1].Name)
+	}
+
+	if root == nil || root.Name != "svg" {
+		return nil, fmt.Errorf("root element is not svg")
+	}
+
+	return root, nil
+}
+
+// qualifiedName returns the name with its namespace prefix
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// attr returns the value of an attribute
+func (e *xmlElement) attr(name string) string {
+	for _, attr := range e.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// definitions returns <defs> elements directly under the element
+func (e *xmlElement) definitions() []*xmlElement {
+	var defs []*xmlElement
+	for _, child := range e.Children {
+		if child.Element != nil && child.Element.Name == "defs" {
+			defs = append(defs, child.Element)
+		}
+	}
+	return defs
+}
+
+// mergeDuplicateDefinitions removes definitions identical to an earlier one and redirects references
+func mergeDuplicateDefinitions(root *xmlElement) {
+	firstByContent := make(map[string]string)
+	replacements := make(map[string]string)
+
+	for _, defs := range root.definitions() {
+		kept := defs.Children[:0]
+		for _, child := range defs.Children {
+			element := child.Element
+			if element == nil || element.attr("id") == "" {
+				kept = append(kept, child)
+				continue
+			}
+
+			id := element.attr("id")
+			key := definitionKey(element)
+			if firstID, ok := firstByContent[key]; ok {
+				replacements[id] = firstID
+				continue
+			}
+			firstByContent[key] = id
+			kept = append(kept, child)
+		}
+		defs.Children = kept
+	}
+
+	if len(replacements) > 0 {
+		replaceReferences(root, replacements)
+	}
+}
+
+// definitionKey serializes a definition without its id
+func definitionKey(element *xmlElement) string {
+	clone := *element
+	clone.Attrs = nil
+	for _, attr := range element.Attrs {
+		if attr.Name.Local != "id" {
+			clone.Attrs = append(clone.Attrs, attr)
+		}
+	}
+
+	var key strings.Builder
+	writeXMLElement(&key, &clone)
+	return key.String()
+}
+
+// replaceReferences rewrites url(#id) and href="#id" references
+func replaceReferences(element *xmlElement, replacements map[string]string) {
+	for i, attr := range element.Attrs {
+		value := referencePattern.ReplaceAllStringFunc(attr.Value, func(ref string) string {
+			id := referencePattern.FindStringSubmatch(ref)[1]
+			if replacement, ok := replacements[id]; ok {
+				return "url(#" + replacement + ")"
+			}
+			return ref
+		})
+		if isHrefAttribute(attr.Name.Local) && strings.HasPrefix(value, "#") {
+			if replacement, ok := replacements[value[1:]]; ok {
+				value = "#" + replacement
+			}
+		}
+		element.Attrs[i].Value = value
+	}
+
+	for _, child := range element.Children {
+		if child.Element != nil {
+			replaceReferences(child.Element, replacements)
+		}
+	}
+}
+
+// collectReferences collects ids referenced with url(#id) or href="#id"
+func collectReferences(element *xmlElement) map[string]bool {
+	references := make(map[string]bool)
+
+	var walk func(e *xmlElement)
+	walk = func(e *xmlElement) {
+		for _, attr := range e.Attrs {
+			for _, match := range referencePattern.FindAllStringSubmatch(attr.Value, -1) {
+				references[match[1]] = true
+			}
+			if isHrefAttribute(attr.Name.Local) && strings.HasPrefix(attr.Value, "#") {
+				references[attr.Value[1:]] = true
+			}
+		}
+		for _, child := range e.Children {
+			if child.Element != nil {
+				walk(child.Element)
+			}
+		}
+	}
+	walk(element)
+
+	return references
+}
+
+// removeUnusedDefinitions removes definitions that are not referenced
+// Returns true if any definition was removed
+func removeUnusedDefinitions(root *xmlElement, references map[string]bool) bool {
+	removed := false
+
+	for _, defs := range root.definitions() {
+		kept := defs.Children[:0]
+		for _, child := range defs.Children {
+			if element := child.Element; element != nil && definitionElements[element.Name] && !references[element.attr("id")] {
+				removed = true
+				continue
+			}
+			kept = append(kept, child)
+		}
+		defs.Children = kept
+	}
+
+	// Remove empty <defs>
+	kept := root.Children[:0]
+	for _, child := range root.Children {
+		if child.Element != nil && child.Element.Name == "defs" && len(child.Element.Children) == 0 {
+			removed = true
+			continue
+		}
+		kept = append(kept, child)
+	}
+	root.Children = kept
+
+	return removed
+}
+
+// normalizeElement rounds numbers, sorts attributes and sorts definitions by id
+func normalizeElement(element *xmlElement) {
+	for i, attr := range element.Attrs {
+		if geometryAttributes[attr.Name.Local] {
+			element.Attrs[i].Value = numberPattern.ReplaceAllStringFunc(attr.Value, roundNumber)
+		}
+	}
+
+	sort.SliceStable(element.Attrs, func(i, j int) bool {
+		return element.Attrs[i].Name.Local < element.Attrs[j].Name.Local
+	})
+
+	// Order inside <defs> doesn't affect rendering
+	if element.Name == "defs" {
+		sort.SliceStable(element.Children, func(i, j int) bool {
+			return contentID(element.Children[i]) < contentID(element.Children[j])
+		})
+	}
+
+	for _, child := range element.Children {
+		if child.Element != nil {
+			normalizeElement(child.Element)
+		}
+	}
+}
+
+// contentID returns the id of an element child (empty for text)
+func contentID(content xmlContent) string {
+	if content.Element == nil {
+		return ""
+	}
+	return content.Element.attr("id")
+}
+
+// roundNumber rounds a number to coordinatePrecision and removes trailing zeros
+func roundNumber(number string) string {
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return number
+	}
+
+	factor := math.Pow10(coordinatePrecision)
+	value = math.Round(value*factor) / factor
+	if value == 0 {
+		return "0" // Avoid "-0"
+	}
+
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// isHrefAttribute checks if the attribute is href or xlink:href
+func isHrefAttribute(name string) bool {
+	return name == "href" || name == "xlink:href"
+}
+
+// writeXMLElement writes an element without insignificant whitespace
+func writeXMLElement(w *strings.Builder, element *xmlElement) {
+	w.WriteString("<" + element.Name)
+	for _, attr := range element.Attrs {
+		w.WriteString(" " + attr.Name.Local + `="` + escapeXML(attr.Value) + `"`)
+	}
+
+	if len(element.Children) == 0 {
+		w.WriteString("/>")
+		return
+	}
+
+	w.WriteString(">")
+	for _, child := range element.Children {
+		if child.Element != nil {
+			writeXMLElement(w, child.Element)
+		} else {
+			w.WriteString(escapeXML(child.Text))
+		}
+	}
+	w.WriteString("</" + element.Name + ">")
+}
@@ -0,0 +1,143 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+)
+
+func TestOptimizeSVG(t *testing.T) {
+	tests := []struct {
+		name        string
+		svg         string
+		want        []string
+		wantMissing []string
+		wantError   bool
+	}{
+		{
+			name: "Round coordinates and remove whitespace",
+			svg: `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" width="100" height="50">
+  <!-- comment -->
+  <rect x="10.04999" y="-0.01" width="20.0" height="5.25" opacity="0.95"/>
+  <path d="M 50.123 50.987 L 100.000 50.000 Z"/>
+</svg>`,
+			want: []string{
+				`<rect height="5.3" opacity="0.95" width="20" x="10" y="0"/>`,
+				`<path d="M 50.1 51 L 100 50 Z"/>`,
+			},
+			wantMissing: []string{"comment", "\n  "},
+		},
+		{
+			name: "Remove unused definitions",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10">
+  <defs>
+    <filter id="unused"><feGaussianBlur stdDeviation="2"/></filter>
+    <linearGradient id="used"><stop offset="0%" style="stop-color:#fff"/></linearGradient>
+  </defs>
+  <rect width="10" height="10" fill="url(#used)"/>
+</svg>`,
+			want:        []string{`id="used"`},
+			wantMissing: []string{`id="unused"`, "feGaussianBlur"},
+		},
+		{
+			name: "Merge duplicated definitions",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10">
+  <defs>
+    <filter id="b"><feGaussianBlur stdDeviation="2"/></filter>
+    <filter id="a"><feGaussianBlur stdDeviation="2"/></filter>
+  </defs>
+  <rect width="5" height="5" filter="url(#b)"/>
+  <rect width="5" height="5" filter="url(#a)"/>
+</svg>`,
+			want:        []string{`<defs><filter id="b">`, `<rect filter="url(#b)" height="5" width="5"/><rect filter="url(#b)" height="5" width="5"/>`},
+			wantMissing: []string{`id="a"`, `url(#a)`},
+		},
+		{
+			name: "Remove empty definitions",
+			svg:  `<svg xmlns="http://www.w3.org/2000/svg"><defs><filter id="x"/></defs><text x="1">A &amp; B</text></svg>`,
+			want: []string{`<svg xmlns="http://www.w3.org/2000/svg"><text x="1">A &amp; B</text></svg>`},
+		},
+		{
+			name:      "Error: invalid XML",
+			svg:       `<svg><rect></svg>`,
+			wantError: true,
+		},
+		{
+			name:      "Error: not SVG",
+			svg:       `<html></html>`,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := OptimizeSVG(tt.svg)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("OptimizeSVG() error = %v, wantError %v", err, tt.wantError)
+			}
+			if tt.wantError {
+				return
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("OptimizeSVG() = %q, want to contain %q", got, want)
+				}
+			}
+			for _, missing := range tt.wantMissing {
+				if strings.Contains(got, missing) {
+					t.Errorf("OptimizeSVG() = %q, should not contain %q", got, missing)
+				}
+			}
+		})
+	}
+}
+
+func TestOptimizeSVG_Deterministic(t *testing.T) {
+	charts := map[string]func() (string, error){
+		"language": func() (string, error) {
+			return GenerateLanguageChart(aggregator.RankLanguages(map[string]int{"Go": 100, "Rust": 100, "Python": 50}), 10)
+		},
+		"commit_languages": func() (string, error) {
+			return GenerateCommitLanguagesChart(map[string]int{"Go": 3, "Rust": 3, "Python": 3, "C": 1})
+		},
+		"commit_time": func() (string, error) {
+			return GenerateCommitTimeChart(map[int]int{1: 2, 13: 5})
+		},
+		"summary": func() (string, error) {
+			return GenerateSummaryCard(aggregator.SummaryStats{TotalStars: 3, RepositoryCount: 1})
+		},
+	}
+
+	for name, generate := range charts {
+		t.Run(name, func(t *testing.T) {
+			var first string
+			for i := 0; i < 10; i++ {
+				svg, err := generate()
+				if err != nil {
+					t.Fatalf("generate error = %v", err)
+				}
+				optimized, err := OptimizeSVG(svg)
+				if err != nil {
+					t.Fatalf("OptimizeSVG() error = %v", err)
+				}
+
+				if i == 0 {
+					first = optimized
+					// Optimizing twice doesn't change the output
+					again, err := OptimizeSVG(optimized)
+					if err != nil || again != optimized {
+						t.Errorf("OptimizeSVG() is not idempotent (error = %v)", err)
+					}
+					if len(optimized) >= len(svg) {
+						t.Errorf("OptimizeSVG() size = %d, want smaller than %d", len(optimized), len(svg))
+					}
+				} else if optimized != first {
+					t.Fatalf("OptimizeSVG() output differs between runs")
+				}
+			}
+		})
+	}
+}
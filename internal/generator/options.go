@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Theme color theme for SVG charts
+type Theme struct {
+	Name       string // Theme name
+	Background string // Card background color
+	Surface    string // Secondary background color (bar backgrounds, gradients)
+	Border     string // Card border color
+	Grid       string // Grid line and empty slot color
+	Text       string // Text color
+	Accent     string // Title and highlight color
+}
+
+// DarkTheme default dark theme (GitHub dark)
+var DarkTheme = Theme{
+	Name:       "dark",
+	Background: DefaultBackgroundColor,
+	Surface:    "#161b22",
+	Border:     "#30363d",
+	Grid:       "#21262d",
+	Text:       DefaultTextColor,
+	Accent:     AccentColor,
+}
+
+// LightTheme light theme (GitHub light)
+var LightTheme = Theme{
+	Name:       "light",
+	Background: "#ffffff",
+	Surface:    "#f6f8fa",
+	Border:     "#d0d7de",
+	Grid:       "#eaeef2",
+	Text:       "#24292f",
+	Accent:     "#0969da",
+}
+
+// Chart layouts
+const (
+	// LayoutPie pie chart layout (default for language chart)
+	LayoutPie = "pie"
+
+	// LayoutDonut donut chart layout
+	LayoutDonut = "donut"
+)
+
+// ChartOptions options to customize chart rendering
+type ChartOptions struct {
+	Theme    Theme  // Color theme
+	MaxItems int    // Maximum number of items to display (0 = chart default)
+	Layout   string // Chart layout (empty = chart default)
+}
+
+// DefaultChartOptions returns the default chart options
+func DefaultChartOptions() ChartOptions {
+	return ChartOptions{
+		Theme: DarkTheme,
+	}
+}
+
+// ThemeByName returns the theme with the specified name
+//
+// Preconditions:
+// - name is a theme name ("dark" or "light", case-insensitive)
+//
+// Postconditions:
+// - Returns the matching theme
+// - Returns error if the theme is unknown
+//
+// Invariants:
+// - Empty name returns DarkTheme
+func ThemeByName(name string) (Theme, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "dark":
+		return DarkTheme, nil
+	case "light":
+		return LightTheme, nil
+	default:
+		return Theme{}, fmt.Errorf("unknown theme: %s", name)
+	}
+}
+
+// ParseChartOptions builds chart options from key/value attributes
+//
+// Preconditions:
+// - attrs is in the format map[string]string{attribute name: value} (e.g., {"theme": "light", "max": "8"})
+// - base is the options used for attributes that are not specified
+//
+// Postconditions:
+// - Returns chart options with attributes applied
+// - Returns error if an attribute value is invalid
+//
+// Invariants:
+// - Unknown attributes are ignored (they may be used by other stages)
+func ParseChartOptions(attrs map[string]string, base ChartOptions) (ChartOptions, error) {
+	opts := base
+
+	if name, ok := attrs["theme"]; ok {
+		theme, err := ThemeByName(name)
+		if err != nil {
+			return base, err
+		}
+		opts.Theme = theme
+	}
+
+	if maxStr, ok := attrs["max"]; ok {
+		maxItems, err := strconv.Atoi(maxStr)
+		if err != nil || maxItems < 1 {
+			return base, fmt.Errorf("invalid max value: %s", maxStr)
+		}
+		opts.MaxItems = maxItems
+	}
+
+	if layout, ok := attrs["layout"]; ok {
+		layout = strings.ToLower(layout)
+		if layout != LayoutPie && layout != LayoutDonut {
+			return base, fmt.Errorf("unknown layout: %s", attrs["layout"])
+		}
+		opts.Layout = layout
+	}
+
+	return opts, nil
+}
+
+// withDefaults fills unset options with default values
+func (o ChartOptions) withDefaults() ChartOptions {
+	if o.Theme.Name == "" {
+		o.Theme = DarkTheme
+	}
+	return o
+}
@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+)
+
+func TestParseChartOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		attrs      map[string]string
+		wantTheme  string
+		wantMax    int
+		wantLayout string
+		wantError  bool
+	}{
+		{
+			name:      "No attributes",
+			attrs:     map[string]string{},
+			wantTheme: "dark",
+		},
+		{
+			name:       "All attributes",
+			attrs:      map[string]string{"theme": "light", "max": "8", "layout": "donut"},
+			wantTheme:  "light",
+			wantMax:    8,
+			wantLayout: LayoutDonut,
+		},
+		{
+			name:      "Unknown attributes are ignored",
+			attrs:     map[string]string{"format": "png"},
+			wantTheme: "dark",
+		},
+		{
+			name:      "Error: unknown theme",
+			attrs:     map[string]string{"theme": "sepia"},
+			wantError: true,
+		},
+		{
+			name:      "Error: invalid max",
+			attrs:     map[string]string{"max": "zero"},
+			wantError: true,
+		},
+		{
+			name:      "Error: unknown layout",
+			attrs:     map[string]string{"layout": "radar"},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseChartOptions(tt.attrs, DefaultChartOptions())
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("ParseChartOptions() should have returned an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseChartOptions() error = %v", err)
+			}
+			if opts.Theme.Name != tt.wantTheme {
+				t.Errorf("ParseChartOptions() theme = %s, want %s", opts.Theme.Name, tt.wantTheme)
+			}
+			if opts.MaxItems != tt.wantMax {
+				t.Errorf("ParseChartOptions() max = %d, want %d", opts.MaxItems, tt.wantMax)
+			}
+			if opts.Layout != tt.wantLayout {
+				t.Errorf("ParseChartOptions() layout = %s, want %s", opts.Layout, tt.wantLayout)
+			}
+		})
+	}
+}
+
+func TestGenerateLanguageChartWithOptions(t *testing.T) {
+	languages := []aggregator.LanguageStat{
+		{Language: "Go", Bytes: 500, Percentage: 50.0},
+		{Language: "Python", Bytes: 300, Percentage: 30.0},
+		{Language: "Rust", Bytes: 150, Percentage: 15.0},
+		{Language: "C", Bytes: 50, Percentage: 5.0},
+	}
+
+	opts := ChartOptions{Theme: LightTheme, MaxItems: 2, Layout: LayoutDonut}
+	svg, err := GenerateLanguageChartWithOptions(languages, opts)
+	if err != nil {
+		t.Fatalf("GenerateLanguageChartWithOptions() error = %v", err)
+	}
+
+	if !strings.Contains(svg, LightTheme.Background) {
+		t.Errorf("GenerateLanguageChartWithOptions() should use light theme background")
+	}
+	if strings.Contains(svg, DarkTheme.Background) {
+		t.Errorf("GenerateLanguageChartWithOptions() should not use dark theme background")
+	}
+	if !strings.Contains(svg, "<circle") {
+		t.Errorf("GenerateLanguageChartWithOptions() should draw donut hole")
+	}
+	if !strings.Contains(svg, ">Other<") || strings.Contains(svg, ">Rust<") {
+		t.Errorf("GenerateLanguageChartWithOptions() should group languages beyond max into Other")
+	}
+	if !strings.Contains(svg, "20.0%") {
+		t.Errorf("GenerateLanguageChartWithOptions() Other should have 20.0%%")
+	}
+}
+
+func TestGenerateCommitLanguagesChartWithOptions_MaxItems(t *testing.T) {
+	commitLanguages := map[string]int{"Go": 10, "Python": 8, "Rust": 6}
+
+	svg, err := GenerateCommitLanguagesChartWithOptions(commitLanguages, ChartOptions{MaxItems: 2})
+	if err != nil {
+		t.Fatalf("GenerateCommitLanguagesChartWithOptions() error = %v", err)
+	}
+
+	if strings.Contains(svg, ">Rust<") {
+		t.Errorf("GenerateCommitLanguagesChartWithOptions() should display only top 2 languages")
+	}
+	if !strings.Contains(svg, DarkTheme.Background) {
+		t.Errorf("GenerateCommitLanguagesChartWithOptions() should default to dark theme")
+	}
+}
@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultPNGScale default scale factor of PNG images (2x for high-DPI displays)
+const DefaultPNGScale = 2.0
+
+// MaxPNGScale maximum scale factor of PNG images (larger values are clamped by the workflow)
+const MaxPNGScale = 8.0
+
+// maxPNGDimension maximum width and height of PNG images in pixels
+// Larger images are rejected instead of allocating gigabytes of memory
+const maxPNGDimension = 16384
+
+// RasterizeSVG converts SVG content generated by this package into PNG data
+//
+// Preconditions:
+// - svgContent is a valid SVG string with width/height or viewBox
+// - scale is the scale factor (1.0 = same pixel size as the SVG)
+//
+// Postconditions:
+// - Returns PNG encoded image data
+// - Returns error if the SVG cannot be parsed, or the image would exceed 16384 pixels in either dimension
+//
+// Invariants:
+// - Pure Go implementation (no external commands or cgo)
+// - Filters (shadows, glows) are not rendered, and emoji glyphs are skipped
+func RasterizeSVG(svgContent string, scale float64) ([]byte, error) {
+	if svgContent == "" {
+		return nil, fmt.Errorf("SVG content is empty")
+	}
+
+	if scale <= 0 || math.IsInf(scale, 0) || math.IsNaN(scale) {
+		return nil, fmt.Errorf("invalid scale: %g", scale)
+	}
+
+	img, err := rasterizeSVG(svgContent, scale)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SavePNG rasterizes SVG content and saves it as a PNG file
+//
+// Preconditions:
+// - svgContent is a valid SVG string
+// - filePath is a valid file path
+// - scale is the scale factor (1.0 = same pixel size as the SVG)
+//
+// Postconditions:
+// - PNG file is created at the specified path
+//
+// Invariants:
+// - Directories are automatically created if they don't exist
+// - Existing files are overwritten
+func SavePNG(svgContent, filePath string, scale float64) error {
+	if filePath == "" {
+		return fmt.Errorf("file path is empty")
+	}
+
+	data, err := RasterizeSVG(svgContent, scale)
+	if err != nil {
+		return err
+	}
+
+	// Create directory if it doesn't exist
+	dir := filepath.Dir(filePath)
+	if dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to save PNG file: %w", err)
+	}
+
+	return nil
+}
+
+// PNGPath returns the PNG file path corresponding to an SVG file path
+// Example: "images/language_chart.svg" -> "images/language_chart.png"
+func PNGPath(svgPath string) string {
+	return strings.TrimSuffix(svgPath, filepath.Ext(svgPath)) + ".png"
+}
@@ -0,0 +1,259 @@
+package generator
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+)
+
+func TestRasterizeSVG(t *testing.T) {
+	svg := `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" width="100" height="50" viewBox="0 0 100 50">
+  <defs>
+    <linearGradient id="grad" x1="0%" y1="0%" x2="100%" y2="0%">
+      <stop offset="0%" style="stop-color:#000000;stop-opacity:1" />
+      <stop offset="100%" style="stop-color:#ffffff;stop-opacity:1" />
+    </linearGradient>
+  </defs>
+  <rect width="50" height="50" fill="#ff0000"/>
+  <rect x="50" width="50" height="25" fill="url(#grad)"/>
+  <path d="M 75 25 L 100 25 L 100 50 L 75 50 Z" fill="#00ff00" opacity="0.5"/>
+</svg>`
+
+	tests := []struct {
+		name       string
+		svg        string
+		scale      float64
+		wantWidth  int
+		wantHeight int
+		wantError  bool
+	}{
+		{
+			name:       "Normal case: scale 1",
+			svg:        svg,
+			scale:      1,
+			wantWidth:  100,
+			wantHeight: 50,
+		},
+		{
+			name:       "Normal case: scale 2",
+			svg:        svg,
+			scale:      2,
+			wantWidth:  200,
+			wantHeight: 100,
+		},
+		{
+			name:      "Error: empty content",
+			svg:       "",
+			scale:     1,
+			wantError: true,
+		},
+		{
+			name:      "Error: invalid scale",
+			svg:       svg,
+			scale:     0,
+			wantError: true,
+		},
+		{
+			name:      "Error: infinite scale",
+			svg:       svg,
+			scale:     math.Inf(1),
+			wantError: true,
+		},
+		{
+			name:      "Error: NaN scale",
+			svg:       svg,
+			scale:     math.NaN(),
+			wantError: true,
+		},
+		{
+			name:      "Error: image too large",
+			svg:       svg,
+			scale:     1e9,
+			wantError: true,
+		},
+		{
+			name:      "Error: not SVG",
+			svg:       `<html></html>`,
+			scale:     1,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := RasterizeSVG(tt.svg, tt.scale)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("RasterizeSVG() error = %v, wantError %v", err, tt.wantError)
+			}
+			if tt.wantError {
+				return
+			}
+
+			img, err := png.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("failed to decode PNG: %v", err)
+			}
+			if img.Bounds().Dx() != tt.wantWidth || img.Bounds().Dy() != tt.wantHeight {
+				t.Errorf("size = %dx%d, want %dx%d", img.Bounds().Dx(), img.Bounds().Dy(), tt.wantWidth, tt.wantHeight)
+			}
+
+			pixel := func(x, y float64) color.NRGBA {
+				return color.NRGBAModel.Convert(img.At(int(x*tt.scale), int(y*tt.scale))).(color.NRGBA)
+			}
+
+			if got := pixel(25, 25); got != (color.NRGBA{R: 255, A: 255}) {
+				t.Errorf("solid fill pixel = %v, want red", got)
+			}
+			if left, right := pixel(52, 10), pixel(98, 10); left.R >= right.R {
+				t.Errorf("gradient is not increasing: left %v, right %v", left, right)
+			}
+			if got := pixel(90, 40); got.G == 0 || got.A == 0 || got.A == 255 {
+				t.Errorf("semi-transparent pixel = %v, want translucent green", got)
+			}
+			if got := pixel(60, 40); got.A != 0 {
+				t.Errorf("unpainted pixel = %v, want transparent", got)
+			}
+		})
+	}
+}
+
+func TestRasterizeSVG_GeneratedCharts(t *testing.T) {
+	languageChart, err := GenerateLanguageChart([]aggregator.LanguageStat{
+		{Language: "Go", Percentage: 60},
+		{Language: "Python", Percentage: 40},
+	}, 10)
+	if err != nil {
+		t.Fatalf("GenerateLanguageChart() error = %v", err)
+	}
+
+	summaryCard, err := GenerateSummaryCard(aggregator.SummaryStats{TotalStars: 10, RepositoryCount: 2})
+	if err != nil {
+		t.Fatalf("GenerateSummaryCard() error = %v", err)
+	}
+
+	for name, svg := range map[string]string{"language": languageChart, "summary": summaryCard} {
+		if _, err := RasterizeSVG(svg, DefaultPNGScale); err != nil {
+			t.Errorf("RasterizeSVG(%s) error = %v", name, err)
+		}
+	}
+}
+
+func TestSavePNG(t *testing.T) {
+	svg := `<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"><rect width="10" height="10" fill="#fff"/></svg>`
+	path := filepath.Join(t.TempDir(), "subdir", "chart.png")
+
+	if err := SavePNG(svg, path, 1); err != nil {
+		t.Fatalf("SavePNG() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read PNG: %v", err)
+	}
+	if _, err := png.Decode(bytes.NewReader(data)); err != nil {
+		t.Errorf("saved file is not a valid PNG: %v", err)
+	}
+
+	if err := SavePNG(svg, "", 1); err == nil {
+		t.Error("SavePNG() with empty path should return error")
+	}
+}
+
+// TestRasterizeSVG_NumberAfterClosePath verifies that a number after closepath is rejected instead of looping forever
+func TestRasterizeSVG_NumberAfterClosePath(t *testing.T) {
+	svg := `<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"><path d="M0 0 L5 5 Z 3" fill="#000000"/></svg>`
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := RasterizeSVG(svg, 1)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("RasterizeSVG() should return error for a number after closepath")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("RasterizeSVG() did not return within 5s")
+	}
+}
+
+func TestParsePathData(t *testing.T) {
+	tests := []struct {
+		name       string
+		d          string
+		wantPaths  int
+		wantClosed bool
+		wantLast   point
+		wantError  bool
+	}{
+		{
+			name:       "Pie slice with arc",
+			d:          "M 50.0 50.0 L 100.0 50.0 A 50.0 50.0 0 0 1 50.0 100.0 Z",
+			wantPaths:  1,
+			wantClosed: true,
+			wantLast:   point{50, 100},
+		},
+		{
+			name:      "Relative commands and implicit lineto",
+			d:         "m10,10 5,0 0,5h-5v-5",
+			wantPaths: 1,
+			wantLast:  point{10, 10},
+		},
+		{
+			name:       "Multiple subpaths",
+			d:          "M0 0L1 1ZM2 2L3 3",
+			wantPaths:  2,
+			wantClosed: true,
+			wantLast:   point{3, 3},
+		},
+		{
+			name:      "Error: missing coordinates",
+			d:         "M 10",
+			wantError: true,
+		},
+		{
+			name:      "Error: number after closepath",
+			d:         "M0 0 L5 5 Z 3",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subpaths, err := parsePathData(tt.d)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("parsePathData() error = %v, wantError %v", err, tt.wantError)
+			}
+			if tt.wantError {
+				return
+			}
+
+			if len(subpaths) != tt.wantPaths {
+				t.Fatalf("parsePathData() subpaths = %d, want %d", len(subpaths), tt.wantPaths)
+			}
+			if subpaths[0].Closed != tt.wantClosed {
+				t.Errorf("parsePathData() closed = %v, want %v", subpaths[0].Closed, tt.wantClosed)
+			}
+			last := subpaths[len(subpaths)-1].Points
+			if got := last[len(last)-1]; got != tt.wantLast {
+				t.Errorf("parsePathData() last point = %v, want %v", got, tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestPNGPath(t *testing.T) {
+	if got := PNGPath("images/language_chart.svg"); got != "images/language_chart.png" {
+		t.Errorf("PNGPath() = %q, want %q", got, "images/language_chart.png")
+	}
+}
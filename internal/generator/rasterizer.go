@@ -0,0 +1,974 @@
+package generator
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// The rasterizer supports the subset of SVG produced by the chart generators:
+// <svg>, <g>, <rect>, <circle>, <line>, <path>, <text> and <linearGradient>.
+// Filters (shadows, glows) are ignored, and glyphs missing from the Go fonts (emoji) are skipped.
+
+// curveSegments Number of line segments used to approximate curves
+const curveSegments = 24
+
+// svgNode element of a parsed SVG document
+type svgNode struct {
+	Name     string
+	Attrs    map[string]string
+	Children []*svgNode
+	Text     string
+}
+
+// point point in user units
+type point struct {
+	X, Y float64
+}
+
+// subpath flattened subpath of a shape
+type subpath struct {
+	Points []point
+	Closed bool
+}
+
+// gradientStop color stop of a linear gradient
+type gradientStop struct {
+	Offset float64
+	Color  color.NRGBA
+}
+
+// linearGradient linear gradient in objectBoundingBox units
+type linearGradient struct {
+	X1, Y1, X2, Y2 float64
+	Stops          []gradientStop
+}
+
+// svgStyle presentation attributes inherited by child elements
+type svgStyle struct {
+	Fill        string
+	Stroke      string
+	StrokeWidth float64
+	Opacity     float64
+	FontSize    float64
+	FontWeight  string
+	FontStyle   string
+	TextAnchor  string
+}
+
+// svgRasterizer draws parsed SVG nodes onto an RGBA image
+type svgRasterizer struct {
+	dst              *image.RGBA
+	scaleX, scaleY   float64
+	originX, originY float64
+	gradients        map[string]*linearGradient
+	faces            map[string]font.Face
+}
+
+var (
+	fontsOnce sync.Once
+	fonts     map[string]*opentype.Font
+	fontsErr  error
+)
+
+// rasterizeSVG parses SVG content and draws it at the specified scale
+func rasterizeSVG(svgContent string, scale float64) (*image.RGBA, error) {
+	root, err := parseSVGTree(svgContent)
+	if err != nil {
+		return nil, err
+	}
+
+	width := parseNumber(root.Attrs["width"])
+	height := parseNumber(root.Attrs["height"])
+	viewBox := strings.Fields(strings.ReplaceAll(root.Attrs["viewBox"], ",", " "))
+
+	originX, originY, viewWidth, viewHeight := 0.0, 0.0, width, height
+	if len(viewBox) == 4 {
+		originX = parseNumber(viewBox[0])
+		originY = parseNumber(viewBox[1])
+		viewWidth = parseNumber(viewBox[2])
+		viewHeight = parseNumber(viewBox[3])
+		if width == 0 || height == 0 {
+			width, height = viewWidth, viewHeight
+		}
+	}
+	if width <= 0 || height <= 0 || viewWidth <= 0 || viewHeight <= 0 {
+		return nil, fmt.Errorf("SVG size is not specified")
+	}
+
+	// Check the size before allocating the image (image.NewRGBA panics on huge dimensions)
+	scaledWidth, scaledHeight := math.Ceil(width*scale), math.Ceil(height*scale)
+	if !(scaledWidth <= maxPNGDimension && scaledHeight <= maxPNGDimension) {
+		return nil, fmt.Errorf("PNG size %gx%g exceeds the maximum of %dx%d pixels", scaledWidth, scaledHeight, maxPNGDimension, maxPNGDimension)
+	}
+	pixelWidth := int(scaledWidth)
+	pixelHeight := int(scaledHeight)
+
+	r := &svgRasterizer{
+		dst:       image.NewRGBA(image.Rect(0, 0, pixelWidth, pixelHeight)),
+		scaleX:    float64(pixelWidth) / viewWidth,
+		scaleY:    float64(pixelHeight) / viewHeight,
+		originX:   originX,
+		originY:   originY,
+		gradients: make(map[string]*linearGradient),
+		faces:     make(map[string]font.Face),
+	}
+	defer r.closeFaces()
+
+	r.collectGradients(root)
+	style := svgStyle{Fill: "#000000", Stroke: "none", StrokeWidth: 1, Opacity: 1, FontSize: 16, TextAnchor: "start"}
+	if err := r.drawChildren(root, style); err != nil {
+		return nil, err
+	}
+
+	return r.dst, nil
+}
+
+// parseSVGTree parses SVG content into a node tree
+func parseSVGTree(svgContent string) (*svgNode, error) {
+	decoder := xml.NewDecoder(strings.NewReader(svgContent))
+
+	var root *svgNode
+	var stack []*svgNode
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SVG: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &svgNode{Name: t.Name.Local, Attrs: make(map[string]string)}
+			for _, attr := range t.Attr {
+				node.Attrs[attr.Name.Local] = attr.Value
+			}
+			// Style declarations take precedence over attributes
+			for _, declaration := range strings.Split(node.Attrs["style"], ";") {
+				if key, value, ok := strings.Cut(declaration, ":"); ok {
+					node.Attrs[strings.TrimSpace(key)] = strings.TrimSpace(value)
+				}
+			}
+
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, node)
+			} else if root == nil {
+				root = node
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += string(t)
+			}
+		}
+	}
+
+	if root == nil || root.Name != "svg" {
+		return nil, fmt.Errorf("root element is not svg")
+	}
+
+	return root, nil
+}
+
+// collectGradients collects linear gradient definitions by id
+func (r *svgRasterizer) collectGradients(node *svgNode) {
+	if node.Name == "linearGradient" && node.Attrs["id"] != "" {
+		gradient := &linearGradient{
+			X1: parseFraction(node.Attrs["x1"], 0),
+			Y1: parseFraction(node.Attrs["y1"], 0),
+			X2: parseFraction(node.Attrs["x2"], 1),
+			Y2: parseFraction(node.Attrs["y2"], 0),
+		}
+		for _, child := range node.Children {
+			if child.Name != "stop" {
+				continue
+			}
+			c, ok := parseColor(child.Attrs["stop-color"])
+			if !ok {
+				c = color.NRGBA{A: 255}
+			}
+			if opacity, err := strconv.ParseFloat(child.Attrs["stop-opacity"], 64); err == nil {
+				c.A = uint8(float64(c.A) * clamp01(opacity))
+			}
+			gradient.Stops = append(gradient.Stops, gradientStop{Offset: parseFraction(child.Attrs["offset"], 0), Color: c})
+		}
+		r.gradients[node.Attrs["id"]] = gradient
+		return
+	}
+
+	for _, child := range node.Children {
+		r.collectGradients(child)
+	}
+}
+
+// drawChildren draws child elements with the inherited style
+func (r *svgRasterizer) drawChildren(node *svgNode, style svgStyle) error {
+	for _, child := range node.Children {
+		if err := r.drawNode(child, style.inherit(child.Attrs)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawNode draws a single element
+func (r *svgRasterizer) drawNode(node *svgNode, style svgStyle) error {
+	switch node.Name {
+	case "svg", "g":
+		return r.drawChildren(node, style)
+	case "rect":
+		r.drawShape(rectPath(node.Attrs), style)
+	case "circle":
+		r.drawShape(circlePath(node.Attrs), style)
+	case "line":
+		style.Fill = "none"
+		r.drawShape([]subpath{{Points: []point{
+			{parseNumber(node.Attrs["x1"]), parseNumber(node.Attrs["y1"])},
+			{parseNumber(node.Attrs["x2"]), parseNumber(node.Attrs["y2"])},
+		}}}, style)
+	case "path":
+		subpaths, err := parsePathData(node.Attrs["d"])
+		if err != nil {
+			return err
+		}
+		r.drawShape(subpaths, style)
+	case "text":
+		return r.drawText(node, style)
+	}
+	// Other elements (defs, filter, ...) are not drawn
+	return nil
+}
+
+// inherit applies presentation attributes of an element
+func (s svgStyle) inherit(attrs map[string]string) svgStyle {
+	if v, ok := attrs["fill"]; ok {
+		s.Fill = v
+	}
+	if v, ok := attrs["stroke"]; ok {
+		s.Stroke = v
+	}
+	if v, ok := attrs["stroke-width"]; ok {
+		s.StrokeWidth = parseNumber(v)
+	}
+	if v, ok := attrs["opacity"]; ok {
+		if opacity, err := strconv.ParseFloat(v, 64); err == nil {
+			s.Opacity *= clamp01(opacity)
+		}
+	}
+	if v, ok := attrs["font-size"]; ok {
+		s.FontSize = parseNumber(v)
+	}
+	if v, ok := attrs["font-weight"]; ok {
+		s.FontWeight = v
+	}
+	if v, ok := attrs["font-style"]; ok {
+		s.FontStyle = v
+	}
+	if v, ok := attrs["text-anchor"]; ok {
+		s.TextAnchor = v
+	}
+	return s
+}
+
+// drawShape fills and strokes subpaths
+func (r *svgRasterizer) drawShape(subpaths []subpath, style svgStyle) {
+	if len(subpaths) == 0 {
+		return
+	}
+
+	if paint := r.paint(style.Fill, style.Opacity, boundingBox(subpaths)); paint != nil {
+		r.fill(subpaths, paint)
+	}
+
+	if style.StrokeWidth > 0 {
+		if paint := r.paint(style.Stroke, style.Opacity, boundingBox(subpaths)); paint != nil {
+			r.fill(strokeOutline(subpaths, style.StrokeWidth/2), paint)
+		}
+	}
+}
+
+// fill fills polygons with the paint using the nonzero rule
+func (r *svgRasterizer) fill(polygons []subpath, paint image.Image) {
+	bounds := r.dst.Bounds()
+	z := vector.NewRasterizer(bounds.Dx(), bounds.Dy())
+	for _, polygon := range polygons {
+		if len(polygon.Points) < 3 {
+			continue
+		}
+		first := r.toDevice(polygon.Points[0])
+		z.MoveTo(float32(first.X), float32(first.Y))
+		for _, p := range polygon.Points[1:] {
+			d := r.toDevice(p)
+			z.LineTo(float32(d.X), float32(d.Y))
+		}
+		z.ClosePath()
+	}
+	z.Draw(r.dst, bounds, paint, image.Point{})
+}
+
+// paint resolves a fill or stroke value into a source image
+// Returns nil if nothing should be painted
+func (r *svgRasterizer) paint(value string, opacity float64, bbox [2]point) image.Image {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "none" || opacity <= 0 {
+		return nil
+	}
+
+	if strings.HasPrefix(value, "url(#") {
+		gradient, ok := r.gradients[strings.TrimSuffix(strings.TrimPrefix(value, "url(#"), ")")]
+		if !ok || len(gradient.Stops) == 0 {
+			return nil
+		}
+		return &gradientImage{
+			gradient: gradient,
+			min:      r.toDevice(bbox[0]),
+			max:      r.toDevice(bbox[1]),
+			opacity:  opacity,
+		}
+	}
+
+	c, ok := parseColor(value)
+	if !ok {
+		return nil
+	}
+	c.A = uint8(float64(c.A) * opacity)
+	return image.NewUniform(c)
+}
+
+// drawText draws a text element with the Go fonts
+func (r *svgRasterizer) drawText(node *svgNode, style svgStyle) error {
+	face, f, err := r.face(style)
+	if err != nil {
+		return err
+	}
+
+	text := drawableText(node.Text, f)
+	if text == "" {
+		return nil
+	}
+
+	var c color.NRGBA
+	fill := strings.TrimSpace(style.Fill)
+	if strings.HasPrefix(fill, "url(#") {
+		gradient, ok := r.gradients[strings.TrimSuffix(strings.TrimPrefix(fill, "url(#"), ")")]
+		if !ok || len(gradient.Stops) == 0 {
+			return nil
+		}
+		c = gradient.Stops[0].Color
+	} else if parsed, ok := parseColor(fill); ok {
+		c = parsed
+	} else {
+		return nil
+	}
+	c.A = uint8(float64(c.A) * style.Opacity)
+
+	origin := r.toDevice(point{parseNumber(node.Attrs["x"]), parseNumber(node.Attrs["y"])})
+	advance := float64(font.MeasureString(face, text)) / 64
+	switch style.TextAnchor {
+	case "middle":
+		origin.X -= advance / 2
+	case "end":
+		origin.X -= advance
+	}
+
+	drawer := &font.Drawer{
+		Dst:  r.dst,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.Point26_6{X: fixed.Int26_6(origin.X * 64), Y: fixed.Int26_6(origin.Y * 64)},
+	}
+	drawer.DrawString(text)
+
+	return nil
+}
+
+// face returns the font face for the style (cached per size and variant)
+func (r *svgRasterizer) face(style svgStyle) (font.Face, *opentype.Font, error) {
+	fontsOnce.Do(loadFonts)
+	if fontsErr != nil {
+		return nil, nil, fontsErr
+	}
+
+	variant := "regular"
+	bold := style.FontWeight == "bold" || style.FontWeight == "bolder" || parseNumber(style.FontWeight) >= 600
+	italic := style.FontStyle == "italic" || style.FontStyle == "oblique"
+	switch {
+	case bold && italic:
+		variant = "bolditalic"
+	case bold:
+		variant = "bold"
+	case italic:
+		variant = "italic"
+	}
+
+	size := style.FontSize * r.scaleY
+	key := fmt.Sprintf("%s/%.2f", variant, size)
+	if face, ok := r.faces[key]; ok {
+		return face, fonts[variant], nil
+	}
+
+	face, err := opentype.NewFace(fonts[variant], &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingNone})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create font face: %w", err)
+	}
+	r.faces[key] = face
+
+	return face, fonts[variant], nil
+}
+
+// closeFaces releases cached font faces
+func (r *svgRasterizer) closeFaces() {
+	for _, face := range r.faces {
+		face.Close()
+	}
+}
+
+// toDevice converts user units to device pixels
+func (r *svgRasterizer) toDevice(p point) point {
+	return point{(p.X - r.originX) * r.scaleX, (p.Y - r.originY) * r.scaleY}
+}
+
+// loadFonts parses the embedded Go fonts
+func loadFonts() {
+	sources := map[string][]byte{
+		"regular":    goregular.TTF,
+		"bold":       gobold.TTF,
+		"italic":     goitalic.TTF,
+		"bolditalic": gobolditalic.TTF,
+	}
+
+	fonts = make(map[string]*opentype.Font, len(sources))
+	for name, ttf := range sources {
+		f, err := opentype.Parse(ttf)
+		if err != nil {
+			fontsErr = fmt.Errorf("failed to parse font %s: %w", name, err)
+			return
+		}
+		fonts[name] = f
+	}
+}
+
+// drawableText collapses whitespace like SVG and removes characters without glyphs (e.g., emoji)
+func drawableText(text string, f *opentype.Font) string {
+	var buf sfnt.Buffer
+	var result strings.Builder
+	for _, r := range text {
+		if r == '\n' || r == '\r' || r == '\t' {
+			r = ' '
+		}
+		if r != ' ' {
+			if index, err := f.GlyphIndex(&buf, r); err != nil || index == 0 {
+				continue
+			}
+		}
+		result.WriteRune(r)
+	}
+	return strings.Join(strings.Fields(result.String()), " ")
+}
+
+// gradientImage source image that evaluates a linear gradient over a bounding box
+type gradientImage struct {
+	gradient *linearGradient
+	min, max point
+	opacity  float64
+}
+
+// ColorModel implements image.Image
+func (g *gradientImage) ColorModel() color.Model {
+	return color.NRGBAModel
+}
+
+// Bounds implements image.Image
+func (g *gradientImage) Bounds() image.Rectangle {
+	return image.Rect(-1e9, -1e9, 1e9, 1e9)
+}
+
+// At implements image.Image
+func (g *gradientImage) At(x, y int) color.Color {
+	// Position in objectBoundingBox units
+	u, v := 0.0, 0.0
+	if width := g.max.X - g.min.X; width > 0 {
+		u = (float64(x) + 0.5 - g.min.X) / width
+	}
+	if height := g.max.Y - g.min.Y; height > 0 {
+		v = (float64(y) + 0.5 - g.min.Y) / height
+	}
+
+	dx, dy := g.gradient.X2-g.gradient.X1, g.gradient.Y2-g.gradient.Y1
+	t := 0.0
+	if length := dx*dx + dy*dy; length > 0 {
+		t = clamp01(((u-g.gradient.X1)*dx + (v-g.gradient.Y1)*dy) / length)
+	}
+
+	c := g.gradient.colorAt(t)
+	c.A = uint8(float64(c.A) * g.opacity)
+	return c
+}
+
+// colorAt interpolates the gradient color at offset t
+func (lg *linearGradient) colorAt(t float64) color.NRGBA {
+	stops := lg.Stops
+	if t <= stops[0].Offset {
+		return stops[0].Color
+	}
+	for i := 1; i < len(stops); i++ {
+		if t <= stops[i].Offset {
+			prev, next := stops[i-1], stops[i]
+			ratio := 0.0
+			if span := next.Offset - prev.Offset; span > 0 {
+				ratio = (t - prev.Offset) / span
+			}
+			lerp := func(a, b uint8) uint8 {
+				return uint8(math.Round(float64(a) + (float64(b)-float64(a))*ratio))
+			}
+			return color.NRGBA{
+				R: lerp(prev.Color.R, next.Color.R),
+				G: lerp(prev.Color.G, next.Color.G),
+				B: lerp(prev.Color.B, next.Color.B),
+				A: lerp(prev.Color.A, next.Color.A),
+			}
+		}
+	}
+	return stops[len(stops)-1].Color
+}
+
+// rectPath builds the outline of a (rounded) rectangle
+func rectPath(attrs map[string]string) []subpath {
+	x, y := parseNumber(attrs["x"]), parseNumber(attrs["y"])
+	width, height := parseNumber(attrs["width"]), parseNumber(attrs["height"])
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	rx, hasRX := attrs["rx"]
+	ry, hasRY := attrs["ry"]
+	if !hasRY {
+		ry = rx
+	}
+	if !hasRX {
+		rx = ry
+	}
+	radiusX := math.Min(parseNumber(rx), width/2)
+	radiusY := math.Min(parseNumber(ry), height/2)
+
+	if radiusX <= 0 || radiusY <= 0 {
+		return []subpath{{Points: []point{{x, y}, {x + width, y}, {x + width, y + height}, {x, y + height}}, Closed: true}}
+	}
+
+	var points []point
+	corners := []struct {
+		cx, cy, start float64
+	}{
+		{x + width - radiusX, y + radiusY, -math.Pi / 2},
+		{x + width - radiusX, y + height - radiusY, 0},
+		{x + radiusX, y + height - radiusY, math.Pi / 2},
+		{x + radiusX, y + radiusY, math.Pi},
+	}
+	for _, corner := range corners {
+		for i := 0; i <= curveSegments/4; i++ {
+			angle := corner.start + (math.Pi/2)*float64(i)/float64(curveSegments/4)
+			points = append(points, point{corner.cx + radiusX*math.Cos(angle), corner.cy + radiusY*math.Sin(angle)})
+		}
+	}
+
+	return []subpath{{Points: points, Closed: true}}
+}
+
+// circlePath builds the outline of a circle
+func circlePath(attrs map[string]string) []subpath {
+	cx, cy, radius := parseNumber(attrs["cx"]), parseNumber(attrs["cy"]), parseNumber(attrs["r"])
+	if radius <= 0 {
+		return nil
+	}
+	return []subpath{{Points: ellipsePoints(point{cx, cy}, radius, radius), Closed: true}}
+}
+
+// ellipsePoints approximates an ellipse with line segments
+func ellipsePoints(center point, rx, ry float64) []point {
+	points := make([]point, 0, curveSegments*2)
+	for i := 0; i < curveSegments*2; i++ {
+		angle := 2 * math.Pi * float64(i) / float64(curveSegments*2)
+		points = append(points, point{center.X + rx*math.Cos(angle), center.Y + ry*math.Sin(angle)})
+	}
+	return points
+}
+
+// strokeOutline converts subpaths into polygons covering the stroke (round joins and caps)
+func strokeOutline(subpaths []subpath, halfWidth float64) []subpath {
+	var polygons []subpath
+	for _, sp := range subpaths {
+		points := sp.Points
+		if sp.Closed && len(points) > 1 {
+			points = append(append([]point{}, points...), points[0])
+		}
+		for i := 0; i+1 < len(points); i++ {
+			a, b := points[i], points[i+1]
+			dx, dy := b.X-a.X, b.Y-a.Y
+			length := math.Hypot(dx, dy)
+			if length == 0 {
+				continue
+			}
+			nx, ny := -dy/length*halfWidth, dx/length*halfWidth
+			polygons = append(polygons, orient(subpath{Points: []point{
+				{a.X + nx, a.Y + ny}, {b.X + nx, b.Y + ny}, {b.X - nx, b.Y - ny}, {a.X - nx, a.Y - ny},
+			}, Closed: true}))
+		}
+		for _, p := range points {
+			polygons = append(polygons, orient(subpath{Points: ellipsePoints(p, halfWidth, halfWidth), Closed: true}))
+		}
+	}
+	return polygons
+}
+
+// orient makes the polygon winding consistent so that overlapping polygons don't cancel out
+func orient(polygon subpath) subpath {
+	area := 0.0
+	points := polygon.Points
+	for i := range points {
+		j := (i + 1) % len(points)
+		area += points[i].X*points[j].Y - points[j].X*points[i].Y
+	}
+	if area < 0 {
+		for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+			points[i], points[j] = points[j], points[i]
+		}
+	}
+	return polygon
+}
+
+// boundingBox returns the minimum and maximum points of subpaths
+func boundingBox(subpaths []subpath) [2]point {
+	minPoint := point{math.Inf(1), math.Inf(1)}
+	maxPoint := point{math.Inf(-1), math.Inf(-1)}
+	for _, sp := range subpaths {
+		for _, p := range sp.Points {
+			minPoint.X, minPoint.Y = math.Min(minPoint.X, p.X), math.Min(minPoint.Y, p.Y)
+			maxPoint.X, maxPoint.Y = math.Max(maxPoint.X, p.X), math.Max(maxPoint.Y, p.Y)
+		}
+	}
+	return [2]point{minPoint, maxPoint}
+}
+
+// parsePathData parses path data (M, L, H, V, C, Q, A, Z commands, absolute and relative)
+func parsePathData(d string) ([]subpath, error) {
+	tokens := tokenizePathData(d)
+
+	var subpaths []subpath
+	current := -1 // Index of the open subpath (-1 = none)
+	var pos, start point
+	command := byte(0)
+
+	number := func(i *int) (float64, error) {
+		if *i >= len(tokens) {
+			return 0, fmt.Errorf("unexpected end of path data: %s", d)
+		}
+		value, err := strconv.ParseFloat(tokens[*i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid path data: %s", d)
+		}
+		*i++
+		return value, nil
+	}
+	numbers := func(i *int, n int) ([]float64, error) {
+		values := make([]float64, n)
+		for k := range values {
+			value, err := number(i)
+			if err != nil {
+				return nil, err
+			}
+			values[k] = value
+		}
+		return values, nil
+	}
+	lineTo := func(p point) {
+		if current < 0 {
+			subpaths = append(subpaths, subpath{Points: []point{pos}})
+			current = len(subpaths) - 1
+		}
+		subpaths[current].Points = append(subpaths[current].Points, p)
+		pos = p
+	}
+
+	for i := 0; i < len(tokens); {
+		if c := tokens[i][0]; strings.IndexByte("MmLlHhVvCcQqAaZz", c) >= 0 {
+			command = c
+			i++
+		} else if command == 0 {
+			return nil, fmt.Errorf("invalid path data: %s", d)
+		}
+
+		relative := command >= 'a'
+		offset := point{}
+		if relative {
+			offset = pos
+		}
+
+		switch command | 0x20 {
+		case 'm':
+			v, err := numbers(&i, 2)
+			if err != nil {
+				return nil, err
+			}
+			pos = point{offset.X + v[0], offset.Y + v[1]}
+			start = pos
+			subpaths = append(subpaths, subpath{Points: []point{pos}})
+			current = len(subpaths) - 1
+			// Subsequent coordinate pairs are implicit lineto commands
+			if relative {
+				command = 'l'
+			} else {
+				command = 'L'
+			}
+		case 'l':
+			v, err := numbers(&i, 2)
+			if err != nil {
+				return nil, err
+			}
+			lineTo(point{offset.X + v[0], offset.Y + v[1]})
+		case 'h':
+			v, err := number(&i)
+			if err != nil {
+				return nil, err
+			}
+			lineTo(point{offset.X + v, pos.Y})
+		case 'v':
+			v, err := number(&i)
+			if err != nil {
+				return nil, err
+			}
+			lineTo(point{pos.X, offset.Y + v})
+		case 'c':
+			v, err := numbers(&i, 6)
+			if err != nil {
+				return nil, err
+			}
+			p0 := pos
+			p1 := point{offset.X + v[0], offset.Y + v[1]}
+			p2 := point{offset.X + v[2], offset.Y + v[3]}
+			p3 := point{offset.X + v[4], offset.Y + v[5]}
+			for s := 1; s <= curveSegments; s++ {
+				t := float64(s) / curveSegments
+				mt := 1 - t
+				lineTo(point{
+					mt*mt*mt*p0.X + 3*mt*mt*t*p1.X + 3*mt*t*t*p2.X + t*t*t*p3.X,
+					mt*mt*mt*p0.Y + 3*mt*mt*t*p1.Y + 3*mt*t*t*p2.Y + t*t*t*p3.Y,
+				})
+			}
+		case 'q':
+			v, err := numbers(&i, 4)
+			if err != nil {
+				return nil, err
+			}
+			p0 := pos
+			p1 := point{offset.X + v[0], offset.Y + v[1]}
+			p2 := point{offset.X + v[2], offset.Y + v[3]}
+			for s := 1; s <= curveSegments; s++ {
+				t := float64(s) / curveSegments
+				mt := 1 - t
+				lineTo(point{
+					mt*mt*p0.X + 2*mt*t*p1.X + t*t*p2.X,
+					mt*mt*p0.Y + 2*mt*t*p1.Y + t*t*p2.Y,
+				})
+			}
+		case 'a':
+			v, err := numbers(&i, 7)
+			if err != nil {
+				return nil, err
+			}
+			end := point{offset.X + v[5], offset.Y + v[6]}
+			for _, p := range arcPoints(pos, end, v[0], v[1], v[2], v[3] != 0, v[4] != 0) {
+				lineTo(p)
+			}
+		case 'z':
+			if current >= 0 {
+				subpaths[current].Closed = true
+				current = -1
+			}
+			pos = start
+			// Closepath takes no arguments, so a number after it is invalid (and would never be consumed)
+			command = 0
+		}
+	}
+
+	return subpaths, nil
+}
+
+// tokenizePathData splits path data into commands and numbers
+func tokenizePathData(d string) []string {
+	var tokens []string
+	var number strings.Builder
+	flush := func() {
+		if number.Len() > 0 {
+			tokens = append(tokens, number.String())
+			number.Reset()
+		}
+	}
+
+	for i := 0; i < len(d); i++ {
+		c := d[i]
+		switch {
+		case strings.IndexByte("MmLlHhVvCcQqAaZz", c) >= 0:
+			flush()
+			tokens = append(tokens, string(c))
+		case c == ' ' || c == ',' || c == '\t' || c == '\n' || c == '\r':
+			flush()
+		case c == '-' || c == '+':
+			// A sign starts a new number unless it follows an exponent
+			if s := number.String(); s != "" && s[len(s)-1] != 'e' && s[len(s)-1] != 'E' {
+				flush()
+			}
+			number.WriteByte(c)
+		case c == '.':
+			if strings.Contains(number.String(), ".") && !strings.ContainsAny(number.String(), "eE") {
+				flush()
+			}
+			number.WriteByte(c)
+		default:
+			number.WriteByte(c)
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+// arcPoints approximates an elliptical arc (endpoint parameterization) with points
+// See https://www.w3.org/TR/SVG11/implnote.html#ArcConversionEndpointToCenter
+func arcPoints(from, to point, rx, ry, rotation float64, largeArc, sweep bool) []point {
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 || from == to {
+		return []point{to}
+	}
+
+	phi := rotation * math.Pi / 180
+	cosPhi, sinPhi := math.Cos(phi), math.Sin(phi)
+
+	dx, dy := (from.X-to.X)/2, (from.Y-to.Y)/2
+	x1 := cosPhi*dx + sinPhi*dy
+	y1 := -sinPhi*dx + cosPhi*dy
+
+	// Scale up radii if they are too small to reach the end point
+	if lambda := (x1*x1)/(rx*rx) + (y1*y1)/(ry*ry); lambda > 1 {
+		rx *= math.Sqrt(lambda)
+		ry *= math.Sqrt(lambda)
+	}
+
+	numerator := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	denominator := rx*rx*y1*y1 + ry*ry*x1*x1
+	factor := math.Sqrt(math.Max(0, numerator/denominator))
+	if largeArc == sweep {
+		factor = -factor
+	}
+	cx1 := factor * rx * y1 / ry
+	cy1 := -factor * ry * x1 / rx
+
+	cx := cosPhi*cx1 - sinPhi*cy1 + (from.X+to.X)/2
+	cy := sinPhi*cx1 + cosPhi*cy1 + (from.Y+to.Y)/2
+
+	startAngle := math.Atan2((y1-cy1)/ry, (x1-cx1)/rx)
+	endAngle := math.Atan2((-y1-cy1)/ry, (-x1-cx1)/rx)
+	delta := endAngle - startAngle
+	if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	} else if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	}
+
+	segments := int(math.Ceil(math.Abs(delta) / (2 * math.Pi) * curveSegments * 2))
+	if segments < 1 {
+		segments = 1
+	}
+
+	points := make([]point, 0, segments)
+	for s := 1; s <= segments; s++ {
+		angle := startAngle + delta*float64(s)/float64(segments)
+		x, y := rx*math.Cos(angle), ry*math.Sin(angle)
+		points = append(points, point{cosPhi*x - sinPhi*y + cx, sinPhi*x + cosPhi*y + cy})
+	}
+	points[len(points)-1] = to
+
+	return points
+}
+
+// parseColor parses #rgb, #rrggbb, #rrggbbaa and a few named colors
+func parseColor(value string) (color.NRGBA, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	switch value {
+	case "black":
+		return color.NRGBA{A: 255}, true
+	case "white":
+		return color.NRGBA{R: 255, G: 255, B: 255, A: 255}, true
+	case "transparent":
+		return color.NRGBA{}, true
+	}
+
+	if !strings.HasPrefix(value, "#") {
+		return color.NRGBA{}, false
+	}
+	hex := value[1:]
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return color.NRGBA{}, false
+	}
+
+	rgba, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, false
+	}
+	return color.NRGBA{R: uint8(rgba >> 24), G: uint8(rgba >> 16), B: uint8(rgba >> 8), A: uint8(rgba)}, true
+}
+
+// parseNumber parses a number, ignoring "px" units (returns 0 if invalid)
+func parseNumber(value string) float64 {
+	number, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "px"), 64)
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+// parseFraction parses a percentage or fraction into the range 0-1
+func parseFraction(value string, defaultValue float64) float64 {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return defaultValue
+	}
+	if strings.HasSuffix(value, "%") {
+		return parseNumber(strings.TrimSuffix(value, "%")) / 100
+	}
+	return parseNumber(value)
+}
+
+// clamp01 clamps a value into the range 0-1
+func clamp01(value float64) float64 {
+	return math.Max(0, math.Min(1, value))
+}
@@ -19,6 +19,7 @@ import (
 // Invariants:
 // - Directories are automatically created if they don't exist
 // - Existing files are overwritten
+// - Content is optimized with OptimizeSVG so that the same data always produces the same file (unparsable content is saved as is)
 func SaveSVG(svgContent, filePath string) error {
 	if svgContent == "" {
 		return fmt.Errorf("SVG content is empty")
@@ -36,6 +37,10 @@ func SaveSVG(svgContent, filePath string) error {
 		}
 	}
 
+	if optimized, err := OptimizeSVG(svgContent); err == nil {
+		svgContent = optimized
+	}
+
 	// Save SVG file with UTF-8 encoding
 	// os.WriteFile writes in UTF-8, so explicit encoding specification is not needed
 	err := os.WriteFile(filePath, []byte(svgContent), 0644)
@@ -20,6 +20,24 @@ import (
 // - All metrics are displayed in card format
 // - Icons and values are properly positioned
 func GenerateSummaryCard(stats aggregator.SummaryStats) (string, error) {
+	return GenerateSummaryCardWithOptions(stats, DefaultChartOptions())
+}
+
+// GenerateSummaryCardWithOptions generates a summary card SVG with custom options
+//
+// Preconditions:
+// - stats is a valid SummaryStats struct
+// - opts are chart options (Theme)
+//
+// Postconditions:
+// - Returns a valid SVG string
+//
+// Invariants:
+// - All metrics are displayed in card format
+func GenerateSummaryCardWithOptions(stats aggregator.SummaryStats, opts ChartOptions) (string, error) {
+	opts = opts.withDefaults()
+	theme := opts.Theme
+
 	// Set SVG size
 	width := DefaultSVGWidth
 	height := 140
@@ -34,7 +52,7 @@ func GenerateSummaryCard(stats aggregator.SummaryStats) (string, error) {
 	svg.WriteString(fmt.Sprintf(SVGHeader, width, height, width, height))
 
 	// Style definitions
-	svg.WriteString(`  <defs>
+	svg.WriteString(fmt.Sprintf(`  <defs>
     <filter id="cardShadow">
       <feGaussianBlur in="SourceAlpha" stdDeviation="4"/>
       <feOffset dx="0" dy="2" result="offsetblur"/>
@@ -57,11 +75,11 @@ func GenerateSummaryCard(stats aggregator.SummaryStats) (string, error) {
         <feMergeNode in="SourceGraphic"/>
       </feMerge>
     </filter>
-    <linearGradient id="cardGrad" x1="0%" y1="0%" x2="100%" y2="100%">
-      <stop offset="0%" style="stop-color:#161b22;stop-opacity:1" />
-      <stop offset="100%" style="stop-color:#0d1117;stop-opacity:1" />
+    <linearGradient id="cardGrad" x1="0%%" y1="0%%" x2="100%%" y2="100%%">
+      <stop offset="0%%" style="stop-color:%s;stop-opacity:1" />
+      <stop offset="100%%" style="stop-color:%s;stop-opacity:1" />
     </linearGradient>
-`)
+`, theme.Surface, theme.Background))
 
 	// Gradient definitions for each card
 	for i := 0; i < 4; i++ {
@@ -77,8 +95,8 @@ func GenerateSummaryCard(stats aggregator.SummaryStats) (string, error) {
 `)
 
 	// Background (gradient + border)
-	svg.WriteString(fmt.Sprintf(`  <rect width="%d" height="%d" fill="url(#cardGrad)" rx="12" stroke="#30363d" stroke-width="1"/>
-`, width, height))
+	svg.WriteString(fmt.Sprintf(`  <rect width="%d" height="%d" fill="url(#cardGrad)" rx="12" stroke="%s" stroke-width="1"/>
+`, width, height, theme.Border))
 
 	// Title (optional, cards are readable without it)
 	// svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="18" font-weight="600" fill="%s" text-anchor="middle">Statistics Summary</text>
@@ -142,11 +160,11 @@ func GenerateSummaryCard(stats aggregator.SummaryStats) (string, error) {
 		// Value (large font)
 		valueText := formatNumber(m.value)
 		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="20" font-weight="600" fill="%s" text-anchor="middle">%s</text>
-`, iconX, valueY, DefaultTextColor, valueText))
+`, iconX, valueY, theme.Text, valueText))
 
 		// Label
 		svg.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" font-family="Segoe UI, system-ui, -apple-system, sans-serif" font-size="11" fill="%s" text-anchor="middle" opacity="0.7">%s</text>
-`, iconX, labelY, DefaultTextColor, m.label))
+`, iconX, labelY, theme.Text, m.label))
 	}
 
 	// Footer
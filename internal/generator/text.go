@@ -0,0 +1,317 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+)
+
+const (
+	// textBarWidth Width of Unicode block bars in characters
+	textBarWidth = 30
+
+	// textEmptyMessage Message displayed when there is no data
+	textEmptyMessage = "No data available"
+)
+
+// barBlocks Unicode blocks used for partial bar cells (1/8 steps)
+var barBlocks = []rune{' ', '▏', '▎', '▍', '▌', '▋', '▊', '▉', '█'}
+
+// sparkBlocks Unicode blocks used for sparklines (lowest to highest)
+var sparkBlocks = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// GenerateLanguageTable generates a Markdown table from language ranking data
+//
+// Preconditions:
+// - rankedLanguages is a slice of ranked languages
+// - maxItems is the maximum number of rows (0 = all languages)
+//
+// Postconditions:
+// - Returns a Markdown table with rank, language and percentage
+//
+// Invariants:
+// - Row order follows rankedLanguages
+// - Percentages are normalized so that the total is 100%
+func GenerateLanguageTable(rankedLanguages []aggregator.LanguageStat, maxItems int) string {
+	if len(rankedLanguages) == 0 {
+		return textEmptyMessage
+	}
+
+	if maxItems > 0 {
+		rankedLanguages = groupOtherLanguages(rankedLanguages, maxItems)
+	}
+
+	totalPercentage := 0.0
+	for _, lang := range rankedLanguages {
+		totalPercentage += lang.Percentage
+	}
+
+	var table strings.Builder
+	table.WriteString("| # | Language | Percentage |\n")
+	table.WriteString("| --: | --- | --: |\n")
+
+	for i, lang := range rankedLanguages {
+		percentage := lang.Percentage
+		if totalPercentage > 0 {
+			percentage = (lang.Percentage / totalPercentage) * 100.0
+		}
+		name := strings.ReplaceAll(lang.Language, "|", `\|`)
+		table.WriteString(fmt.Sprintf("| %d | %s | %.1f%% |\n", i+1, name, percentage))
+	}
+
+	return strings.TrimSuffix(table.String(), "\n")
+}
+
+// GenerateCommitTimeBars generates Unicode block bars from commit time distribution
+//
+// Preconditions:
+// - timeDistribution is in the format map[int]int{time slot: commit count}
+//
+// Postconditions:
+// - Returns 24 lines of "HH:00 bar count"
+//
+// Invariants:
+// - All 24 hours are displayed (time slots with no data are shown as 0)
+func GenerateCommitTimeBars(timeDistribution map[int]int) string {
+	if len(timeDistribution) == 0 {
+		return textEmptyMessage
+	}
+
+	maxCommits := 0
+	for hour := 0; hour < 24; hour++ {
+		if timeDistribution[hour] > maxCommits {
+			maxCommits = timeDistribution[hour]
+		}
+	}
+
+	var text strings.Builder
+	for hour := 0; hour < 24; hour++ {
+		count := timeDistribution[hour]
+		text.WriteString(fmt.Sprintf("%02d:00 %s %d\n", hour, blockBar(count, maxCommits, textBarWidth), count))
+	}
+
+	return strings.TrimSuffix(text.String(), "\n")
+}
+
+// GenerateCommitLanguagesBars generates Unicode block bars from top languages by commit
+//
+// Preconditions:
+// - commitLanguages is in the format map[string]int{language name: usage count}
+// - maxItems is the maximum number of languages (0 = 5)
+//
+// Postconditions:
+// - Returns one line per language in the format "language bar count"
+//
+// Invariants:
+// - Sorted by usage count in descending order (by language name when counts are equal)
+func GenerateCommitLanguagesBars(commitLanguages map[string]int, maxItems int) string {
+	if len(commitLanguages) == 0 {
+		return textEmptyMessage
+	}
+
+	if maxItems <= 0 {
+		maxItems = 5
+	}
+
+	type langCount struct {
+		lang  string
+		count int
+	}
+	var langList []langCount
+	for lang, count := range commitLanguages {
+		langList = append(langList, langCount{lang: lang, count: count})
+	}
+	sort.Slice(langList, func(i, j int) bool {
+		if langList[i].count != langList[j].count {
+			return langList[i].count > langList[j].count
+		}
+		return langList[i].lang < langList[j].lang
+	})
+	if len(langList) > maxItems {
+		langList = langList[:maxItems]
+	}
+
+	nameWidth := 0
+	for _, item := range langList {
+		if width := utf8.RuneCountInString(item.lang); width > nameWidth {
+			nameWidth = width
+		}
+	}
+
+	maxCount := langList[0].count
+	var text strings.Builder
+	for _, item := range langList {
+		padding := strings.Repeat(" ", nameWidth-utf8.RuneCountInString(item.lang))
+		text.WriteString(fmt.Sprintf("%s%s %s %d\n", item.lang, padding, blockBar(item.count, maxCount, textBarWidth), item.count))
+	}
+
+	return strings.TrimSuffix(text.String(), "\n")
+}
+
+// GenerateCommitHistorySparkline generates a sparkline from commit history
+//
+// Preconditions:
+// - commitHistory is in the format map[string]int{date: commit count}
+// - maxItems is the maximum number of most recent dates (0 = all dates)
+//
+// Postconditions:
+// - Returns "first date sparkline last date (N commits)"
+//
+// Invariants:
+// - Dates are displayed in ascending order
+func GenerateCommitHistorySparkline(commitHistory map[string]int, maxItems int) string {
+	sortedPairs := aggregator.SortCommitHistoryByDate(commitHistory)
+	if len(sortedPairs) == 0 {
+		return textEmptyMessage
+	}
+
+	if maxItems > 0 && len(sortedPairs) > maxItems {
+		sortedPairs = sortedPairs[len(sortedPairs)-maxItems:]
+	}
+
+	maxCommits := 0
+	total := 0
+	for _, pair := range sortedPairs {
+		total += pair.Count
+		if pair.Count > maxCommits {
+			maxCommits = pair.Count
+		}
+	}
+
+	var spark strings.Builder
+	for _, pair := range sortedPairs {
+		level := 0
+		if maxCommits > 0 {
+			level = pair.Count * (len(sparkBlocks) - 1) / maxCommits
+		}
+		spark.WriteRune(sparkBlocks[level])
+	}
+
+	return fmt.Sprintf("%s %s %s (%d commits)", sortedPairs[0].Date, spark.String(), sortedPairs[len(sortedPairs)-1].Date, total)
+}
+
+// GenerateContributorsBars generates Unicode block bars of the top contributors
+//
+// Preconditions:
+// - contributors is a slice of ranked contributors (see aggregator.RankContributors)
+// - maxItems is the maximum number of contributors (0 = 10)
+//
+// Postconditions:
+// - Returns one line per contributor: login, bar, total and its breakdown
+//
+// Invariants:
+// - Contributors are displayed in the order of contributors
+// - Bars are aligned and scaled to the largest total
+func GenerateContributorsBars(contributors []aggregator.ContributorStat, maxItems int) string {
+	if maxItems <= 0 {
+		maxItems = 10
+	}
+	if len(contributors) > maxItems {
+		contributors = contributors[:maxItems]
+	}
+
+	nameWidth := 0
+	maxTotal := 0
+	for _, contributor := range contributors {
+		nameWidth = max(nameWidth, utf8.RuneCountInString(contributor.Login))
+		maxTotal = max(maxTotal, contributor.Total())
+	}
+	if maxTotal == 0 {
+		return textEmptyMessage
+	}
+
+	var text strings.Builder
+	for _, contributor := range contributors {
+		padding := strings.Repeat(" ", nameWidth-utf8.RuneCountInString(contributor.Login))
+		text.WriteString(fmt.Sprintf("%s%s %s %d (%d commits, %d PRs, %d reviews)\n", contributor.Login, padding,
+			blockBar(contributor.Total(), maxTotal, textBarWidth), contributor.Total(), contributor.Commits, contributor.PullRequests, contributor.Reviews))
+	}
+
+	return strings.TrimSuffix(text.String(), "\n")
+}
+
+// GenerateLeaderboardTable generates a Markdown table from a team leaderboard
+//
+// Preconditions:
+// - leaderboard has ranked entries (see aggregator.RankContributors)
+// - maxItems is the maximum number of rows (0 = 10)
+//
+// Postconditions:
+// - Returns a Markdown table with rank, user, commits, pull requests, reviews and total
+// - Users whose contributions could not be fetched are listed below the table (or below "No data available" if no user could be fetched)
+//
+// Invariants:
+// - Row order follows leaderboard.Entries
+func GenerateLeaderboardTable(leaderboard aggregator.Leaderboard, maxItems int) string {
+	entries := leaderboard.Entries
+	if len(entries) == 0 {
+		if len(leaderboard.Failed) > 0 {
+			return fmt.Sprintf("%s\n\nUnavailable: %s", textEmptyMessage, strings.Join(leaderboard.Failed, ", "))
+		}
+		return textEmptyMessage
+	}
+
+	if maxItems <= 0 {
+		maxItems = 10
+	}
+	if len(entries) > maxItems {
+		entries = entries[:maxItems]
+	}
+
+	var table strings.Builder
+	table.WriteString("| # | User | Commits | PRs | Reviews | Total |\n")
+	table.WriteString("| --: | --- | --: | --: | --: | --: |\n")
+
+	for i, entry := range entries {
+		table.WriteString(fmt.Sprintf("| %d | %s | %d | %d | %d | %d |\n", i+1, entry.Login, entry.Commits, entry.PullRequests, entry.Reviews, entry.Total()))
+	}
+
+	if len(leaderboard.Failed) > 0 {
+		table.WriteString(fmt.Sprintf("\nUnavailable: %s\n", strings.Join(leaderboard.Failed, ", ")))
+	}
+
+	return strings.TrimSuffix(table.String(), "\n")
+}
+
+// GenerateSummaryText generates plain-text summary statistics
+//
+// Preconditions:
+// - stats is a valid SummaryStats struct
+//
+// Postconditions:
+// - Returns one line per metric (stars, repositories, commits, PRs)
+//
+// Invariants:
+// - Values are aligned
+func GenerateSummaryText(stats aggregator.SummaryStats) string {
+	return fmt.Sprintf("Stars:         %d\nRepositories:  %d\nCommits:       %d\nPull Requests: %d",
+		stats.TotalStars, stats.RepositoryCount, stats.TotalCommits, stats.TotalPullRequests)
+}
+
+// blockBar draws a bar with Unicode blocks scaled to width characters
+func blockBar(value, maxValue, width int) string {
+	if maxValue <= 0 || value <= 0 {
+		return strings.Repeat(" ", width)
+	}
+
+	units := value * width * 8 / maxValue
+	if units == 0 {
+		units = 1 // Ensure minimum visible bar
+	}
+	full := units / 8
+	partial := units % 8
+
+	var bar strings.Builder
+	bar.WriteString(strings.Repeat(string(barBlocks[8]), full))
+	cells := full
+	if partial > 0 {
+		bar.WriteRune(barBlocks[partial])
+		cells++
+	}
+	bar.WriteString(strings.Repeat(" ", width-cells))
+
+	return bar.String()
+}
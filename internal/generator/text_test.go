@@ -0,0 +1,192 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+)
+
+func TestGenerateLanguageTable(t *testing.T) {
+	tests := []struct {
+		name      string
+		languages []aggregator.LanguageStat
+		maxItems  int
+		want      []string
+		wantRows  int
+	}{
+		{
+			name: "Ranked languages",
+			languages: []aggregator.LanguageStat{
+				{Language: "Go", Percentage: 75.0},
+				{Language: "Python", Percentage: 25.0},
+			},
+			want:     []string{"| # | Language | Percentage |", "| 1 | Go | 75.0% |", "| 2 | Python | 25.0% |"},
+			wantRows: 2,
+		},
+		{
+			name: "Grouped into Other",
+			languages: []aggregator.LanguageStat{
+				{Language: "Go", Percentage: 50.0},
+				{Language: "Python", Percentage: 30.0},
+				{Language: "Rust", Percentage: 20.0},
+			},
+			maxItems: 1,
+			want:     []string{"| 1 | Go | 50.0% |", "| 2 | Other | 50.0% |"},
+			wantRows: 2,
+		},
+		{
+			name:      "Empty data",
+			languages: nil,
+			want:      []string{textEmptyMessage},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GenerateLanguageTable(tt.languages, tt.maxItems)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("GenerateLanguageTable() = %q, want to contain %q", got, want)
+				}
+			}
+			if tt.wantRows > 0 {
+				if rows := strings.Count(got, "\n") - 1; rows != tt.wantRows {
+					t.Errorf("GenerateLanguageTable() rows = %d, want %d", rows, tt.wantRows)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateCommitTimeBars(t *testing.T) {
+	got := GenerateCommitTimeBars(map[int]int{9: 10, 21: 5})
+
+	lines := strings.Split(got, "\n")
+	if len(lines) != 24 {
+		t.Fatalf("GenerateCommitTimeBars() lines = %d, want 24", len(lines))
+	}
+	if !strings.HasPrefix(lines[9], "09:00 "+strings.Repeat("█", textBarWidth)) {
+		t.Errorf("line for 09:00 = %q, want full bar", lines[9])
+	}
+	if !strings.HasSuffix(lines[21], " 5") || strings.Count(lines[21], "█") != textBarWidth/2 {
+		t.Errorf("line for 21:00 = %q, want half bar", lines[21])
+	}
+	if !strings.HasSuffix(lines[0], " 0") || strings.Contains(lines[0], "█") {
+		t.Errorf("line for 00:00 = %q, want empty bar", lines[0])
+	}
+
+	if got := GenerateCommitTimeBars(nil); got != textEmptyMessage {
+		t.Errorf("GenerateCommitTimeBars(nil) = %q, want %q", got, textEmptyMessage)
+	}
+}
+
+func TestGenerateCommitLanguagesBars(t *testing.T) {
+	commitLanguages := map[string]int{"Go": 8, "Python": 4, "Rust": 4, "C": 1}
+
+	got := GenerateCommitLanguagesBars(commitLanguages, 3)
+	lines := strings.Split(got, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("GenerateCommitLanguagesBars() lines = %d, want 3", len(lines))
+	}
+
+	wantOrder := []string{"Go", "Python", "Rust"}
+	for i, lang := range wantOrder {
+		if !strings.HasPrefix(lines[i], lang) {
+			t.Errorf("line %d = %q, want to start with %q", i, lines[i], lang)
+		}
+	}
+
+	// Bars start at the same column
+	if strings.Index(lines[0], "█") != strings.Index(lines[1], "█") {
+		t.Errorf("bars are not aligned: %q, %q", lines[0], lines[1])
+	}
+}
+
+func TestGenerateContributorsBars(t *testing.T) {
+	contributors := []aggregator.ContributorStat{
+		{Login: "alice", Commits: 10, PullRequests: 2, Reviews: 4},
+		{Login: "bob", Commits: 3},
+		{Login: "carol", Reviews: 1},
+	}
+
+	got := GenerateContributorsBars(contributors, 2)
+	lines := strings.Split(got, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("GenerateContributorsBars() lines = %d, want 2", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "alice") || !strings.HasSuffix(lines[0], "16 (10 commits, 2 PRs, 4 reviews)") {
+		t.Errorf("line 0 = %q, want alice with 16 contributions", lines[0])
+	}
+	if strings.Index(lines[0], "█") != strings.Index(lines[1], "█") {
+		t.Errorf("bars are not aligned: %q, %q", lines[0], lines[1])
+	}
+
+	if got := GenerateContributorsBars(nil, 0); got != textEmptyMessage {
+		t.Errorf("GenerateContributorsBars(nil) = %q, want %q", got, textEmptyMessage)
+	}
+}
+
+func TestGenerateLeaderboardTable(t *testing.T) {
+	leaderboard := aggregator.Leaderboard{
+		Days: 30,
+		Entries: []aggregator.ContributorStat{
+			{Login: "alice", Commits: 20, PullRequests: 4, Reviews: 6},
+			{Login: "bob", Commits: 5, PullRequests: 1},
+		},
+		Failed: []string{"ghost"},
+	}
+
+	got := GenerateLeaderboardTable(leaderboard, 0)
+	lines := strings.Split(got, "\n")
+	if len(lines) != 6 {
+		t.Fatalf("GenerateLeaderboardTable() lines = %d, want 6\n%s", len(lines), got)
+	}
+	if lines[2] != "| 1 | alice | 20 | 4 | 6 | 30 |" {
+		t.Errorf("first row = %q, want alice with 30 contributions", lines[2])
+	}
+	if lines[5] != "Unavailable: ghost" {
+		t.Errorf("last line = %q, want the unavailable users", lines[5])
+	}
+
+	if got := GenerateLeaderboardTable(aggregator.Leaderboard{}, 0); got != textEmptyMessage {
+		t.Errorf("GenerateLeaderboardTable(empty) = %q, want %q", got, textEmptyMessage)
+	}
+	if got := GenerateLeaderboardTable(aggregator.Leaderboard{Failed: []string{"ghost", "broken"}}, 0); got != textEmptyMessage+"\n\nUnavailable: ghost, broken" {
+		t.Errorf("GenerateLeaderboardTable(all failed) = %q, want the unavailable users", got)
+	}
+}
+
+func TestGenerateCommitHistorySparkline(t *testing.T) {
+	history := map[string]int{
+		"2024-01-01": 0,
+		"2024-01-02": 7,
+		"2024-01-03": 14,
+	}
+
+	got := GenerateCommitHistorySparkline(history, 0)
+	want := "2024-01-01 ▁▄█ 2024-01-03 (21 commits)"
+	if got != want {
+		t.Errorf("GenerateCommitHistorySparkline() = %q, want %q", got, want)
+	}
+
+	got = GenerateCommitHistorySparkline(history, 2)
+	if !strings.HasPrefix(got, "2024-01-02 ") {
+		t.Errorf("GenerateCommitHistorySparkline() with maxItems = %q, want to start at 2024-01-02", got)
+	}
+}
+
+func TestGenerateSummaryText(t *testing.T) {
+	got := GenerateSummaryText(aggregator.SummaryStats{
+		TotalStars:        42,
+		RepositoryCount:   7,
+		TotalCommits:      1234,
+		TotalPullRequests: 56,
+	})
+
+	for _, want := range []string{"Stars:         42", "Repositories:  7", "Commits:       1234", "Pull Requests: 56"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("GenerateSummaryText() = %q, want to contain %q", got, want)
+		}
+	}
+}
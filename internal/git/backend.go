@@ -0,0 +1,121 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Backend names
+const (
+	// BackendAuto use the git binary if installed, otherwise the native implementation
+	BackendAuto = "auto"
+
+	// BackendExec run the git binary
+	BackendExec = "exec"
+
+	// BackendNative in-process implementation (no git binary required)
+	BackendNative = "native"
+)
+
+// Backend git operations used by this tool
+type Backend interface {
+	// Init creates a repository whose HEAD points to branch and whose origin remote is remoteURL
+	Init(repoPath, branch, remoteURL string) error
+
+	// IsRepository checks if the path is inside a Git repository
+	IsRepository(repoPath string) bool
+
+	// Status returns changed files (relative paths, including untracked files)
+	Status(repoPath string) ([]string, error)
+
+	// Add stages files (if empty, all changes are staged)
+	Add(repoPath string, files []string) error
+
+	// Commit commits staged changes (does nothing if there is nothing to commit)
+	Commit(repoPath, message string, opts CommitOptions) error
+
+	// Push pushes a branch to a remote
+	Push(repoPath string, opts PushOptions) error
+
+	// Fetch fetches a branch into its remote-tracking branch (refs/remotes/<remote>/<branch>)
+	Fetch(repoPath string, opts PushOptions) error
+
+	// ResetHard resets the current branch, index and working tree to a revision (untracked files are kept)
+	ResetHard(repoPath, revision string) error
+
+	// Restore restores files (relative paths) to their contents at HEAD, removing the files that are not at HEAD
+	Restore(repoPath string, files []string) error
+
+	// CurrentBranch returns the current branch name ("HEAD" in detached HEAD state)
+	CurrentBranch(repoPath string) (string, error)
+
+	// ResolveRevision returns the commit hash of a revision (e.g. HEAD, a branch name)
+	ResolveRevision(repoPath, revision string) (string, error)
+
+	// ResetBranch creates or resets a branch at the current commit and switches to it, keeping working tree changes
+	ResetBranch(repoPath, branch string) error
+
+	// Checkout switches to an existing branch
+	Checkout(repoPath, branch string) error
+
+	// RemoteURL returns the URL of a remote
+	RemoteURL(repoPath, remote string) (string, error)
+}
+
+// PushOptions options of Backend.Push and Backend.Fetch
+type PushOptions struct {
+	Remote string // Remote name (empty = "origin")
+	Branch string // Branch name (empty = current branch)
+	Token  string // GitHub token (empty = use configured credentials)
+	Force  bool   // Overwrite the remote branch (ignored by Fetch)
+}
+
+// ErrNonFastForward push was rejected because the remote branch has commits that are not in the local branch
+var ErrNonFastForward = errors.New("push rejected: remote branch has new commits")
+
+// ErrRemoteBranchNotFound fetched branch doesn't exist on the remote
+var ErrRemoteBranchNotFound = errors.New("remote branch not found")
+
+// DefaultBackend backend used by the package-level functions
+var DefaultBackend Backend = &ExecBackend{}
+
+// NewBackend creates a backend by name
+//
+// Preconditions:
+// - name is "auto", "exec" or "native" (empty = "auto")
+//
+// Postconditions:
+// - Returns the backend
+// - Returns error if the name is unknown
+//
+// Invariants:
+// - "auto" selects the exec backend only if the git binary is found in PATH
+func NewBackend(name string) (Backend, error) {
+	switch name {
+	case "", BackendAuto:
+		if _, err := exec.LookPath("git"); err == nil {
+			return &ExecBackend{}, nil
+		}
+		return &NativeBackend{}, nil
+	case BackendExec:
+		return &ExecBackend{}, nil
+	case BackendNative:
+		return &NativeBackend{}, nil
+	default:
+		return nil, fmt.Errorf("unknown git backend: %s (use auto, exec or native)", name)
+	}
+}
+
+// defaultIdentity returns the commit author used when user.name/user.email are not configured
+// Uses GITHUB_ACTOR environment variable in GitHub Actions environment
+func defaultIdentity() (string, string) {
+	if actor := os.Getenv("GITHUB_ACTOR"); actor != "" {
+		// GitHub Actions no-reply email address format
+		return actor, fmt.Sprintf("%s@users.noreply.github.com", actor)
+	}
+
+	// Fallback: GitHub Actions default
+	return "github-actions[bot]", "github-actions[bot]@users.noreply.github.com"
+}
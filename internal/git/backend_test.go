@@ -0,0 +1,315 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestNewBackend(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Backend
+		wantErr bool
+	}{
+		{name: "exec", want: &ExecBackend{}},
+		{name: "native", want: &NativeBackend{}},
+		{name: "unknown", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, err := NewBackend(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewBackend(%q) エラー = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !tt.wantErr && typeName(backend) != typeName(tt.want) {
+				t.Errorf("NewBackend(%q) = %T, want %T", tt.name, backend, tt.want)
+			}
+		})
+	}
+
+	// auto は常にいずれかのバックエンドを返すこと
+	if backend, err := NewBackend(""); err != nil || backend == nil {
+		t.Errorf("NewBackend(\"\") = %v, %v", backend, err)
+	}
+}
+
+// typeName はバックエンドの型名を返す
+func typeName(backend Backend) string {
+	switch backend.(type) {
+	case *ExecBackend:
+		return "exec"
+	case *NativeBackend:
+		return "native"
+	default:
+		return ""
+	}
+}
+
+// useBackend はテスト中だけ DefaultBackend を差し替える
+func useBackend(t *testing.T, name string) {
+	t.Helper()
+	if name == BackendExec {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git コマンドが見つからないためスキップします")
+		}
+	}
+
+	backend, err := NewBackend(name)
+	if err != nil {
+		t.Fatalf("NewBackend(%q) エラー = %v", name, err)
+	}
+
+	original := DefaultBackend
+	DefaultBackend = backend
+	t.Cleanup(func() { DefaultBackend = original })
+}
+
+// setupNativeRepository は git コマンドを使わずにベアリポジトリをリモートに持つテスト用リポジトリを作成する
+func setupNativeRepository(t *testing.T) (repoDir, remoteDir string) {
+	t.Helper()
+
+	root := t.TempDir()
+	repoDir = filepath.Join(root, "repo")
+	remoteDir = filepath.Join(root, "remote.git")
+
+	if _, err := gogit.PlainInit(remoteDir, true); err != nil {
+		t.Fatalf("ベアリポジトリの作成に失敗しました: %v", err)
+	}
+	repo, err := gogit.PlainInitWithOptions(repoDir, &gogit.PlainInitOptions{
+		InitOptions: gogit.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName("main")},
+	})
+	if err != nil {
+		t.Fatalf("リポジトリの作成に失敗しました: %v", err)
+	}
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remoteDir}}); err != nil {
+		t.Fatalf("リモートの追加に失敗しました: %v", err)
+	}
+
+	cfg, err := repo.Config()
+	if err != nil {
+		t.Fatalf("設定の読み込みに失敗しました: %v", err)
+	}
+	cfg.User.Name = "Test User"
+	cfg.User.Email = "test@example.com"
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatalf("設定の保存に失敗しました: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# Test\n"), 0644); err != nil {
+		t.Fatalf("テストファイルの作成に失敗しました: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("ワークツリーの取得に失敗しました: %v", err)
+	}
+	if _, err := wt.Add("README.md"); err != nil {
+		t.Fatalf("git add に失敗しました: %v", err)
+	}
+	signature := &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Now()}
+	if _, err := wt.Commit("initial commit", &gogit.CommitOptions{Author: signature}); err != nil {
+		t.Fatalf("初回コミットに失敗しました: %v", err)
+	}
+	if err := repo.Push(&gogit.PushOptions{}); err != nil {
+		t.Fatalf("初回プッシュに失敗しました: %v", err)
+	}
+
+	return repoDir, remoteDir
+}
+
+// refHash はリポジトリの参照が指すコミットを返す
+func refHash(t *testing.T, dir, ref string) plumbing.Hash {
+	t.Helper()
+	repo, err := gogit.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("リポジトリを開けませんでした: %v", err)
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		t.Fatalf("%s の解決に失敗しました: %v", ref, err)
+	}
+	return *hash
+}
+
+func TestBackends(t *testing.T) {
+	for _, name := range []string{BackendExec, BackendNative} {
+		t.Run(name, func(t *testing.T) {
+			useBackend(t, name)
+			repoDir, remoteDir := setupNativeRepository(t)
+
+			if !IsGitRepository(repoDir) {
+				t.Errorf("IsGitRepository(%q) = false, want true", repoDir)
+			}
+			if IsGitRepository(t.TempDir()) {
+				t.Error("IsGitRepository() リポジトリ外で true が返されました")
+			}
+
+			if branch, err := GetCurrentBranch(repoDir); err != nil || branch != "main" {
+				t.Errorf("GetCurrentBranch() = %q, %v, want main", branch, err)
+			}
+
+			url, err := GetRemoteURL(repoDir, "")
+			if err != nil || url != remoteDir {
+				t.Errorf("GetRemoteURL() = %q, %v, want %q", url, err, remoteDir)
+			}
+
+			// 変更の検出
+			if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# Test\n\nupdated\n"), 0644); err != nil {
+				t.Fatalf("テストファイルの更新に失敗しました: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(repoDir, "chart.svg"), []byte("<svg/>\n"), 0644); err != nil {
+				t.Fatalf("テストファイルの作成に失敗しました: %v", err)
+			}
+			files, err := DetectChanges(repoDir)
+			if err != nil {
+				t.Fatalf("DetectChanges() エラー = %v", err)
+			}
+			if len(files) != 2 {
+				t.Errorf("DetectChanges() = %v, want 2 files", files)
+			}
+
+			// コミットとプッシュ
+			if err := CommitAndPush(repoDir, "Update metrics", nil, "origin", "main", ""); err != nil {
+				t.Fatalf("CommitAndPush() エラー = %v", err)
+			}
+			if hasChanges, err := HasChanges(repoDir); err != nil || hasChanges {
+				t.Errorf("HasChanges() = %v, %v, want false", hasChanges, err)
+			}
+			mainHead := refHash(t, repoDir, "main")
+			if head, err := ResolveRevision(repoDir, "HEAD"); err != nil || head != mainHead.String() {
+				t.Errorf("ResolveRevision(HEAD) = %q, %v, want %s", head, err, mainHead)
+			}
+			if _, err := ResolveRevision(repoDir, "nonexistent"); err == nil {
+				t.Error("ResolveRevision() 存在しないリビジョンでエラーが返されませんでした")
+			}
+			if remoteHead := refHash(t, remoteDir, "main"); remoteHead != mainHead {
+				t.Errorf("リモートの main = %s, want %s", remoteHead, mainHead)
+			}
+
+			// 変更がない場合のコミットはエラーにならないこと
+			if err := Commit(repoDir, "Update metrics", nil); err != nil {
+				t.Errorf("Commit() 変更なしでエラー = %v", err)
+			}
+
+			// 専用ブランチへのコミット（元のブランチは変更されない）
+			if err := os.WriteFile(filepath.Join(repoDir, "chart.svg"), []byte("<svg></svg>\n"), 0644); err != nil {
+				t.Fatalf("テストファイルの更新に失敗しました: %v", err)
+			}
+			branch := "update-gh-profile/metrics"
+			if err := CommitToBranch(repoDir, "Update metrics", nil, "origin", branch, ""); err != nil {
+				t.Fatalf("CommitToBranch() エラー = %v", err)
+			}
+			if current, err := GetCurrentBranch(repoDir); err != nil || current != "main" {
+				t.Errorf("GetCurrentBranch() = %q, %v, want main", current, err)
+			}
+			if head := refHash(t, repoDir, "main"); head != mainHead {
+				t.Errorf("main ブランチが変更されました: %s -> %s", mainHead, head)
+			}
+			if parent := refHash(t, remoteDir, branch+"^"); parent != mainHead {
+				t.Errorf("リモートブランチの親 = %s, want %s", parent, mainHead)
+			}
+			content, err := os.ReadFile(filepath.Join(repoDir, "chart.svg"))
+			if err != nil || string(content) != "<svg/>\n" {
+				t.Errorf("元のブランチの chart.svg = %q, %v, want %q", content, err, "<svg/>\n")
+			}
+		})
+	}
+}
+
+func TestCheckoutRemoteBranch(t *testing.T) {
+	for _, name := range []string{BackendExec, BackendNative} {
+		t.Run(name, func(t *testing.T) {
+			useBackend(t, name)
+			_, remoteDir := setupNativeRepository(t)
+			branch := "profile-assets"
+
+			// 初回はリモートにブランチがないため、孤立ブランチを用意する
+			dir := filepath.Join(t.TempDir(), "assets")
+			existed, err := CheckoutRemoteBranch(dir, remoteDir, branch, "")
+			if err != nil || existed {
+				t.Fatalf("CheckoutRemoteBranch() = %v, %v, want false, nil", existed, err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "chart.svg"), []byte("<svg/>\n"), 0644); err != nil {
+				t.Fatalf("テストファイルの作成に失敗しました: %v", err)
+			}
+			if err := CommitAndPush(dir, "Update assets", nil, "origin", branch, ""); err != nil {
+				t.Fatalf("CommitAndPush() エラー = %v", err)
+			}
+
+			// 孤立ブランチは main の履歴を含まないこと
+			repo, err := gogit.PlainOpen(remoteDir)
+			if err != nil {
+				t.Fatalf("リポジトリを開けませんでした: %v", err)
+			}
+			commit, err := repo.CommitObject(refHash(t, remoteDir, branch))
+			if err != nil {
+				t.Fatalf("コミットの取得に失敗しました: %v", err)
+			}
+			if commit.NumParents() != 0 {
+				t.Errorf("孤立ブランチの親コミット数 = %d, want 0", commit.NumParents())
+			}
+			if _, err := commit.File("README.md"); err == nil {
+				t.Error("孤立ブランチに main のファイルが含まれています")
+			}
+
+			// 2回目以降は既存のブランチを取得する
+			dir = filepath.Join(t.TempDir(), "assets")
+			existed, err = CheckoutRemoteBranch(dir, remoteDir, branch, "")
+			if err != nil || !existed {
+				t.Fatalf("CheckoutRemoteBranch() = %v, %v, want true, nil", existed, err)
+			}
+			if content, err := os.ReadFile(filepath.Join(dir, "chart.svg")); err != nil || string(content) != "<svg/>\n" {
+				t.Errorf("chart.svg = %q, %v", content, err)
+			}
+			if current, err := GetCurrentBranch(dir); err != nil || current != branch {
+				t.Errorf("GetCurrentBranch() = %q, %v, want %s", current, err, branch)
+			}
+		})
+	}
+}
+
+func TestRestoreFiles(t *testing.T) {
+	for _, name := range []string{BackendExec, BackendNative} {
+		t.Run(name, func(t *testing.T) {
+			useBackend(t, name)
+			repoDir, _ := setupNativeRepository(t)
+
+			// 生成ファイルの変更と追加、対象外のファイルの変更
+			if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# Test\n\nupdated\n"), 0644); err != nil {
+				t.Fatalf("テストファイルの更新に失敗しました: %v", err)
+			}
+			if err := os.MkdirAll(filepath.Join(repoDir, "charts"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(repoDir, "charts", "chart.svg"), []byte("<svg/>\n"), 0644); err != nil {
+				t.Fatalf("テストファイルの作成に失敗しました: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(repoDir, "notes.txt"), []byte("keep\n"), 0644); err != nil {
+				t.Fatalf("テストファイルの作成に失敗しました: %v", err)
+			}
+
+			if err := RestoreFiles(repoDir, []string{"README.md", filepath.Join("charts", "chart.svg")}); err != nil {
+				t.Fatalf("RestoreFiles() エラー = %v", err)
+			}
+
+			if content, err := os.ReadFile(filepath.Join(repoDir, "README.md")); err != nil || string(content) != "# Test\n" {
+				t.Errorf("README.md = %q, %v, want コミット済みの内容", content, err)
+			}
+			if _, err := os.Stat(filepath.Join(repoDir, "charts", "chart.svg")); !os.IsNotExist(err) {
+				t.Errorf("HEAD にないファイルが削除されていません: %v", err)
+			}
+			files, err := DetectChanges(repoDir)
+			if err != nil || len(files) != 1 || files[0] != "notes.txt" {
+				t.Errorf("DetectChanges() = %v, %v, want [notes.txt]", files, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package git
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// Commit signing formats
+const (
+	// SigningGPG sign commits with an OpenPGP key
+	SigningGPG = "gpg"
+
+	// SigningSSH sign commits with an SSH key
+	SigningSSH = "ssh"
+)
+
+// Identity author or committer of a commit
+type Identity struct {
+	Name  string
+	Email string
+}
+
+// CommitOptions options applied to every commit
+type CommitOptions struct {
+	Author            Identity // Commit author (empty = user.name/user.email from git config, or GITHUB_ACTOR)
+	Committer         Identity // Commit committer (empty = user.name/user.email from git config, or GITHUB_ACTOR)
+	SigningFormat     string   // "gpg" or "ssh" (empty = commits are not signed)
+	SigningKey        string   // GPG key ID or SSH key path for the exec backend, private key file for the native backend
+	SigningPassphrase string   // Passphrase of the private key file (native backend only)
+}
+
+// DefaultCommitOptions options used by the package-level commit functions
+var DefaultCommitOptions CommitOptions
+
+// ParseIdentity parses an identity in "Name <email>" format
+//
+// Preconditions:
+// - value is "Name <email>" (empty = zero identity)
+//
+// Postconditions:
+// - Returns the parsed identity
+// - Returns error if the name or email is missing
+//
+// Invariants:
+// - Surrounding whitespace is ignored
+func ParseIdentity(value string) (Identity, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Identity{}, nil
+	}
+
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Name == "" {
+		return Identity{}, fmt.Errorf("invalid identity %q (use \"Name <email>\")", value)
+	}
+
+	return Identity{Name: address.Name, Email: address.Address}, nil
+}
+
+// IsZero checks if the identity is not set
+func (i Identity) IsZero() bool {
+	return i.Name == "" && i.Email == ""
+}
+
+// String returns the identity in "Name <email>" format
+func (i Identity) String() string {
+	return fmt.Sprintf("%s <%s>", i.Name, i.Email)
+}
+
+// Validate checks that the signing configuration is complete
+//
+// Preconditions:
+// - None
+//
+// Postconditions:
+// - Returns error if the signing format is unknown or an SSH key is missing
+//
+// Invariants:
+// - A GPG key may be omitted with the exec backend (user.signingkey is used)
+func (o CommitOptions) Validate() error {
+	switch o.SigningFormat {
+	case "", SigningGPG:
+		return nil
+	case SigningSSH:
+		if o.SigningKey == "" {
+			return fmt.Errorf("signing key is required for SSH signing")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown signing format: %s (use gpg or ssh)", o.SigningFormat)
+	}
+}
+
+// resolveIdentity returns the identity, filling empty fields from the configured user or the default identity
+func resolveIdentity(identity Identity, configuredName, configuredEmail string) Identity {
+	defaultName, defaultEmail := defaultIdentity()
+	if configuredName == "" {
+		configuredName = defaultName
+	}
+	if configuredEmail == "" {
+		configuredEmail = defaultEmail
+	}
+
+	if identity.Name == "" {
+		identity.Name = configuredName
+	}
+	if identity.Email == "" {
+		identity.Email = configuredEmail
+	}
+
+	return identity
+}
@@ -0,0 +1,227 @@
+package git
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestParseIdentity(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Identity
+		wantErr bool
+	}{
+		{name: "名前とメールアドレス", value: "Profile Bot <bot@example.com>", want: Identity{Name: "Profile Bot", Email: "bot@example.com"}},
+		{name: "前後の空白", value: "  octocat <octocat@users.noreply.github.com> ", want: Identity{Name: "octocat", Email: "octocat@users.noreply.github.com"}},
+		{name: "空文字列", value: "", want: Identity{}},
+		{name: "名前なし", value: "bot@example.com", wantErr: true},
+		{name: "不正な形式", value: "Profile Bot", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIdentity(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseIdentity(%q) エラー = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseIdentity(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    CommitOptions
+		wantErr bool
+	}{
+		{name: "署名なし", opts: CommitOptions{}},
+		{name: "GPG 鍵指定なし", opts: CommitOptions{SigningFormat: SigningGPG}},
+		{name: "SSH", opts: CommitOptions{SigningFormat: SigningSSH, SigningKey: "id_ed25519"}},
+		{name: "SSH 鍵指定なし", opts: CommitOptions{SigningFormat: SigningSSH}, wantErr: true},
+		{name: "不明な形式", opts: CommitOptions{SigningFormat: "x509"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() エラー = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// useCommitOptions はテスト中だけ DefaultCommitOptions を差し替える
+func useCommitOptions(t *testing.T, opts CommitOptions) {
+	t.Helper()
+	original := DefaultCommitOptions
+	DefaultCommitOptions = opts
+	t.Cleanup(func() { DefaultCommitOptions = original })
+}
+
+// headCommit は HEAD のコミットを返す
+func headCommit(t *testing.T, dir string) *object.Commit {
+	t.Helper()
+	repo, err := gogit.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("リポジトリを開けませんでした: %v", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("HEAD の取得に失敗しました: %v", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatalf("コミットの取得に失敗しました: %v", err)
+	}
+	return commit
+}
+
+// writeSSHKey はテスト用の SSH 鍵を作成し、秘密鍵のパスと公開鍵を返す
+func writeSSHKey(t *testing.T) (string, ssh.PublicKey) {
+	t.Helper()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("鍵の生成に失敗しました: %v", err)
+	}
+	block, err := ssh.MarshalPrivateKey(privateKey, "")
+	if err != nil {
+		t.Fatalf("秘密鍵のエンコードに失敗しました: %v", err)
+	}
+	keyPath := filepath.Join(t.TempDir(), "id_ed25519")
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatalf("秘密鍵の保存に失敗しました: %v", err)
+	}
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("公開鍵の変換に失敗しました: %v", err)
+	}
+	return keyPath, sshPublicKey
+}
+
+func TestCommitIdentityAndSSHSigning(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen が見つからないためスキップします")
+	}
+	keyPath, publicKey := writeSSHKey(t)
+
+	for _, name := range []string{BackendExec, BackendNative} {
+		t.Run(name, func(t *testing.T) {
+			useBackend(t, name)
+			repoDir, _ := setupNativeRepository(t)
+			useCommitOptions(t, CommitOptions{
+				Author:        Identity{Name: "octocat", Email: "octocat@users.noreply.github.com"},
+				Committer:     Identity{Name: "Profile Bot", Email: "bot@example.com"},
+				SigningFormat: SigningSSH,
+				SigningKey:    keyPath,
+			})
+
+			if err := os.WriteFile(filepath.Join(repoDir, "chart.svg"), []byte("<svg/>\n"), 0644); err != nil {
+				t.Fatalf("テストファイルの作成に失敗しました: %v", err)
+			}
+			if err := Commit(repoDir, "Update metrics", nil); err != nil {
+				t.Fatalf("Commit() エラー = %v", err)
+			}
+
+			commit := headCommit(t, repoDir)
+			if commit.Author.Name != "octocat" || commit.Author.Email != "octocat@users.noreply.github.com" {
+				t.Errorf("作成者 = %s <%s>, want octocat", commit.Author.Name, commit.Author.Email)
+			}
+			if commit.Committer.Name != "Profile Bot" || commit.Committer.Email != "bot@example.com" {
+				t.Errorf("コミッター = %s <%s>, want Profile Bot", commit.Committer.Name, commit.Committer.Email)
+			}
+			if !strings.HasPrefix(commit.PGPSignature, "-----BEGIN SSH SIGNATURE-----") {
+				t.Fatalf("SSH 署名がありません: %q", commit.PGPSignature)
+			}
+
+			// 署名をリポジトリの設定に依存せずに ssh-keygen で検証する
+			encoded := &plumbing.MemoryObject{}
+			if err := commit.EncodeWithoutSignature(encoded); err != nil {
+				t.Fatalf("コミットのエンコードに失敗しました: %v", err)
+			}
+			payload, err := encoded.Reader()
+			if err != nil {
+				t.Fatalf("コミットの読み込みに失敗しました: %v", err)
+			}
+
+			dir := t.TempDir()
+			allowedSigners := filepath.Join(dir, "allowed_signers")
+			signaturePath := filepath.Join(dir, "commit.sig")
+			if err := os.WriteFile(allowedSigners, []byte("bot@example.com "+string(ssh.MarshalAuthorizedKey(publicKey))), 0644); err != nil {
+				t.Fatalf("allowed_signers の作成に失敗しました: %v", err)
+			}
+			if err := os.WriteFile(signaturePath, []byte(commit.PGPSignature), 0644); err != nil {
+				t.Fatalf("署名ファイルの作成に失敗しました: %v", err)
+			}
+
+			cmd := exec.Command("ssh-keygen", "-Y", "verify", "-f", allowedSigners, "-I", "bot@example.com", "-n", "git", "-s", signaturePath)
+			cmd.Stdin = payload
+			if output, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("署名の検証に失敗しました: %v\n%s", err, output)
+			}
+		})
+	}
+}
+
+func TestNativeGPGSigning(t *testing.T) {
+	useBackend(t, BackendNative)
+	repoDir, _ := setupNativeRepository(t)
+
+	entity, err := openpgp.NewEntity("Profile Bot", "", "bot@example.com", nil)
+	if err != nil {
+		t.Fatalf("鍵の生成に失敗しました: %v", err)
+	}
+	keyPath := filepath.Join(t.TempDir(), "signing.asc")
+	if err := os.WriteFile(keyPath, armorKey(t, openpgp.PrivateKeyType, func(w io.Writer) error { return entity.SerializePrivate(w, nil) }), 0600); err != nil {
+		t.Fatalf("秘密鍵の保存に失敗しました: %v", err)
+	}
+
+	useCommitOptions(t, CommitOptions{SigningFormat: SigningGPG, SigningKey: keyPath})
+	if err := os.WriteFile(filepath.Join(repoDir, "chart.svg"), []byte("<svg/>\n"), 0644); err != nil {
+		t.Fatalf("テストファイルの作成に失敗しました: %v", err)
+	}
+	if err := Commit(repoDir, "Update metrics", nil); err != nil {
+		t.Fatalf("Commit() エラー = %v", err)
+	}
+
+	commit := headCommit(t, repoDir)
+	publicKey := armorKey(t, openpgp.PublicKeyType, entity.Serialize)
+	if _, err := commit.Verify(string(publicKey)); err != nil {
+		t.Errorf("GPG 署名の検証に失敗しました: %v", err)
+	}
+}
+
+// armorKey は OpenPGP 鍵を ASCII 形式でエンコードする
+func armorKey(t *testing.T, blockType string, serialize func(io.Writer) error) []byte {
+	t.Helper()
+	var armored bytes.Buffer
+	writer, err := armor.Encode(&armored, blockType, nil)
+	if err != nil {
+		t.Fatalf("鍵のエンコードに失敗しました: %v", err)
+	}
+	if err := serialize(writer); err != nil {
+		t.Fatalf("鍵のシリアライズに失敗しました: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("鍵のエンコードに失敗しました: %v", err)
+	}
+	return armored.Bytes()
+}
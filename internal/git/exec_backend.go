@@ -0,0 +1,317 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/watsumi/update-gh-profile/internal/logger"
+)
+
+// ExecBackend backend that runs the git binary
+type ExecBackend struct{}
+
+// Init implements Backend
+func (b *ExecBackend) Init(repoPath, branch, remoteURL string) error {
+	if err := os.MkdirAll(repoPath, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// git init -b is not available in older versions of git
+	for _, args := range [][]string{
+		{"init"},
+		{"symbolic-ref", "HEAD", "refs/heads/" + branch},
+		{"remote", "add", "origin", remoteURL},
+	} {
+		if _, err := runGit(repoPath, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsRepository implements Backend
+// Uses git rev-parse command for verification, and checks for .git existence as fallback
+func (b *ExecBackend) IsRepository(repoPath string) bool {
+	// First check with git rev-parse command (more reliable method)
+	if _, err := runGit(repoPath, "rev-parse", "--git-dir"); err == nil {
+		// If git rev-parse succeeds, it's a Git repository
+		return true
+	}
+
+	// If git rev-parse fails, check for .git existence as fallback
+	absPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return false
+	}
+
+	gitDir := filepath.Join(absPath, ".git")
+	info, err := os.Stat(gitDir)
+	if err != nil {
+		return false
+	}
+
+	// Consider it a Git repository if .git is a directory, file, or symbolic link
+	// (in shallow clones, .git can be a file)
+	return info.IsDir() || (info.Mode()&os.ModeSymlink != 0) || (info.Mode().IsRegular())
+}
+
+// Status implements Backend
+func (b *ExecBackend) Status(repoPath string) ([]string, error) {
+	// Execute git status --porcelain to get changed files
+	// Untracked directories are expanded to their files, like the native backend
+	output, err := runGit(repoPath, "status", "--porcelain", "--untracked-files=all")
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse output and create file list
+	if output == "" {
+		return []string{}, nil
+	}
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	var files []string
+
+	for _, line := range lines {
+		// git status --porcelain output format: " M file.txt" or "MM file.txt"
+		// The part after the status code is the file path
+		fields := strings.Fields(line)
+		if len(fields) >= 2 {
+			// Second field is the file path
+			file := strings.Join(fields[1:], " ")
+			// Convert absolute path to relative path
+			if filepath.IsAbs(file) {
+				relPath, err := filepath.Rel(repoPath, file)
+				if err == nil {
+					file = relPath
+				}
+			}
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+// Add implements Backend
+func (b *ExecBackend) Add(repoPath string, files []string) error {
+	// Stage all changes if no files are specified
+	args := []string{"add", "-A"}
+	if len(files) > 0 {
+		args = append([]string{"add"}, files...)
+	}
+
+	_, err := runGit(repoPath, args...)
+	return err
+}
+
+// Commit implements Backend
+// Identity is passed through environment variables, so the repository config is not modified
+func (b *ExecBackend) Commit(repoPath, message string, opts CommitOptions) error {
+	configuredName, _ := runGit(repoPath, "config", "user.name")
+	configuredEmail, _ := runGit(repoPath, "config", "user.email")
+	configuredName, configuredEmail = strings.TrimSpace(configuredName), strings.TrimSpace(configuredEmail)
+	author := resolveIdentity(opts.Author, configuredName, configuredEmail)
+	committer := resolveIdentity(opts.Committer, configuredName, configuredEmail)
+
+	var args []string
+	switch opts.SigningFormat {
+	case SigningGPG:
+		args = append(args, "-c", "gpg.format=openpgp")
+		if opts.SigningKey != "" {
+			args = append(args, "-c", "user.signingkey="+opts.SigningKey)
+		}
+	case SigningSSH:
+		args = append(args, "-c", "gpg.format=ssh", "-c", "user.signingkey="+opts.SigningKey)
+	}
+	args = append(args, "commit", "-m", message)
+	if opts.SigningFormat != "" {
+		args = append(args, "-S")
+	}
+
+	// Execute commit
+	env := []string{
+		"GIT_AUTHOR_NAME=" + author.Name,
+		"GIT_AUTHOR_EMAIL=" + author.Email,
+		"GIT_COMMITTER_NAME=" + committer.Name,
+		"GIT_COMMITTER_EMAIL=" + committer.Email,
+	}
+	output, err := runGitCommand(repoPath, "", env, args...)
+	if err != nil {
+		// Don't treat as error if there are no changes to commit (already committed)
+		if strings.Contains(output, "nothing to commit") || strings.Contains(output, "nothing added to commit") {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Push implements Backend
+func (b *ExecBackend) Push(repoPath string, opts PushOptions) error {
+	remote := opts.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+
+	// Get current branch if branch is not specified
+	branch := opts.Branch
+	if branch == "" {
+		var err error
+		branch, err = b.CurrentBranch(repoPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Execute push
+	args := []string{"push", remote, branch}
+	if opts.Force {
+		args = []string{"push", "--force", remote, branch}
+	}
+
+	// The token is passed to a credential helper for this command only
+	_, err := runGitCommand(repoPath, opts.Token, nil, args...)
+	if err != nil && isNonFastForward(err.Error()) {
+		return fmt.Errorf("%w: %v", ErrNonFastForward, err)
+	}
+	return err
+}
+
+// Fetch implements Backend
+func (b *ExecBackend) Fetch(repoPath string, opts PushOptions) error {
+	remote := opts.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+
+	refSpec := fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", opts.Branch, remote, opts.Branch)
+	_, err := runGitCommand(repoPath, opts.Token, nil, "fetch", remote, refSpec)
+	if err != nil && strings.Contains(err.Error(), "couldn't find remote ref") {
+		return fmt.Errorf("%w: %s", ErrRemoteBranchNotFound, opts.Branch)
+	}
+	return err
+}
+
+// ResetHard implements Backend
+func (b *ExecBackend) ResetHard(repoPath, revision string) error {
+	_, err := runGit(repoPath, "reset", "--hard", revision)
+	return err
+}
+
+// Restore implements Backend
+func (b *ExecBackend) Restore(repoPath string, files []string) error {
+	for _, file := range files {
+		// Files that are not at HEAD (e.g. a new chart) are removed
+		if _, err := runGit(repoPath, "cat-file", "-e", "HEAD:./"+filepath.ToSlash(file)); err != nil {
+			if err := os.Remove(filepath.Join(repoPath, file)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove %s: %w", file, err)
+			}
+			continue
+		}
+		if _, err := runGit(repoPath, "checkout", "HEAD", "--", file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isNonFastForward checks if git push output reports a non-fast-forward rejection
+func isNonFastForward(output string) bool {
+	return strings.Contains(output, "non-fast-forward") || strings.Contains(output, "fetch first")
+}
+
+// CurrentBranch implements Backend
+func (b *ExecBackend) CurrentBranch(repoPath string) (string, error) {
+	output, err := runGit(repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// ResolveRevision implements Backend
+func (b *ExecBackend) ResolveRevision(repoPath, revision string) (string, error) {
+	output, err := runGit(repoPath, "rev-parse", "--verify", revision+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", revision, err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// ResetBranch implements Backend
+func (b *ExecBackend) ResetBranch(repoPath, branch string) error {
+	_, err := runGit(repoPath, "checkout", "-B", branch)
+	return err
+}
+
+// Checkout implements Backend
+func (b *ExecBackend) Checkout(repoPath, branch string) error {
+	_, err := runGit(repoPath, "checkout", branch)
+	return err
+}
+
+// RemoteURL implements Backend
+func (b *ExecBackend) RemoteURL(repoPath, remote string) (string, error) {
+	output, err := runGit(repoPath, "remote", "get-url", remote)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// tokenEnv environment variable passing the token to the credential helper
+const tokenEnv = "UPDATE_GH_PROFILE_GIT_TOKEN"
+
+// credentialHelper credential helper that reads the token from tokenEnv
+// The token never appears in command arguments, remote URLs or .git/config
+const credentialHelper = `!f() { test "$1" = get && echo username=x-access-token && echo "password=$` + tokenEnv + `"; }; f`
+
+// runGit executes a git command and returns stdout
+func runGit(repoPath string, args ...string) (string, error) {
+	return runGitCommand(repoPath, "", nil, args...)
+}
+
+// runGitCommand executes a git command with a token and extra environment variables
+// Returns stdout (also on failure), and an error whose stderr is redacted of credentials
+func runGitCommand(repoPath, token string, env []string, args ...string) (string, error) {
+	if token != "" {
+		// Reset configured helpers so that only the ephemeral one is used
+		args = append([]string{"-c", "credential.helper=", "-c", "credential.helper=" + credentialHelper}, args...)
+		env = append(env, tokenEnv+"="+token)
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	cmd.Env = append(os.Environ(), append(env, "GIT_TERMINAL_PROMPT=0")...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return stdout.String(), fmt.Errorf("failed to execute git %s: %w\nstderr: %s", gitSubcommand(args), err, logger.RedactText(stderr.String(), token))
+	}
+
+	return stdout.String(), nil
+}
+
+// gitSubcommand returns the subcommand name (skipping -c options)
+func gitSubcommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		if args[i] == "-c" {
+			i++
+			continue
+		}
+		return args[i]
+	}
+	return ""
+}
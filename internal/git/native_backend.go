@@ -0,0 +1,484 @@
+package git
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	"github.com/watsumi/update-gh-profile/internal/logger"
+)
+
+// NativeBackend in-process backend built on go-git (works without the git binary)
+type NativeBackend struct{}
+
+// open opens the repository containing repoPath
+func (b *NativeBackend) open(repoPath string) (*gogit.Repository, error) {
+	repo, err := gogit.PlainOpenWithOptions(repoPath, &gogit.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+	return repo, nil
+}
+
+// worktree opens the worktree of the repository containing repoPath
+func (b *NativeBackend) worktree(repoPath string) (*gogit.Repository, *gogit.Worktree, error) {
+	repo, err := b.open(repoPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open worktree: %w", err)
+	}
+
+	return repo, wt, nil
+}
+
+// Init implements Backend
+func (b *NativeBackend) Init(repoPath, branch, remoteURL string) error {
+	repo, err := gogit.PlainInitWithOptions(repoPath, &gogit.PlainInitOptions{
+		InitOptions: gogit.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName(branch)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remoteURL}}); err != nil {
+		return fmt.Errorf("failed to add remote: %w", err)
+	}
+
+	return nil
+}
+
+// IsRepository implements Backend
+func (b *NativeBackend) IsRepository(repoPath string) bool {
+	_, err := b.open(repoPath)
+	return err == nil
+}
+
+// Status implements Backend
+func (b *NativeBackend) Status(repoPath string) ([]string, error) {
+	_, wt, err := b.worktree(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status: %w", err)
+	}
+
+	files := []string{}
+	for file, s := range status {
+		if s.Staging == gogit.Unmodified && s.Worktree == gogit.Unmodified {
+			continue
+		}
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// Add implements Backend
+func (b *NativeBackend) Add(repoPath string, files []string) error {
+	_, wt, err := b.worktree(repoPath)
+	if err != nil {
+		return err
+	}
+
+	// Stage all changes if no files are specified
+	if len(files) == 0 {
+		if err := wt.AddWithOptions(&gogit.AddOptions{All: true}); err != nil {
+			return fmt.Errorf("failed to add files: %w", err)
+		}
+		return nil
+	}
+
+	for _, file := range files {
+		path, err := worktreePath(wt, repoPath, file)
+		if err != nil {
+			return err
+		}
+		if _, err := wt.Add(path); err != nil {
+			return fmt.Errorf("failed to add %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// Commit implements Backend
+func (b *NativeBackend) Commit(repoPath, message string, opts CommitOptions) error {
+	repo, wt, err := b.worktree(repoPath)
+	if err != nil {
+		return err
+	}
+
+	// Don't treat as error if there is nothing staged (already committed)
+	status, err := wt.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get status: %w", err)
+	}
+	staged := false
+	for _, s := range status {
+		if s.Staging != gogit.Unmodified && s.Staging != gogit.Untracked {
+			staged = true
+			break
+		}
+	}
+	if !staged {
+		return nil
+	}
+
+	cfg, err := repo.ConfigScoped(config.GlobalScope)
+	if err != nil {
+		return fmt.Errorf("failed to read Git configuration: %w", err)
+	}
+	now := time.Now()
+	author := resolveIdentity(opts.Author, cfg.User.Name, cfg.User.Email)
+	committer := resolveIdentity(opts.Committer, cfg.User.Name, cfg.User.Email)
+
+	signer, err := newSigner(opts)
+	if err != nil {
+		return err
+	}
+
+	_, err = wt.Commit(message, &gogit.CommitOptions{
+		Author:    &object.Signature{Name: author.Name, Email: author.Email, When: now},
+		Committer: &object.Signature{Name: committer.Name, Email: committer.Email, When: now},
+		Signer:    signer,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
+// Push implements Backend
+func (b *NativeBackend) Push(repoPath string, opts PushOptions) error {
+	repo, err := b.open(repoPath)
+	if err != nil {
+		return err
+	}
+
+	remoteName := opts.Remote
+	if remoteName == "" {
+		remoteName = "origin"
+	}
+
+	// Get current branch if branch is not specified
+	branch := opts.Branch
+	if branch == "" {
+		branch, err = b.CurrentBranch(repoPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	remoteURL, err := b.RemoteURL(repoPath, remoteName)
+	if err != nil {
+		return err
+	}
+
+	auth, err := pushAuth(repo, remoteURL, opts.Token)
+	if err != nil {
+		return err
+	}
+
+	refSpec := fmt.Sprintf("refs/heads/%s:refs/heads/%s", branch, branch)
+	if opts.Force {
+		refSpec = "+" + refSpec
+	}
+
+	err = repo.Push(&gogit.PushOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{config.RefSpec(refSpec)},
+		Auth:       auth,
+		Force:      opts.Force,
+	})
+	if errors.Is(err, gogit.ErrForceNeeded) || (err != nil && strings.Contains(err.Error(), "non-fast-forward")) {
+		return fmt.Errorf("%w: %s", ErrNonFastForward, logger.RedactText(err.Error(), opts.Token))
+	}
+	if err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		// Errors of go-git can include the remote URL, so they are redacted
+		return fmt.Errorf("failed to push: %s", logger.RedactText(err.Error(), opts.Token))
+	}
+
+	return nil
+}
+
+// Fetch implements Backend
+func (b *NativeBackend) Fetch(repoPath string, opts PushOptions) error {
+	repo, err := b.open(repoPath)
+	if err != nil {
+		return err
+	}
+
+	remoteName := opts.Remote
+	if remoteName == "" {
+		remoteName = "origin"
+	}
+
+	remoteURL, err := b.RemoteURL(repoPath, remoteName)
+	if err != nil {
+		return err
+	}
+
+	auth, err := pushAuth(repo, remoteURL, opts.Token)
+	if err != nil {
+		return err
+	}
+
+	refSpec := fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", opts.Branch, remoteName, opts.Branch)
+	err = repo.Fetch(&gogit.FetchOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{config.RefSpec(refSpec)},
+		Auth:       auth,
+	})
+	if errors.Is(err, gogit.NoMatchingRefSpecError{}) {
+		return fmt.Errorf("%w: %s", ErrRemoteBranchNotFound, opts.Branch)
+	}
+	if err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("failed to fetch: %s", logger.RedactText(err.Error(), opts.Token))
+	}
+
+	return nil
+}
+
+// ResetHard implements Backend
+func (b *NativeBackend) ResetHard(repoPath, revision string) error {
+	repo, wt, err := b.worktree(repoPath)
+	if err != nil {
+		return err
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", revision, err)
+	}
+
+	// Reset can't move an unborn branch (new repository), so create the branch first
+	if _, err := repo.Head(); errors.Is(err, plumbing.ErrReferenceNotFound) {
+		head, err := repo.Reference(plumbing.HEAD, false)
+		if err != nil {
+			return fmt.Errorf("failed to read HEAD: %w", err)
+		}
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(head.Target(), *hash)); err != nil {
+			return fmt.Errorf("failed to create branch %s: %w", head.Target().Short(), err)
+		}
+	}
+
+	if err := wt.Reset(&gogit.ResetOptions{Commit: *hash, Mode: gogit.HardReset}); err != nil {
+		return fmt.Errorf("failed to reset to %s: %w", revision, err)
+	}
+
+	return nil
+}
+
+// Restore implements Backend
+func (b *NativeBackend) Restore(repoPath string, files []string) error {
+	repo, wt, err := b.worktree(repoPath)
+	if err != nil {
+		return err
+	}
+
+	// A new repository has no HEAD, so no file is at HEAD
+	var tree *object.Tree
+	if head, err := repo.Head(); err == nil {
+		commit, err := repo.CommitObject(head.Hash())
+		if err != nil {
+			return fmt.Errorf("failed to read HEAD: %w", err)
+		}
+		if tree, err = commit.Tree(); err != nil {
+			return fmt.Errorf("failed to read HEAD: %w", err)
+		}
+	}
+
+	var tracked []string
+	for _, file := range files {
+		path, err := worktreePath(wt, repoPath, file)
+		if err != nil {
+			return err
+		}
+		if tree != nil {
+			if _, err := tree.File(path); err == nil {
+				tracked = append(tracked, path)
+				continue
+			}
+		}
+		// Files that are not at HEAD (e.g. a new chart) are removed
+		if err := wt.Filesystem.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", file, err)
+		}
+	}
+
+	if len(tracked) > 0 {
+		if err := wt.Restore(&gogit.RestoreOptions{Staged: true, Worktree: true, Files: tracked}); err != nil {
+			return fmt.Errorf("failed to restore files: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// CurrentBranch implements Backend
+func (b *NativeBackend) CurrentBranch(repoPath string) (string, error) {
+	repo, err := b.open(repoPath)
+	if err != nil {
+		return "", err
+	}
+
+	head, err := repo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	// Detached HEAD (same output as git rev-parse --abbrev-ref HEAD)
+	if head.Type() != plumbing.SymbolicReference {
+		return "HEAD", nil
+	}
+
+	return head.Target().Short(), nil
+}
+
+// ResolveRevision implements Backend
+func (b *NativeBackend) ResolveRevision(repoPath, revision string) (string, error) {
+	repo, err := b.open(repoPath)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", revision, err)
+	}
+	return hash.String(), nil
+}
+
+// ResetBranch implements Backend
+func (b *NativeBackend) ResetBranch(repoPath, branch string) error {
+	repo, err := b.open(repoPath)
+	if err != nil {
+		return err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	// Point the branch at the current commit and switch HEAD without touching the index or working tree
+	branchRef := plumbing.NewBranchReferenceName(branch)
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(branchRef, head.Hash())); err != nil {
+		return fmt.Errorf("failed to reset branch %s: %w", branch, err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, branchRef)); err != nil {
+		return fmt.Errorf("failed to switch to branch %s: %w", branch, err)
+	}
+
+	return nil
+}
+
+// Checkout implements Backend
+func (b *NativeBackend) Checkout(repoPath, branch string) error {
+	_, wt, err := b.worktree(repoPath)
+	if err != nil {
+		return err
+	}
+
+	if err := wt.Checkout(&gogit.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(branch)}); err != nil {
+		return fmt.Errorf("failed to checkout %s: %w", branch, err)
+	}
+
+	return nil
+}
+
+// RemoteURL implements Backend
+func (b *NativeBackend) RemoteURL(repoPath, remote string) (string, error) {
+	repo, err := b.open(repoPath)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := repo.Remote(remote)
+	if err != nil {
+		return "", fmt.Errorf("failed to get remote %s: %w", remote, err)
+	}
+
+	urls := r.Config().URLs
+	if len(urls) == 0 {
+		return "", fmt.Errorf("remote %s has no URL", remote)
+	}
+
+	return urls[0], nil
+}
+
+// worktreePath converts a path relative to repoPath into a path relative to the worktree root
+func worktreePath(wt *gogit.Worktree, repoPath, file string) (string, error) {
+	if !filepath.IsAbs(file) {
+		absRepoPath, err := filepath.Abs(repoPath)
+		if err != nil {
+			return "", err
+		}
+		file = filepath.Join(absRepoPath, file)
+	}
+
+	path, err := filepath.Rel(wt.Filesystem.Root(), file)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", file, err)
+	}
+
+	return filepath.ToSlash(path), nil
+}
+
+// pushAuth returns credentials for push
+// Uses the token if set, otherwise the http.<url>.extraheader configured by actions/checkout
+func pushAuth(repo *gogit.Repository, remoteURL, token string) (transport.AuthMethod, error) {
+	if token != "" {
+		return &http.BasicAuth{Username: "x-access-token", Password: token}, nil
+	}
+
+	cfg, err := repo.Config()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git configuration: %w", err)
+	}
+
+	for _, subsection := range cfg.Raw.Section("http").Subsections {
+		if !strings.HasPrefix(remoteURL, subsection.Name) {
+			continue
+		}
+
+		header := subsection.Option("extraheader")
+		const prefix = "authorization: basic "
+		if !strings.HasPrefix(strings.ToLower(header), prefix) {
+			continue
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(header[len(prefix):]))
+		if err != nil {
+			continue
+		}
+		username, password, ok := strings.Cut(string(decoded), ":")
+		if ok {
+			return &http.BasicAuth{Username: username, Password: password}, nil
+		}
+	}
+
+	// Use no credentials (e.g. local or SSH remotes)
+	return nil, nil
+}
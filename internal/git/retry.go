@@ -0,0 +1,154 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Default retry settings of CommitAndPushWithRetry
+const (
+	// DefaultPushAttempts maximum number of push attempts
+	DefaultPushAttempts = 3
+
+	// DefaultPushBackoff wait before the first retry (doubled for each retry)
+	DefaultPushBackoff = 2 * time.Second
+)
+
+// RetryOptions options of CommitAndPushWithRetry
+type RetryOptions struct {
+	Attempts int           // Maximum number of push attempts (0 = DefaultPushAttempts)
+	Backoff  time.Duration // Wait before the first retry, doubled for each retry (0 = DefaultPushBackoff)
+}
+
+// RegenerateFunc rewrites the generated files on top of the fetched remote branch
+type RegenerateFunc func() error
+
+// CommitAndPushWithRetry commits and pushes changes, rebasing onto the remote branch if it moved
+//
+// Preconditions:
+// - repoPath is a valid Git repository path
+// - message is a valid commit message
+// - files is a list of files to commit (if empty, all changes are committed)
+// - remote is the remote name (optional, default is "origin")
+// - branch is the branch name (optional, default is current branch)
+// - token is a GitHub Personal Access Token (optional)
+// - regenerate rewrites the generated files (nil = restore the committed contents of the changed files)
+//
+// Postconditions:
+// - Changes are committed and pushed to remote repository
+// - If the push is rejected as non-fast-forward, the branch is reset to the remote branch, the generated files are rewritten, committed and pushed again
+// - Returns error if the push still fails after the last attempt
+//
+// Invariants:
+// - Commits on the remote branch are never overwritten (no force push)
+// - Does nothing if there are no changes
+// - The wait before each retry doubles
+func CommitAndPushWithRetry(repoPath, message string, files []string, remote, branch, token string, regenerate RegenerateFunc, retry RetryOptions) error {
+	if remote == "" {
+		remote = "origin"
+	}
+	if retry.Attempts <= 0 {
+		retry.Attempts = DefaultPushAttempts
+	}
+	if retry.Backoff <= 0 {
+		retry.Backoff = DefaultPushBackoff
+	}
+
+	changed, err := DetectChanges(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to check for changes: %w", err)
+	}
+	if len(changed) == 0 {
+		return nil // Do nothing if there are no changes
+	}
+
+	if branch == "" {
+		branch, err = GetCurrentBranch(repoPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Without regenerate, the contents of the changed files are restored after the rebase
+	if regenerate == nil {
+		if len(files) > 0 {
+			changed = files
+		}
+		regenerate, err = restoreFilesFunc(repoPath, changed)
+		if err != nil {
+			return err
+		}
+	}
+
+	backoff := retry.Backoff
+	for attempt := 1; ; attempt++ {
+		if err := Commit(repoPath, message, files); err != nil {
+			return fmt.Errorf("failed to commit: %w", err)
+		}
+
+		err = Push(repoPath, remote, branch, token)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ErrNonFastForward) || attempt >= retry.Attempts {
+			return fmt.Errorf("failed to push (attempt %d/%d): %w", attempt, retry.Attempts, err)
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+
+		// Rebase the generated files onto the remote branch
+		if err := DefaultBackend.Fetch(repoPath, PushOptions{Remote: remote, Branch: branch, Token: token}); err != nil {
+			return fmt.Errorf("failed to fetch %s/%s: %w", remote, branch, err)
+		}
+		if err := DefaultBackend.ResetHard(repoPath, fmt.Sprintf("refs/remotes/%s/%s", remote, branch)); err != nil {
+			return fmt.Errorf("failed to reset to %s/%s: %w", remote, branch, err)
+		}
+		if err := regenerate(); err != nil {
+			return fmt.Errorf("failed to regenerate files: %w", err)
+		}
+
+		hasChanges, err := HasChanges(repoPath)
+		if err != nil {
+			return fmt.Errorf("failed to check for changes: %w", err)
+		}
+		if !hasChanges {
+			return nil // The remote branch already has the same content
+		}
+	}
+}
+
+// restoreFilesFunc saves the current contents of files and returns a function that writes them back
+// Files that don't exist are removed when restored
+func restoreFilesFunc(repoPath string, files []string) (RegenerateFunc, error) {
+	contents := make(map[string][]byte, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(repoPath, file))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		contents[file] = data
+	}
+
+	return func() error {
+		for file, data := range contents {
+			path := filepath.Join(repoPath, file)
+			if data == nil {
+				if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
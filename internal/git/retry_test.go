@@ -0,0 +1,143 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// pushFromClone は別のクローンからリモートにコミットをプッシュする（手動での README 編集を想定）
+func pushFromClone(t *testing.T, remoteDir, file, content string) {
+	t.Helper()
+	cloneDir := filepath.Join(t.TempDir(), "clone")
+	repo, err := gogit.PlainClone(cloneDir, false, &gogit.CloneOptions{
+		URL:           remoteDir,
+		ReferenceName: plumbing.NewBranchReferenceName("main"),
+	})
+	if err != nil {
+		t.Fatalf("クローンに失敗しました: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(cloneDir, file), []byte(content), 0644); err != nil {
+		t.Fatalf("テストファイルの更新に失敗しました: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("ワークツリーの取得に失敗しました: %v", err)
+	}
+	if _, err := wt.Add(file); err != nil {
+		t.Fatalf("git add に失敗しました: %v", err)
+	}
+	signature := &object.Signature{Name: "Someone", Email: "someone@example.com", When: time.Now()}
+	if _, err := wt.Commit("Edit "+file, &gogit.CommitOptions{Author: signature}); err != nil {
+		t.Fatalf("コミットに失敗しました: %v", err)
+	}
+	if err := repo.Push(&gogit.PushOptions{}); err != nil {
+		t.Fatalf("プッシュに失敗しました: %v", err)
+	}
+}
+
+// remoteFile はリモートブランチのファイル内容を返す
+func remoteFile(t *testing.T, remoteDir, branch, file string) string {
+	t.Helper()
+	repo, err := gogit.PlainOpen(remoteDir)
+	if err != nil {
+		t.Fatalf("リポジトリを開けませんでした: %v", err)
+	}
+	commit, err := repo.CommitObject(refHash(t, remoteDir, branch))
+	if err != nil {
+		t.Fatalf("コミットの取得に失敗しました: %v", err)
+	}
+	f, err := commit.File(file)
+	if err != nil {
+		t.Fatalf("%s の取得に失敗しました: %v", file, err)
+	}
+	content, err := f.Contents()
+	if err != nil {
+		t.Fatalf("%s の読み込みに失敗しました: %v", file, err)
+	}
+	return content
+}
+
+func TestCommitAndPushWithRetry(t *testing.T) {
+	retry := RetryOptions{Attempts: 3, Backoff: time.Millisecond}
+
+	for _, name := range []string{BackendExec, BackendNative} {
+		t.Run(name, func(t *testing.T) {
+			useBackend(t, name)
+
+			t.Run("再生成", func(t *testing.T) {
+				repoDir, remoteDir := setupNativeRepository(t)
+
+				// 実行中に README.md が手動で編集される
+				pushFromClone(t, remoteDir, "README.md", "# Test\n\nmanual edit\n")
+
+				// 生成したファイル: README.md の末尾にセクションを追加する
+				regenerate := func() error {
+					readme, err := os.ReadFile(filepath.Join(repoDir, "README.md"))
+					if err != nil {
+						return err
+					}
+					if err := os.WriteFile(filepath.Join(repoDir, "README.md"), append(readme, []byte("\nstats\n")...), 0644); err != nil {
+						return err
+					}
+					return os.WriteFile(filepath.Join(repoDir, "chart.svg"), []byte("<svg/>\n"), 0644)
+				}
+				if err := regenerate(); err != nil {
+					t.Fatalf("ファイルの生成に失敗しました: %v", err)
+				}
+
+				if err := CommitAndPushWithRetry(repoDir, "Update metrics", nil, "origin", "main", "", regenerate, retry); err != nil {
+					t.Fatalf("CommitAndPushWithRetry() エラー = %v", err)
+				}
+
+				if got := remoteFile(t, remoteDir, "main", "README.md"); got != "# Test\n\nmanual edit\n\nstats\n" {
+					t.Errorf("リモートの README.md = %q, want 手動編集と生成結果の両方", got)
+				}
+				if got := remoteFile(t, remoteDir, "main", "chart.svg"); got != "<svg/>\n" {
+					t.Errorf("リモートの chart.svg = %q", got)
+				}
+				if head := refHash(t, repoDir, "main"); head != refHash(t, remoteDir, "main") {
+					t.Errorf("ローカルの main がリモートと一致しません")
+				}
+			})
+
+			t.Run("変更ファイルの復元", func(t *testing.T) {
+				repoDir, remoteDir := setupNativeRepository(t)
+				pushFromClone(t, remoteDir, "NOTES.md", "notes\n")
+
+				if err := os.WriteFile(filepath.Join(repoDir, "chart.svg"), []byte("<svg/>\n"), 0644); err != nil {
+					t.Fatalf("テストファイルの作成に失敗しました: %v", err)
+				}
+				if err := CommitAndPushWithRetry(repoDir, "Update metrics", nil, "origin", "", "", nil, retry); err != nil {
+					t.Fatalf("CommitAndPushWithRetry() エラー = %v", err)
+				}
+
+				if got := remoteFile(t, remoteDir, "main", "chart.svg"); got != "<svg/>\n" {
+					t.Errorf("リモートの chart.svg = %q", got)
+				}
+				if got := remoteFile(t, remoteDir, "main", "NOTES.md"); got != "notes\n" {
+					t.Errorf("リモートの NOTES.md = %q", got)
+				}
+			})
+
+			t.Run("再試行回数の上限", func(t *testing.T) {
+				repoDir, remoteDir := setupNativeRepository(t)
+				pushFromClone(t, remoteDir, "README.md", "# Test\n\nmanual edit\n")
+
+				if err := os.WriteFile(filepath.Join(repoDir, "chart.svg"), []byte("<svg/>\n"), 0644); err != nil {
+					t.Fatalf("テストファイルの作成に失敗しました: %v", err)
+				}
+				err := CommitAndPushWithRetry(repoDir, "Update metrics", nil, "origin", "main", "", nil, RetryOptions{Attempts: 1, Backoff: time.Millisecond})
+				if err == nil || !strings.Contains(err.Error(), "attempt 1/1") {
+					t.Errorf("CommitAndPushWithRetry() エラー = %v, want 再試行なしでの失敗", err)
+				}
+			})
+		})
+	}
+}
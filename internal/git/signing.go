@@ -0,0 +1,158 @@
+package git
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	gogit "github.com/go-git/go-git/v5"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshSignatureNamespace namespace used by git for SSH commit signatures
+const sshSignatureNamespace = "git"
+
+// newSigner creates a commit signer for the native backend
+// Returns nil if signing is disabled
+func newSigner(opts CommitOptions) (gogit.Signer, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch opts.SigningFormat {
+	case SigningGPG:
+		if opts.SigningKey == "" {
+			return nil, fmt.Errorf("signing key file is required for GPG signing with the native backend")
+		}
+		return newGPGSigner(opts.SigningKey, opts.SigningPassphrase)
+	case SigningSSH:
+		return newSSHSigner(opts.SigningKey, opts.SigningPassphrase)
+	default:
+		return nil, nil
+	}
+}
+
+// gpgSigner signs commits with an OpenPGP private key
+type gpgSigner struct {
+	entity *openpgp.Entity
+}
+
+// newGPGSigner loads an armored OpenPGP private key
+func newGPGSigner(keyPath, passphrase string) (*gpgSigner, error) {
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GPG signing key: %w", err)
+	}
+	if len(entities) == 0 || entities[0].PrivateKey == nil {
+		return nil, fmt.Errorf("GPG signing key doesn't contain a private key")
+	}
+
+	entity := entities[0]
+	if entity.PrivateKey.Encrypted {
+		if err := entity.DecryptPrivateKeys([]byte(passphrase)); err != nil {
+			return nil, fmt.Errorf("failed to decrypt GPG signing key: %w", err)
+		}
+	}
+
+	return &gpgSigner{entity: entity}, nil
+}
+
+// Sign implements gogit.Signer
+func (s *gpgSigner) Sign(message io.Reader) ([]byte, error) {
+	var signature bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&signature, s.entity, message, nil); err != nil {
+		return nil, fmt.Errorf("failed to sign commit: %w", err)
+	}
+	return signature.Bytes(), nil
+}
+
+// sshSigner signs commits in the SSHSIG format used by git (gpg.format=ssh)
+type sshSigner struct {
+	signer ssh.Signer
+}
+
+// newSSHSigner loads an SSH private key
+func newSSHSigner(keyPath, passphrase string) (*sshSigner, error) {
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+
+	var signer ssh.Signer
+	if passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(data, []byte(passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SSH signing key: %w", err)
+	}
+
+	return &sshSigner{signer: signer}, nil
+}
+
+// Sign implements gogit.Signer
+func (s *sshSigner) Sign(message io.Reader) ([]byte, error) {
+	hash := sha512.New()
+	if _, err := io.Copy(hash, message); err != nil {
+		return nil, err
+	}
+
+	// Data to be signed (see PROTOCOL.sshsig in OpenSSH)
+	signedData := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{sshSignatureNamespace, "", "sha512", hash.Sum(nil)})...)
+
+	// RSA keys must not use SHA-1 signatures
+	var signature *ssh.Signature
+	var err error
+	if algorithmSigner, ok := s.signer.(ssh.AlgorithmSigner); ok && s.signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		signature, err = algorithmSigner.SignWithAlgorithm(rand.Reader, signedData, ssh.KeyAlgoRSASHA512)
+	} else {
+		signature, err = s.signer.Sign(rand.Reader, signedData)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign commit: %w", err)
+	}
+
+	blob := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}{1, s.signer.PublicKey().Marshal(), sshSignatureNamespace, "", "sha512", ssh.Marshal(signature)})...)
+
+	return armorSSHSignature(blob), nil
+}
+
+// armorSSHSignature encodes a signature blob in the PEM-like armor written by ssh-keygen
+func armorSSHSignature(blob []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(blob)
+
+	var armored strings.Builder
+	armored.WriteString("-----BEGIN SSH SIGNATURE-----\n")
+	for len(encoded) > 70 {
+		armored.WriteString(encoded[:70] + "\n")
+		encoded = encoded[70:]
+	}
+	armored.WriteString(encoded + "\n")
+	armored.WriteString("-----END SSH SIGNATURE-----\n")
+
+	return []byte(armored.String())
+}
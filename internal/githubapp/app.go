@@ -0,0 +1,218 @@
+package githubapp
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/logger"
+
+	"github.com/google/go-github/v76/github"
+	"golang.org/x/oauth2"
+)
+
+// Lifetimes of the tokens
+const (
+	// jwtLifetime lifetime of the JWT authenticating as the app (GitHub accepts at most 10 minutes)
+	jwtLifetime = 9 * time.Minute
+	// jwtClockSkew the JWT is issued in the past to tolerate clock drift
+	jwtClockSkew = 60 * time.Second
+	// refreshMargin installation tokens are refreshed this long before they expire
+	refreshMargin = 5 * time.Minute
+)
+
+// Config GitHub App credentials
+type Config struct {
+	AppID          string       // App ID (or client ID) of the GitHub App
+	PrivateKey     string       // PEM encoded private key of the GitHub App
+	InstallationID int64        // Installation ID (0 = looked up from Repository)
+	Repository     string       // Repository the app is installed on ("owner/repo"), used when InstallationID is 0
+	APIURL         string       // REST API base URL (empty = https://api.github.com/)
+	HTTPClient     *http.Client // Unauthenticated HTTP client (nil = http.DefaultClient)
+}
+
+// TokenSource mints installation tokens of a GitHub App
+// It implements oauth2.TokenSource, so API clients pick up refreshed tokens automatically
+type TokenSource struct {
+	config Config
+	key    *rsa.PrivateKey
+	now    func() time.Time
+
+	mu             sync.Mutex
+	installationID int64
+	token          *oauth2.Token
+}
+
+// NewTokenSource creates a token source of the GitHub App
+//
+// Preconditions:
+// - config has an AppID and a PEM encoded RSA private key
+// - config has an InstallationID or a Repository
+//
+// Postconditions:
+// - Returns a token source (no request is made until Token is called)
+// - Returns error if the configuration or the private key is invalid
+func NewTokenSource(config Config) (*TokenSource, error) {
+	if config.AppID == "" {
+		return nil, errors.New("GitHub App ID is not set")
+	}
+	if config.InstallationID == 0 && config.Repository == "" {
+		return nil, errors.New("GitHub App installation ID or repository is required")
+	}
+
+	key, err := ParsePrivateKey(config.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+
+	return &TokenSource{
+		config:         config,
+		key:            key,
+		now:            time.Now,
+		installationID: config.InstallationID,
+	}, nil
+}
+
+// Token returns an installation token, minting a new one if the current token expires soon
+//
+// Postconditions:
+// - Returns a token valid for at least refreshMargin (GitHub issues tokens valid for one hour)
+// - Returns error if the installation cannot be found or the exchange fails
+//
+// Invariants:
+// - Safe for concurrent use, at most one exchange runs at a time
+// - Minted tokens are masked in log output
+func (s *TokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && s.now().Add(refreshMargin).Before(s.token.Expiry) {
+		return s.token, nil
+	}
+
+	ctx := context.Background()
+	client, err := s.appClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.installationID == 0 {
+		owner, repo, ok := strings.Cut(s.config.Repository, "/")
+		if !ok || owner == "" || repo == "" {
+			return nil, fmt.Errorf("invalid repository: %s (use owner/repo)", s.config.Repository)
+		}
+		installation, _, err := client.Apps.FindRepositoryInstallation(ctx, owner, repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find GitHub App installation on %s: %w", s.config.Repository, err)
+		}
+		s.installationID = installation.GetID()
+		logger.Info("GitHub App installation found: %d", s.installationID)
+	}
+
+	installationToken, _, err := client.Apps.CreateInstallationToken(ctx, s.installationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub App installation token: %w", err)
+	}
+	if installationToken.GetToken() == "" {
+		return nil, errors.New("GitHub App installation token is empty")
+	}
+
+	logger.AddSecrets(installationToken.GetToken())
+	s.token = &oauth2.Token{
+		AccessToken: installationToken.GetToken(),
+		TokenType:   "Bearer",
+		Expiry:      installationToken.GetExpiresAt().Time,
+	}
+	logger.Info("GitHub App installation token minted (expires at %s)", s.token.Expiry.Format(time.RFC3339))
+
+	return s.token, nil
+}
+
+// appClient creates a REST API client authenticated as the app with a fresh JWT
+func (s *TokenSource) appClient() (*github.Client, error) {
+	jwt, err := SignJWT(s.config.AppID, s.key, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	client := github.NewClient(s.config.HTTPClient).WithAuthToken(jwt)
+	if s.config.APIURL != "" {
+		baseURL, err := url.Parse(strings.TrimSuffix(s.config.APIURL, "/") + "/")
+		if err != nil {
+			return nil, fmt.Errorf("invalid API URL: %w", err)
+		}
+		client.BaseURL = baseURL
+	}
+
+	return client, nil
+}
+
+// SignJWT creates a JWT authenticating as the GitHub App (RS256)
+//
+// Preconditions:
+// - appID is the App ID (or client ID) of the GitHub App
+// - key is the private key of the GitHub App
+//
+// Postconditions:
+// - Returns a JWT issued 60 seconds before now and expiring 9 minutes after now
+func SignJWT(appID string, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-jwtClockSkew).Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+		"iss": appID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// ParsePrivateKey parses a PEM encoded RSA private key (PKCS #1 as downloaded from GitHub, or PKCS #8)
+func ParsePrivateKey(value string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(value))
+	if block == nil {
+		return nil, errors.New("GitHub App private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("GitHub App private key is not an RSA key")
+	}
+	return key, nil
+}
@@ -0,0 +1,220 @@
+package githubapp
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestKey generates an RSA key and returns it with its PKCS #1 PEM encoding
+func newTestKey(t *testing.T) (*rsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	encoded := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return key, string(encoded)
+}
+
+// verifyJWT verifies the RS256 signature of jwt and returns its claims
+func verifyJWT(t *testing.T, jwt string, key *rsa.PublicKey) map[string]any {
+	t.Helper()
+
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("JWT has %d parts, want 3", len(parts))
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("failed to decode signature: %v", err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		t.Fatalf("invalid JWT signature: %v", err)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("failed to decode claims: %v", err)
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatalf("failed to parse claims: %v", err)
+	}
+	return claims
+}
+
+// fakeTokenEndpoint fake GitHub REST API issuing installation tokens of installation 42 on acme/profile
+type fakeTokenEndpoint struct {
+	*httptest.Server
+	minted  int
+	expires time.Time
+}
+
+// newFakeTokenEndpoint starts a fake token endpoint accepting JWTs signed with key
+func newFakeTokenEndpoint(t *testing.T, key *rsa.PublicKey, expires time.Time) *fakeTokenEndpoint {
+	t.Helper()
+
+	endpoint := &fakeTokenEndpoint{expires: expires}
+	endpoint.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims := verifyJWT(t, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), key)
+		if claims["iss"] != "12345" {
+			t.Errorf("iss = %v, want 12345", claims["iss"])
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/profile/installation":
+			json.NewEncoder(w).Encode(map[string]any{"id": 42})
+		case r.Method == http.MethodPost && r.URL.Path == "/app/installations/42/access_tokens":
+			endpoint.minted++
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]any{
+				"token":      fmt.Sprintf("ghs_installation_token_%d", endpoint.minted),
+				"expires_at": endpoint.expires.Format(time.RFC3339),
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{"message": "Not Found"})
+		}
+	}))
+	t.Cleanup(endpoint.Close)
+	return endpoint
+}
+
+// TestSignJWT verifies the claims and the signature of the app JWT
+func TestSignJWT(t *testing.T) {
+	key, _ := newTestKey(t)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	jwt, err := SignJWT("12345", key, now)
+	if err != nil {
+		t.Fatalf("SignJWT() error = %v", err)
+	}
+
+	claims := verifyJWT(t, jwt, &key.PublicKey)
+	if claims["iss"] != "12345" {
+		t.Errorf("iss = %v, want 12345", claims["iss"])
+	}
+	if claims["iat"] != float64(now.Add(-time.Minute).Unix()) {
+		t.Errorf("iat = %v, want one minute before now", claims["iat"])
+	}
+	if claims["exp"] != float64(now.Add(9*time.Minute).Unix()) {
+		t.Errorf("exp = %v, want nine minutes after now", claims["exp"])
+	}
+}
+
+// TestTokenSource_Token verifies that the installation is looked up and tokens are reused until they expire
+func TestTokenSource_Token(t *testing.T) {
+	key, keyPEM := newTestKey(t)
+	now := time.Now()
+	endpoint := newFakeTokenEndpoint(t, &key.PublicKey, now.Add(time.Hour))
+
+	source, err := NewTokenSource(Config{
+		AppID:      "12345",
+		PrivateKey: keyPEM,
+		Repository: "acme/profile",
+		APIURL:     endpoint.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewTokenSource() error = %v", err)
+	}
+	source.now = func() time.Time { return now }
+
+	token, err := source.Token()
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if token.AccessToken != "ghs_installation_token_1" {
+		t.Errorf("AccessToken = %q, want ghs_installation_token_1", token.AccessToken)
+	}
+
+	// Still valid: the token is reused
+	now = now.Add(30 * time.Minute)
+	token, err = source.Token()
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if token.AccessToken != "ghs_installation_token_1" || endpoint.minted != 1 {
+		t.Errorf("AccessToken = %q after %d exchanges, want the first token to be reused", token.AccessToken, endpoint.minted)
+	}
+
+	// Expires soon: a new token is minted
+	now = now.Add(26 * time.Minute)
+	endpoint.expires = now.Add(time.Hour)
+	token, err = source.Token()
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if token.AccessToken != "ghs_installation_token_2" {
+		t.Errorf("AccessToken = %q, want ghs_installation_token_2 after refresh", token.AccessToken)
+	}
+}
+
+// TestTokenSource_Errors verifies that invalid configurations and failed exchanges are reported
+func TestTokenSource_Errors(t *testing.T) {
+	key, keyPEM := newTestKey(t)
+	endpoint := newFakeTokenEndpoint(t, &key.PublicKey, time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name      string
+		config    Config
+		wantNewOK bool
+	}{
+		{name: "missing app ID", config: Config{PrivateKey: keyPEM, InstallationID: 42}},
+		{name: "missing installation", config: Config{AppID: "12345", PrivateKey: keyPEM}},
+		{name: "invalid private key", config: Config{AppID: "12345", PrivateKey: "not a key", InstallationID: 42}},
+		{name: "app not installed", config: Config{AppID: "12345", PrivateKey: keyPEM, Repository: "acme/other", APIURL: endpoint.URL}, wantNewOK: true},
+		{name: "unknown installation", config: Config{AppID: "12345", PrivateKey: keyPEM, InstallationID: 7, APIURL: endpoint.URL}, wantNewOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := NewTokenSource(tt.config)
+			if !tt.wantNewOK {
+				if err == nil {
+					t.Error("NewTokenSource() should return error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewTokenSource() error = %v", err)
+			}
+			if _, err := source.Token(); err == nil {
+				t.Error("Token() should return error")
+			}
+		})
+	}
+}
+
+// TestParsePrivateKey verifies that PKCS #1 and PKCS #8 keys are accepted
+func TestParsePrivateKey(t *testing.T) {
+	key, pkcs1 := newTestKey(t)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	pkcs8 := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	for name, value := range map[string]string{"PKCS #1": pkcs1, "PKCS #8": pkcs8} {
+		parsed, err := ParsePrivateKey(value)
+		if err != nil {
+			t.Fatalf("ParsePrivateKey(%s) error = %v", name, err)
+		}
+		if !parsed.Equal(key) {
+			t.Errorf("ParsePrivateKey(%s) returned a different key", name)
+		}
+	}
+}
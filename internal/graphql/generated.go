@@ -4,6 +4,7 @@
 package graphql
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/hasura/go-graphql-client"
@@ -232,7 +233,7 @@ type ProductiveTimeQuery struct {
 // ============================================================================
 
 // NewGraphQLClient GraphQLクライアントを作成
-func NewGraphQLClient(endpoint string, token string) *graphql.Client {
-	// この関数は実際の実装で置き換えられます
-	return graphql.NewClient(endpoint, nil)
+// 認証・プロキシ・CA証明書は httpClient で設定する（nil = http.DefaultClient）
+func NewGraphQLClient(endpoint string, httpClient *http.Client) *graphql.Client {
+	return graphql.NewClient(endpoint, httpClient)
 }
@@ -0,0 +1,184 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Log formats
+const (
+	FormatAuto    = "auto"    // actions in GitHub Actions, text otherwise
+	FormatText    = "text"    // Plain text lines
+	FormatJSON    = "json"    // One JSON object per line
+	FormatActions = "actions" // GitHub Actions workflow commands
+)
+
+// EntryKind kind of a log entry
+type EntryKind int
+
+const (
+	EntryLog        EntryKind = iota // Leveled log message
+	EntryOutput                      // Progress message for users (Print)
+	EntryGroupStart                  // Start of a group (Message is the title)
+	EntryGroupEnd                    // End of a group
+	EntryMask                        // Secret registered to be masked (Message is the secret)
+)
+
+// Entry log entry passed to formatters
+type Entry struct {
+	Kind    EntryKind
+	Level   LogLevel
+	Time    time.Time
+	Message string // Message with secrets redacted (except EntryMask)
+	File    string // File the message refers to (optional)
+}
+
+// Formatter converts log entries to output lines
+type Formatter interface {
+	// Format returns the output of an entry ("" = nothing is written)
+	Format(entry Entry) string
+}
+
+// NewFormatter returns the formatter of a log format
+//
+// Preconditions:
+// - format is one of FormatAuto, FormatText, FormatJSON, FormatActions or empty (= FormatAuto)
+//
+// Postconditions:
+// - Returns the formatter, or an error if the format is unknown
+//
+// Invariants:
+// - FormatAuto selects FormatActions when GITHUB_ACTIONS is "true"
+func NewFormatter(format string) (Formatter, error) {
+	switch strings.ToLower(format) {
+	case "", FormatAuto:
+		if os.Getenv("GITHUB_ACTIONS") == "true" {
+			return &ActionsFormatter{}, nil
+		}
+		return &TextFormatter{}, nil
+	case FormatText:
+		return &TextFormatter{}, nil
+	case FormatJSON:
+		return &JSONFormatter{}, nil
+	case FormatActions:
+		return &ActionsFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown log format: %s (use auto, text, json or actions)", format)
+	}
+}
+
+// TextFormatter plain text format
+// Log messages are prefixed with time and level, progress messages are written as is
+type TextFormatter struct{}
+
+// Format implements Formatter
+func (f *TextFormatter) Format(entry Entry) string {
+	switch entry.Kind {
+	case EntryLog:
+		message := entry.Message
+		if entry.File != "" {
+			message = entry.File + ": " + message
+		}
+		return fmt.Sprintf("%s [%s] %s", entry.Time.Format("2006/01/02 15:04:05"), entry.Level, message)
+	case EntryOutput:
+		return entry.Message
+	case EntryGroupStart:
+		return "\n" + entry.Message
+	default:
+		return ""
+	}
+}
+
+// JSONFormatter one JSON object per line
+type JSONFormatter struct{}
+
+// jsonEntry JSON representation of an entry
+type jsonEntry struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+	File    string `json:"file,omitempty"`
+}
+
+// Format implements Formatter
+func (f *JSONFormatter) Format(entry Entry) string {
+	var kind string
+	switch entry.Kind {
+	case EntryLog:
+		kind = "log"
+	case EntryOutput:
+		kind = "output"
+	case EntryGroupStart:
+		kind = "group"
+	default:
+		return ""
+	}
+
+	data, err := json.Marshal(jsonEntry{
+		Time:    entry.Time.Format(time.RFC3339),
+		Level:   entry.Level.String(),
+		Kind:    kind,
+		Message: entry.Message,
+		File:    entry.File,
+	})
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// ActionsFormatter GitHub Actions workflow commands
+// Warnings and errors become annotations, groups become collapsible sections and secrets are masked with ::add-mask::
+type ActionsFormatter struct{}
+
+// Format implements Formatter
+func (f *ActionsFormatter) Format(entry Entry) string {
+	switch entry.Kind {
+	case EntryLog:
+		switch entry.Level {
+		case LogLevelDebug:
+			return workflowCommand("debug", "", entry.Message)
+		case LogLevelWarning:
+			return workflowCommand("warning", entry.File, entry.Message)
+		case LogLevelError, LogLevelFatal:
+			return workflowCommand("error", entry.File, entry.Message)
+		default:
+			return entry.Message
+		}
+	case EntryOutput:
+		return entry.Message
+	case EntryGroupStart:
+		return workflowCommand("group", "", entry.Message)
+	case EntryGroupEnd:
+		return "::endgroup::"
+	case EntryMask:
+		return workflowCommand("add-mask", "", entry.Message)
+	default:
+		return ""
+	}
+}
+
+// workflowCommand formats a workflow command (::name file=...::message)
+func workflowCommand(name, file, message string) string {
+	var command strings.Builder
+	command.WriteString("::" + name)
+	if file != "" {
+		command.WriteString(" file=" + escapeProperty(file))
+	}
+	command.WriteString("::" + escapeData(message))
+	return command.String()
+}
+
+// escapeData escapes the message of a workflow command
+func escapeData(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(value)
+}
+
+// escapeProperty escapes a property value of a workflow command
+func escapeProperty(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(value)
+}
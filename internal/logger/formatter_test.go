@@ -0,0 +1,153 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNewFormatter(t *testing.T) {
+	tests := []struct {
+		name          string
+		format        string
+		githubActions string
+		want          Formatter
+		wantErr       bool
+	}{
+		{name: "text", format: "text", want: &TextFormatter{}},
+		{name: "json", format: "JSON", want: &JSONFormatter{}},
+		{name: "actions", format: "actions", want: &ActionsFormatter{}},
+		{name: "auto (GitHub Actions)", format: "", githubActions: "true", want: &ActionsFormatter{}},
+		{name: "auto (ローカル)", format: "auto", githubActions: "", want: &TextFormatter{}},
+		{name: "不明な形式", format: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GITHUB_ACTIONS", tt.githubActions)
+			got, err := NewFormatter(tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewFormatter(%q) エラー = %v, wantErr %v", tt.format, err, tt.wantErr)
+			}
+			if !tt.wantErr && typeName(got) != typeName(tt.want) {
+				t.Errorf("NewFormatter(%q) = %s, want %s", tt.format, typeName(got), typeName(tt.want))
+			}
+		})
+	}
+}
+
+// typeName はフォーマッタの型名を返す
+func typeName(f Formatter) string {
+	switch f.(type) {
+	case *TextFormatter:
+		return "text"
+	case *JSONFormatter:
+		return "json"
+	case *ActionsFormatter:
+		return "actions"
+	default:
+		return "unknown"
+	}
+}
+
+// logAll はすべての種類のエントリを出力する
+func logAll(formatter Formatter) string {
+	var buf bytes.Buffer
+	l := NewLogger(LogLevelDebug, &buf)
+	l.SetFormatter(formatter)
+
+	l.AddSecrets("custom-secret-value")
+	l.Group("📊 Fetching data")
+	l.Debug("debug message")
+	l.Info("info message")
+	l.Print("  ✅ Data fetched")
+	l.Group("🎨 Generating charts")
+	l.WarningFile("README.md", "Failed to update section %s", "languages")
+	l.Error("request failed:\ncustom-secret-value rejected (100%%)")
+	l.EndGroup()
+	l.EndGroup()
+
+	return buf.String()
+}
+
+func TestTextFormatter(t *testing.T) {
+	output := logAll(&TextFormatter{})
+
+	for _, want := range []string{
+		"\n📊 Fetching data\n",
+		"[DEBUG] debug message",
+		"[INFO] info message",
+		"\n  ✅ Data fetched\n",
+		"[WARNING] README.md: Failed to update section languages",
+		"[ERROR] request failed:\n*** rejected (100%)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("出力に %q が含まれていません: %q", want, output)
+		}
+	}
+	if strings.Contains(output, "custom-secret-value") || strings.Contains(output, "::") {
+		t.Errorf("出力にシークレットまたはワークフローコマンドが含まれています: %q", output)
+	}
+}
+
+func TestJSONFormatter(t *testing.T) {
+	output := logAll(&JSONFormatter{})
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	var entries []jsonEntry
+	for _, line := range lines {
+		var entry jsonEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("JSON として解析できません: %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	want := []jsonEntry{
+		{Level: "INFO", Kind: "group", Message: "📊 Fetching data"},
+		{Level: "DEBUG", Kind: "log", Message: "debug message"},
+		{Level: "INFO", Kind: "log", Message: "info message"},
+		{Level: "INFO", Kind: "output", Message: "  ✅ Data fetched"},
+		{Level: "INFO", Kind: "group", Message: "🎨 Generating charts"},
+		{Level: "WARNING", Kind: "log", Message: "Failed to update section languages", File: "README.md"},
+		{Level: "ERROR", Kind: "log", Message: "request failed:\n*** rejected (100%)"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("エントリ数 = %d, want %d: %q", len(entries), len(want), output)
+	}
+	for i := range want {
+		entries[i].Time = ""
+		if entries[i] != want[i] {
+			t.Errorf("entries[%d] = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+}
+
+func TestActionsFormatter(t *testing.T) {
+	output := logAll(&ActionsFormatter{})
+
+	want := strings.Join([]string{
+		"::add-mask::custom-secret-value",
+		"::group::📊 Fetching data",
+		"::debug::debug message",
+		"info message",
+		"  ✅ Data fetched",
+		"::endgroup::",
+		"::group::🎨 Generating charts",
+		"::warning file=README.md::Failed to update section languages",
+		"::error::request failed:%0A*** rejected (100%25)",
+		"::endgroup::",
+	}, "\n") + "\n"
+	if output != want {
+		t.Errorf("出力 = %q, want %q", output, want)
+	}
+}
+
+func TestWorkflowCommandEscape(t *testing.T) {
+	got := workflowCommand("warning", "docs/a:b,c.md", "50% done\r\n")
+	want := "::warning file=docs/a%3Ab%2Cc.md::50%25 done%0D%0A"
+	if got != want {
+		t.Errorf("workflowCommand() = %q, want %q", got, want)
+	}
+}
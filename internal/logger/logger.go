@@ -3,8 +3,9 @@ package logger
 import (
 	"fmt"
 	"io"
-	"log"
 	"os"
+	"sync"
+	"time"
 )
 
 // LogLevel log level
@@ -15,26 +16,48 @@ const (
 	LogLevelInfo
 	LogLevelWarning
 	LogLevelError
+	LogLevelFatal
 )
 
 // Logger logger struct
 type Logger struct {
-	level  LogLevel
-	logger *log.Logger
+	level     LogLevel
+	output    io.Writer
+	formatter Formatter
+
+	mu        sync.Mutex
+	secrets   []string // Values masked in every message (see AddSecrets)
+	groupOpen bool     // Whether a group started by Group is open
+	warnings  []Entry  // Warnings written so far (see Warnings)
 }
 
-// NewLogger creates a new logger
+// NewLogger creates a new logger (text format)
 func NewLogger(level LogLevel, output io.Writer) *Logger {
 	if output == nil {
 		output = os.Stderr
 	}
 
 	return &Logger{
-		level:  level,
-		logger: log.New(output, "", log.LstdFlags),
+		level:     level,
+		output:    output,
+		formatter: &TextFormatter{},
 	}
 }
 
+// SetOutput sets the output destination
+func (l *Logger) SetOutput(output io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.output = output
+}
+
+// SetFormatter sets the output format
+func (l *Logger) SetFormatter(formatter Formatter) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.formatter = formatter
+}
+
 // SetLevel sets the log level
 func (l *Logger) SetLevel(level LogLevel) {
 	l.level = level
@@ -43,37 +66,103 @@ func (l *Logger) SetLevel(level LogLevel) {
 // Debug outputs debug level log
 func (l *Logger) Debug(format string, args ...interface{}) {
 	if l.level <= LogLevelDebug {
-		l.logger.Printf("[DEBUG] "+format, args...)
+		l.write(Entry{Kind: EntryLog, Level: LogLevelDebug, Message: fmt.Sprintf(format, args...)})
 	}
 }
 
 // Info outputs info level log
 func (l *Logger) Info(format string, args ...interface{}) {
 	if l.level <= LogLevelInfo {
-		l.logger.Printf("[INFO] "+format, args...)
+		l.write(Entry{Kind: EntryLog, Level: LogLevelInfo, Message: fmt.Sprintf(format, args...)})
 	}
 }
 
 // Warning outputs warning level log
 func (l *Logger) Warning(format string, args ...interface{}) {
 	if l.level <= LogLevelWarning {
-		l.logger.Printf("[WARNING] "+format, args...)
+		l.write(Entry{Kind: EntryLog, Level: LogLevelWarning, Message: fmt.Sprintf(format, args...)})
 	}
 }
 
 // Error outputs error level log
 func (l *Logger) Error(format string, args ...interface{}) {
 	if l.level <= LogLevelError {
-		l.logger.Printf("[ERROR] "+format, args...)
+		l.write(Entry{Kind: EntryLog, Level: LogLevelError, Message: fmt.Sprintf(format, args...)})
 	}
 }
 
 // Fatal outputs fatal error level log and exits
 func (l *Logger) Fatal(format string, args ...interface{}) {
-	l.logger.Printf("[FATAL] "+format, args...)
+	l.write(Entry{Kind: EntryLog, Level: LogLevelFatal, Message: fmt.Sprintf(format, args...)})
 	os.Exit(1)
 }
 
+// WarningFile outputs warning level log about a file (annotated on the file in GitHub Actions)
+func (l *Logger) WarningFile(file, format string, args ...interface{}) {
+	if l.level <= LogLevelWarning {
+		l.write(Entry{Kind: EntryLog, Level: LogLevelWarning, Message: fmt.Sprintf(format, args...), File: file})
+	}
+}
+
+// Print outputs a progress message for users
+// Printed regardless of the log level
+func (l *Logger) Print(format string, args ...interface{}) {
+	l.write(Entry{Kind: EntryOutput, Level: LogLevelInfo, Message: fmt.Sprintf(format, args...)})
+}
+
+// Group starts a group of output (a collapsible section in GitHub Actions)
+// The previous group is ended if it is still open
+func (l *Logger) Group(title string) {
+	l.EndGroup()
+
+	l.mu.Lock()
+	l.groupOpen = true
+	l.mu.Unlock()
+
+	l.write(Entry{Kind: EntryGroupStart, Level: LogLevelInfo, Message: title})
+}
+
+// EndGroup ends the group started by Group (does nothing if no group is open)
+func (l *Logger) EndGroup() {
+	l.mu.Lock()
+	open := l.groupOpen
+	l.groupOpen = false
+	l.mu.Unlock()
+
+	if open {
+		l.write(Entry{Kind: EntryGroupEnd, Level: LogLevelInfo})
+	}
+}
+
+// write formats an entry with secrets redacted and writes it
+func (l *Logger) write(entry Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry.Time = time.Now()
+	if entry.Kind != EntryMask {
+		entry.Message = RedactText(entry.Message, l.secrets...)
+		entry.File = RedactText(entry.File, l.secrets...)
+	}
+
+	if entry.Kind == EntryLog && entry.Level == LogLevelWarning {
+		l.warnings = append(l.warnings, entry)
+	}
+
+	if line := l.formatter.Format(entry); line != "" {
+		fmt.Fprintln(l.output, line)
+	}
+}
+
+// Warnings returns the warnings written so far (secrets redacted)
+// Used to report warnings at the end of a run (e.g. in the GitHub Actions job summary)
+func (l *Logger) Warnings() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]Entry(nil), l.warnings...)
+}
+
 // ParseLogLevel parses LogLevel from string
 func ParseLogLevel(levelStr string) LogLevel {
 	switch levelStr {
@@ -101,6 +190,8 @@ func (l LogLevel) String() string {
 		return "WARNING"
 	case LogLevelError:
 		return "ERROR"
+	case LogLevelFatal:
+		return "FATAL"
 	default:
 		return "UNKNOWN"
 	}
@@ -130,6 +221,26 @@ func Fatal(format string, args ...interface{}) {
 	DefaultLogger.Fatal(format, args...)
 }
 
+func WarningFile(file, format string, args ...interface{}) {
+	DefaultLogger.WarningFile(file, format, args...)
+}
+
+func Print(format string, args ...interface{}) {
+	DefaultLogger.Print(format, args...)
+}
+
+func Group(title string) {
+	DefaultLogger.Group(title)
+}
+
+func EndGroup() {
+	DefaultLogger.EndGroup()
+}
+
+func Warnings() []Entry {
+	return DefaultLogger.Warnings()
+}
+
 // LogError logs an error (does nothing if error is nil)
 func LogError(err error, format string, args ...interface{}) error {
 	if err != nil {
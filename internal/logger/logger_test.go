@@ -234,3 +234,26 @@ func TestLogError_Nil(t *testing.T) {
 		t.Errorf("LogError() nil エラーの場合はログを出力すべきではありませんでした")
 	}
 }
+
+func TestLogger_Warnings(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(LogLevelError, &buf)
+	logger.AddSecrets("custom-secret-value")
+
+	logger.Info("info")
+	logger.Warning("filtered warning")
+	logger.SetLevel(LogLevelInfo)
+	logger.Warning("first warning: custom-secret-value")
+	logger.WarningFile("README.md", "second warning")
+
+	warnings := logger.Warnings()
+	if len(warnings) != 2 {
+		t.Fatalf("Warnings() = %d 件, want 2", len(warnings))
+	}
+	if warnings[0].Message != "first warning: ***" {
+		t.Errorf("Warnings()[0].Message = %q, want %q", warnings[0].Message, "first warning: ***")
+	}
+	if warnings[1].File != "README.md" || warnings[1].Message != "second warning" {
+		t.Errorf("Warnings()[1] = %+v, want README.md: second warning", warnings[1])
+	}
+}
@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// giteaPageSize page size of Gitea list requests (default maximum of Gitea and Forgejo)
+const giteaPageSize = 50
+
+// giteaMaxCommitPages maximum commit pages fetched per repository
+const giteaMaxCommitPages = 20
+
+// Gitea provider backed by the Gitea API (v1), also served by Forgejo
+type Gitea struct {
+	restClient
+	commits map[string][]giteaCommit // Commits of the past year fetched by Repositories (by "owner/name")
+	repos   map[string]Repository    // Repositories fetched by Repositories (by "owner/name")
+}
+
+// giteaRepository repository returned by the Gitea API
+type giteaRepository struct {
+	Name  string `json:"name"`
+	Owner struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+	Fork          bool   `json:"fork"`
+	Empty         bool   `json:"empty"`
+	StarsCount    int    `json:"stars_count"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+// giteaCommit commit returned by the Gitea API
+type giteaCommit struct {
+	Commit struct {
+		Author struct {
+			Date time.Time `json:"date"`
+		} `json:"author"`
+		Committer struct {
+			Date time.Time `json:"date"`
+		} `json:"committer"`
+	} `json:"commit"`
+	Author *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+}
+
+// date returns the commit date (the author date if the commit date is missing)
+func (c giteaCommit) date() time.Time {
+	if !c.Commit.Committer.Date.IsZero() {
+		return c.Commit.Committer.Date
+	}
+	return c.Commit.Author.Date
+}
+
+// NewGitea creates a Gitea (or Forgejo) provider
+//
+// Preconditions:
+// - serverURL is the server URL (e.g. "https://forge.example.com") or its API URL ("https://forge.example.com/api/v1")
+// - httpClient sends the Gitea token (e.g. repository.ClientConfig.HTTPClient)
+//
+// Postconditions:
+// - Returns a provider fetching data from the API of serverURL
+func NewGitea(serverURL string, httpClient *http.Client) *Gitea {
+	baseURL := strings.TrimSuffix(serverURL, "/")
+	if !strings.HasSuffix(baseURL, "/api/v1") {
+		baseURL += "/api/v1"
+	}
+	return &Gitea{
+		restClient: restClient{name: "Gitea", baseURL: baseURL, client: httpClient},
+		commits:    make(map[string][]giteaCommit),
+		repos:      make(map[string]Repository),
+	}
+}
+
+// Name returns "gitea"
+func (g *Gitea) Name() string {
+	return "gitea"
+}
+
+// Viewer returns the authenticated user
+func (g *Gitea) Viewer(ctx context.Context) (User, error) {
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+	}
+	if _, err := g.get(ctx, "/user", nil, &user); err != nil {
+		return User{}, err
+	}
+	return User{Login: user.Login, ID: strconv.FormatInt(user.ID, 10)}, nil
+}
+
+// Repositories returns the repositories owned by the user
+// Commits of the past year on the default branch are fetched and kept for CommitTimestamps and CommitLanguages
+func (g *Gitea) Repositories(ctx context.Context, user User, excludeForks bool) ([]Repository, error) {
+	giteaRepos, err := giteaPages[giteaRepository](ctx, g, "/user/repos", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().AddDate(-1, 0, 0)
+	var repos []Repository
+	for _, giteaRepo := range giteaRepos {
+		// /user/repos also lists repositories of organizations and collaborations
+		if !strings.EqualFold(giteaRepo.Owner.Login, user.Login) || (excludeForks && giteaRepo.Fork) {
+			continue
+		}
+
+		path := "/repos/" + url.PathEscape(giteaRepo.Owner.Login) + "/" + url.PathEscape(giteaRepo.Name)
+		repo := Repository{
+			Host:  webHost(g.baseURL),
+			Owner: giteaRepo.Owner.Login,
+			Name:  giteaRepo.Name,
+			Stars: giteaRepo.StarsCount,
+		}
+
+		if _, err := g.get(ctx, path+"/languages", nil, &repo.Languages); err != nil {
+			return nil, err
+		}
+
+		if !giteaRepo.Empty && giteaRepo.DefaultBranch != "" {
+			commits, total, err := g.recentCommits(ctx, path, giteaRepo.DefaultBranch, since)
+			if err != nil {
+				return nil, err
+			}
+			repo.CommitCount = total
+			for _, commit := range commits {
+				repo.CommitDates = append(repo.CommitDates, commit.date())
+			}
+			g.commits[repo.Owner+"/"+repo.Name] = commits
+		}
+
+		g.repos[repo.Owner+"/"+repo.Name] = repo
+		repos = append(repos, repo)
+	}
+
+	return repos, nil
+}
+
+// Contributions returns the number of pull requests opened by the user
+func (g *Gitea) Contributions(ctx context.Context, user User) (Contributions, error) {
+	query := url.Values{}
+	query.Set("type", "pulls")
+	query.Set("state", "all")
+	query.Set("created", "true")
+	query.Set("limit", "1")
+
+	var pulls []json.RawMessage
+	header, err := g.get(ctx, "/repos/issues/search", query, &pulls)
+	if err != nil {
+		return Contributions{}, err
+	}
+	if total, err := strconv.Atoi(header.Get("X-Total-Count")); err == nil {
+		return Contributions{PullRequests: total}, nil
+	}
+
+	query.Del("limit")
+	all, err := giteaPages[json.RawMessage](ctx, g, "/repos/issues/search", query)
+	if err != nil {
+		return Contributions{}, err
+	}
+	return Contributions{PullRequests: len(all)}, nil
+}
+
+// CommitTimestamps returns the timestamps of commits authored by the user in the owned repositories
+// Repositories must be called first (commits are not fetched again)
+func (g *Gitea) CommitTimestamps(ctx context.Context, user User, since, until time.Time) ([]time.Time, error) {
+	var timestamps []time.Time
+	for _, commits := range g.commits {
+		for _, commit := range commits {
+			date := commit.date()
+			if isGiteaAuthor(commit, user) && !date.Before(since) && !date.After(until) {
+				timestamps = append(timestamps, date)
+			}
+		}
+	}
+	return timestamps, nil
+}
+
+// CommitLanguages returns the language weights of commits authored by the user in the owned repositories
+// Each commit is weighted by the languages of its repository, like the GitHub provider
+func (g *Gitea) CommitLanguages(ctx context.Context, user User) (map[string]map[string]int, error) {
+	commitLanguages := make(map[string]map[string]int)
+	for key, commits := range g.commits {
+		languages := g.repos[key].Languages
+		if len(languages) == 0 {
+			continue
+		}
+
+		for _, commit := range commits {
+			if !isGiteaAuthor(commit, user) {
+				continue
+			}
+
+			commitKey := commit.date().UTC().Format(time.RFC3339)
+			if commitLanguages[commitKey] == nil {
+				commitLanguages[commitKey] = make(map[string]int)
+			}
+			for lang, size := range languages {
+				weight := 1
+				if size > 1000 {
+					weight = 2
+				}
+				commitLanguages[commitKey][lang] += weight
+			}
+		}
+	}
+	return commitLanguages, nil
+}
+
+// recentCommits returns the commits on branch since the given time and the total number of commits on branch
+func (g *Gitea) recentCommits(ctx context.Context, path, branch string, since time.Time) ([]giteaCommit, int, error) {
+	query := url.Values{}
+	query.Set("sha", branch)
+	query.Set("stat", "false")
+	query.Set("verification", "false")
+	query.Set("files", "false")
+	query.Set("limit", strconv.Itoa(giteaPageSize))
+
+	var recent []giteaCommit
+	total, listed := 0, 0
+	for page := 1; page <= giteaMaxCommitPages; page++ {
+		query.Set("page", strconv.Itoa(page))
+		var commits []giteaCommit
+		header, err := g.get(ctx, path+"/commits", query, &commits)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to fetch commits of %s: %w", strings.TrimPrefix(path, "/repos/"), err)
+		}
+		if page == 1 {
+			total, _ = strconv.Atoi(header.Get("X-Total-Count"))
+		}
+		listed += len(commits)
+
+		// Commits are listed newest first
+		reachedSince := false
+		for _, commit := range commits {
+			if commit.date().Before(since) {
+				reachedSince = true
+				break
+			}
+			recent = append(recent, commit)
+		}
+		if reachedSince || !giteaHasNextPage(header, len(commits), listed) {
+			break
+		}
+	}
+
+	if total == 0 {
+		total = len(recent)
+	}
+	return recent, total, nil
+}
+
+// isGiteaAuthor reports whether the commit is authored by the user
+// Commits whose author email is not linked to an account have no author login and are attributed to the repository owner
+func isGiteaAuthor(commit giteaCommit, user User) bool {
+	if commit.Author == nil || commit.Author.Login == "" {
+		return true
+	}
+	return strings.EqualFold(commit.Author.Login, user.Login)
+}
+
+// giteaPages fetches all pages of a list endpoint, following the Link and X-Total-Count headers
+func giteaPages[T any](ctx context.Context, g *Gitea, path string, query url.Values) ([]T, error) {
+	pageQuery := url.Values{}
+	for key, values := range query {
+		pageQuery[key] = values
+	}
+	pageQuery.Set("limit", strconv.Itoa(giteaPageSize))
+
+	var all []T
+	for page := 1; ; page++ {
+		pageQuery.Set("page", strconv.Itoa(page))
+		var items []T
+		header, err := g.get(ctx, path, pageQuery, &items)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		if !giteaHasNextPage(header, len(items), len(all)) {
+			break
+		}
+	}
+	return all, nil
+}
+
+// giteaHasNextPage reports whether another page follows a page of items, after listed items in total
+// A short page is not the last one: servers cap the page size at [api] MAX_RESPONSE_ITEMS, which may be below giteaPageSize
+func giteaHasNextPage(header http.Header, items, listed int) bool {
+	if items == 0 {
+		return false
+	}
+	if link := header.Get("Link"); link != "" {
+		return strings.Contains(link, `rel="next"`)
+	}
+	if total, err := strconv.Atoi(header.Get("X-Total-Count")); err == nil {
+		return listed < total
+	}
+	// Without pagination headers, read until an empty page
+	return true
+}
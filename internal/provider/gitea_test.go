@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/repository"
+)
+
+// newFakeGitea starts a fake Gitea API server
+func newFakeGitea(t *testing.T, now time.Time) *httptest.Server {
+	t.Helper()
+
+	commit := func(date time.Time, login string) map[string]any {
+		c := map[string]any{"commit": map[string]any{"committer": map[string]any{"date": date}}}
+		if login != "" {
+			c["author"] = map[string]any{"login": login}
+		}
+		return c
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer gitea-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": 7, "login": "octo"})
+	})
+	mux.HandleFunc("/api/v1/user/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Total-Count", "3")
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"name": "app", "owner": map[string]any{"login": "octo"}, "stars_count": 2, "default_branch": "main"},
+			{"name": "fork", "owner": map[string]any{"login": "octo"}, "fork": true, "empty": true},
+			{"name": "shared", "owner": map[string]any{"login": "team"}, "default_branch": "main"},
+		})
+	})
+	mux.HandleFunc("/api/v1/repos/octo/app/languages", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]int{"Go": 4000, "Makefile": 200})
+	})
+	mux.HandleFunc("/api/v1/repos/octo/fork/languages", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]int{})
+	})
+	mux.HandleFunc("/api/v1/repos/octo/app/commits", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sha") != "main" {
+			t.Errorf("sha = %q, want main", r.URL.Query().Get("sha"))
+		}
+		w.Header().Set("X-Total-Count", "40")
+		json.NewEncoder(w).Encode([]map[string]any{
+			commit(now.Add(-time.Hour), "octo"),
+			commit(now.Add(-2*time.Hour), "someone"),
+			commit(now.Add(-3*time.Hour), ""),
+			commit(now.AddDate(-2, 0, 0), "octo"),
+		})
+	})
+	mux.HandleFunc("/api/v1/repos/issues/search", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("type") != "pulls" || r.URL.Query().Get("created") != "true" {
+			t.Errorf("query = %s, want created pull requests", r.URL.RawQuery)
+		}
+		w.Header().Set("X-Total-Count", "6")
+		json.NewEncoder(w).Encode([]map[string]any{{"id": 1}})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestGitea verifies that repositories, commits and pull requests are mapped into provider data
+func TestGitea(t *testing.T) {
+	now := time.Now()
+	server := newFakeGitea(t, now)
+	httpClient, err := repository.ClientConfig{Token: "gitea-token"}.HTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gitea := NewGitea(server.URL+"/", httpClient)
+	ctx := context.Background()
+
+	user, err := gitea.Viewer(ctx)
+	if err != nil {
+		t.Fatalf("Viewer() error = %v", err)
+	}
+	if user.Login != "octo" || user.ID != "7" {
+		t.Errorf("Viewer() = %+v, want octo (7)", user)
+	}
+
+	data, err := Collect(ctx, gitea, user, Options{ExcludeForks: true})
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if len(data.Repositories) != 1 || data.Repositories[0].Name != "app" || data.Repositories[0].Stars != 2 {
+		t.Fatalf("Repositories = %+v, want only octo/app (forks and other owners excluded)", data.Repositories)
+	}
+	if data.Repositories[0].Host != "127.0.0.1" {
+		t.Errorf("Host = %q, want the host of the server", data.Repositories[0].Host)
+	}
+	if data.LanguageTotals["Go"] != 4000 || data.LanguageTotals["Makefile"] != 200 {
+		t.Errorf("LanguageTotals = %v, want Go=4000, Makefile=200", data.LanguageTotals)
+	}
+	if data.TotalCommits != 40 {
+		t.Errorf("TotalCommits = %d, want 40 (X-Total-Count)", data.TotalCommits)
+	}
+	if data.TotalPullRequests != 6 {
+		t.Errorf("TotalPullRequests = %d, want 6", data.TotalPullRequests)
+	}
+
+	history := 0
+	for _, count := range data.CommitHistories["127.0.0.1/octo/app"] {
+		history += count
+	}
+	if history != 3 {
+		t.Errorf("commit history has %d commits, want 3 (past year only)", history)
+	}
+
+	hours := 0
+	for _, count := range data.TimeDistributions["gitea"] {
+		hours += count
+	}
+	if hours != 2 {
+		t.Errorf("time distribution has %d commits, want 2 (commits of other users excluded)", hours)
+	}
+	if len(data.CommitLanguages) != 2 {
+		t.Errorf("CommitLanguages has %d commits, want 2", len(data.CommitLanguages))
+	}
+	for _, langs := range data.CommitLanguages {
+		if langs["Go"] != 2 || langs["Makefile"] != 1 {
+			t.Errorf("languages = %v, want Go=2, Makefile=1", langs)
+		}
+	}
+}
+
+// TestNewGitea verifies that the API path is appended to server URLs only
+func TestNewGitea(t *testing.T) {
+	for _, serverURL := range []string{"https://forge.example.com", "https://forge.example.com/", "https://forge.example.com/api/v1/"} {
+		if got := NewGitea(serverURL, http.DefaultClient).baseURL; got != "https://forge.example.com/api/v1" {
+			t.Errorf("NewGitea(%q) base URL = %q, want https://forge.example.com/api/v1", serverURL, got)
+		}
+	}
+}
+
+// TestGitea_ShortPages verifies that pagination continues when the server returns fewer items than requested (MAX_RESPONSE_ITEMS)
+func TestGitea_ShortPages(t *testing.T) {
+	const serverPageSize = 2
+	now := time.Now()
+
+	// paginate writes a page of items capped at serverPageSize, with X-Total-Count and (if link) a Link header
+	paginate := func(w http.ResponseWriter, r *http.Request, items []map[string]any, link bool) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		start := min((page-1)*serverPageSize, len(items))
+		end := min(start+serverPageSize, len(items))
+		w.Header().Set("X-Total-Count", strconv.Itoa(len(items)))
+		if link && end < len(items) {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=%d>; rel="next"`, r.URL.Path, page+1))
+		}
+		json.NewEncoder(w).Encode(items[start:end])
+	}
+
+	var repos, commits []map[string]any
+	for i := range 5 {
+		repos = append(repos, map[string]any{"name": "repo" + strconv.Itoa(i), "owner": map[string]any{"login": "octo"}})
+		commits = append(commits, map[string]any{"commit": map[string]any{"committer": map[string]any{"date": now.Add(-time.Duration(i+1) * time.Hour)}}})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/user/repos", func(w http.ResponseWriter, r *http.Request) {
+		paginate(w, r, repos, true)
+	})
+	mux.HandleFunc("/api/v1/repos/octo/repo0/commits", func(w http.ResponseWriter, r *http.Request) {
+		paginate(w, r, commits, false)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	gitea := NewGitea(server.URL, server.Client())
+	ctx := context.Background()
+
+	listed, err := giteaPages[giteaRepository](ctx, gitea, "/user/repos", nil)
+	if err != nil {
+		t.Fatalf("giteaPages() error = %v", err)
+	}
+	if len(listed) != len(repos) {
+		t.Errorf("giteaPages() = %d repositories, want %d (Link header)", len(listed), len(repos))
+	}
+
+	recent, total, err := gitea.recentCommits(ctx, "/repos/octo/repo0", "main", now.AddDate(-1, 0, 0))
+	if err != nil {
+		t.Fatalf("recentCommits() error = %v", err)
+	}
+	if len(recent) != len(commits) || total != len(commits) {
+		t.Errorf("recentCommits() = %d commits (total %d), want %d (X-Total-Count)", len(recent), total, len(commits))
+	}
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/repository"
+)
+
+// GitHub provider backed by the GitHub GraphQL API
+type GitHub struct {
+	api repository.ClientConfig
+}
+
+// NewGitHub creates a GitHub provider
+//
+// Preconditions:
+// - api has a token (see repository.ClientConfig)
+//
+// Postconditions:
+// - Returns a provider fetching data from the GraphQL API of api
+func NewGitHub(api repository.ClientConfig) *GitHub {
+	return &GitHub{api: api}
+}
+
+// Name returns "github"
+func (g *GitHub) Name() string {
+	return "github"
+}
+
+// Viewer returns the authenticated user
+func (g *GitHub) Viewer(ctx context.Context) (User, error) {
+	login, id, err := repository.FetchViewerGenerated(ctx, g.api)
+	if err != nil {
+		return User{}, err
+	}
+	return User{Login: login, ID: id}, nil
+}
+
+// Repositories returns the repositories owned by the user
+func (g *GitHub) Repositories(ctx context.Context, user User, excludeForks bool) ([]Repository, error) {
+	repoGraphQLData, err := repository.FetchRepositoriesWithGraphQLGenerated(ctx, g.api, user.Login, excludeForks)
+	if err != nil {
+		return nil, err
+	}
+
+	restURL, _ := g.api.Endpoints()
+	repos := make([]Repository, 0, len(repoGraphQLData))
+	for _, repoData := range repoGraphQLData {
+		repo := Repository{
+			Host:        webHost(restURL),
+			Owner:       repoData.Owner.Login,
+			Name:        repoData.Name,
+			Stars:       repoData.StargazerCount,
+			Languages:   make(map[string]int, len(repoData.Languages.Nodes)),
+			CommitCount: repoData.DefaultBranchRef.Target.History.TotalCount,
+		}
+		for _, lang := range repoData.Languages.Nodes {
+			repo.Languages[lang.Name] += lang.Size
+		}
+		for _, commit := range repoData.DefaultBranchRef.Target.History.Nodes {
+			// Use the author date if the commit date is missing
+			date := commit.CommittedDate
+			if date == "" {
+				date = commit.Author.Date
+			}
+			if t, err := time.Parse(time.RFC3339, date); err == nil {
+				repo.CommitDates = append(repo.CommitDates, t)
+			}
+		}
+		repos = append(repos, repo)
+	}
+
+	return repos, nil
+}
+
+// Contributions returns the number of pull requests opened by the user
+func (g *GitHub) Contributions(ctx context.Context, user User) (Contributions, error) {
+	userDetails, err := repository.FetchUserDetailsWithGraphQLGenerated(ctx, g.api, user.Login)
+	if err != nil {
+		return Contributions{}, err
+	}
+	return Contributions{PullRequests: userDetails.PullRequests.TotalCount}, nil
+}
+
+// CommitTimestamps returns the timestamps of commits contributed by the user
+func (g *GitHub) CommitTimestamps(ctx context.Context, user User, since, until time.Time) ([]time.Time, error) {
+	return repository.FetchCommitTimestampsWithGraphQL(ctx, g.api, user.Login, user.ID, since, until)
+}
+
+// CommitLanguages returns the language weights of commits contributed by the user
+func (g *GitHub) CommitLanguages(ctx context.Context, user User) (map[string]map[string]int, error) {
+	return repository.FetchCommitLanguagesWithGraphQL(ctx, g.api, user.Login)
+}
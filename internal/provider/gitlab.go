@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultGitLabURL REST API base URL of gitlab.com
+const DefaultGitLabURL = "https://gitlab.com/api/v4"
+
+// gitlabPerPage page size of GitLab list requests (maximum allowed by the API)
+const gitlabPerPage = 100
+
+// GitLab provider backed by the GitLab REST API (v4)
+type GitLab struct {
+	restClient
+	projects  map[int]gitlabProject  // Projects fetched by Repositories (by project ID)
+	languages map[int]map[string]int // Language bytes per project (by project ID)
+}
+
+// gitlabProject project returned by the GitLab API
+type gitlabProject struct {
+	ID        int    `json:"id"`
+	Path      string `json:"path"`
+	Namespace struct {
+		FullPath string `json:"full_path"`
+	} `json:"namespace"`
+	StarCount         int             `json:"star_count"`
+	DefaultBranch     string          `json:"default_branch"`
+	ForkedFromProject json.RawMessage `json:"forked_from_project"`
+	Statistics        struct {
+		CommitCount    int   `json:"commit_count"`
+		RepositorySize int64 `json:"repository_size"`
+	} `json:"statistics"`
+}
+
+// gitlabEvent push event returned by the GitLab API
+type gitlabEvent struct {
+	ProjectID int       `json:"project_id"`
+	CreatedAt time.Time `json:"created_at"`
+	PushData  *struct {
+		CommitCount int `json:"commit_count"`
+	} `json:"push_data"`
+}
+
+// NewGitLab creates a GitLab provider
+//
+// Preconditions:
+// - baseURL is empty or the REST API base URL (e.g. "https://gitlab.example.com/api/v4")
+// - httpClient sends the GitLab token (e.g. repository.ClientConfig.HTTPClient)
+//
+// Postconditions:
+// - Returns a provider fetching data from baseURL (DefaultGitLabURL if empty)
+func NewGitLab(baseURL string, httpClient *http.Client) *GitLab {
+	if baseURL == "" {
+		baseURL = DefaultGitLabURL
+	}
+	return &GitLab{
+		restClient: restClient{name: "GitLab", baseURL: strings.TrimSuffix(baseURL, "/"), client: httpClient},
+		projects:   make(map[int]gitlabProject),
+		languages:  make(map[int]map[string]int),
+	}
+}
+
+// Name returns "gitlab"
+func (g *GitLab) Name() string {
+	return "gitlab"
+}
+
+// Viewer returns the authenticated user
+func (g *GitLab) Viewer(ctx context.Context) (User, error) {
+	var user struct {
+		ID       int    `json:"id"`
+		Username string `json:"username"`
+	}
+	if _, err := g.get(ctx, "/user", nil, &user); err != nil {
+		return User{}, err
+	}
+	return User{Login: user.Username, ID: strconv.Itoa(user.ID)}, nil
+}
+
+// Repositories returns the projects owned by the user
+// The language breakdown is converted from percentages to bytes using the repository size
+func (g *GitLab) Repositories(ctx context.Context, user User, excludeForks bool) ([]Repository, error) {
+	query := url.Values{}
+	query.Set("owned", "true")
+	query.Set("statistics", "true")
+	query.Set("order_by", "last_activity_at")
+	projects, err := getPages[gitlabProject](ctx, g, "/projects", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []Repository
+	for _, project := range projects {
+		isFork := len(project.ForkedFromProject) > 0 && string(project.ForkedFromProject) != "null"
+		if excludeForks && isFork {
+			continue
+		}
+		g.projects[project.ID] = project
+
+		languages, err := g.projectLanguages(ctx, project.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		repo := Repository{
+			Host:        webHost(g.baseURL),
+			Owner:       project.Namespace.FullPath,
+			Name:        project.Path,
+			Stars:       project.StarCount,
+			Languages:   languages,
+			CommitCount: project.Statistics.CommitCount,
+		}
+
+		// Recent commits on the default branch (empty projects have no default branch)
+		if project.DefaultBranch != "" {
+			var commits []struct {
+				CommittedDate time.Time `json:"committed_date"`
+			}
+			commitQuery := url.Values{}
+			commitQuery.Set("ref_name", project.DefaultBranch)
+			commitQuery.Set("per_page", strconv.Itoa(gitlabPerPage))
+			if _, err := g.get(ctx, fmt.Sprintf("/projects/%d/repository/commits", project.ID), commitQuery, &commits); err != nil {
+				return nil, err
+			}
+			for _, commit := range commits {
+				repo.CommitDates = append(repo.CommitDates, commit.CommittedDate)
+			}
+		}
+
+		repos = append(repos, repo)
+	}
+
+	return repos, nil
+}
+
+// Contributions returns the number of merge requests opened by the user
+func (g *GitLab) Contributions(ctx context.Context, user User) (Contributions, error) {
+	query := url.Values{}
+	query.Set("scope", "created_by_me")
+	query.Set("state", "all")
+	query.Set("per_page", "1")
+
+	var mergeRequests []json.RawMessage
+	header, err := g.get(ctx, "/merge_requests", query, &mergeRequests)
+	if err != nil {
+		return Contributions{}, err
+	}
+
+	// GitLab omits X-Total for large result sets
+	if total, err := strconv.Atoi(header.Get("X-Total")); err == nil {
+		return Contributions{PullRequests: total}, nil
+	}
+	query.Del("per_page")
+	all, err := getPages[json.RawMessage](ctx, g, "/merge_requests", query)
+	if err != nil {
+		return Contributions{}, err
+	}
+	return Contributions{PullRequests: len(all)}, nil
+}
+
+// CommitTimestamps returns the timestamps of commits pushed by the user
+// Every commit of a push is dated at the time of the push
+func (g *GitLab) CommitTimestamps(ctx context.Context, user User, since, until time.Time) ([]time.Time, error) {
+	events, err := g.pushEvents(ctx, user, since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	var timestamps []time.Time
+	for _, event := range events {
+		for i := 0; i < event.PushData.CommitCount; i++ {
+			timestamps = append(timestamps, event.CreatedAt)
+		}
+	}
+	return timestamps, nil
+}
+
+// CommitLanguages returns the language weights of commits pushed by the user in the past year
+// Each push is weighted by the languages of its project, like the GitHub provider
+func (g *GitLab) CommitLanguages(ctx context.Context, user User) (map[string]map[string]int, error) {
+	until := time.Now()
+	events, err := g.pushEvents(ctx, user, until.AddDate(-1, 0, 0), until)
+	if err != nil {
+		return nil, err
+	}
+
+	commitLanguages := make(map[string]map[string]int)
+	for _, event := range events {
+		languages, err := g.projectLanguages(ctx, event.ProjectID)
+		if err != nil || len(languages) == 0 {
+			// Projects may have been deleted or made inaccessible since the push
+			continue
+		}
+
+		commitKey := event.CreatedAt.UTC().Format(time.RFC3339)
+		if commitLanguages[commitKey] == nil {
+			commitLanguages[commitKey] = make(map[string]int)
+		}
+		for lang, size := range languages {
+			weight := 1
+			if size > 1000 {
+				weight = 2
+			}
+			commitLanguages[commitKey][lang] += weight * event.PushData.CommitCount
+		}
+	}
+	return commitLanguages, nil
+}
+
+// pushEvents returns the push events of the user in [since, until]
+func (g *GitLab) pushEvents(ctx context.Context, user User, since, until time.Time) ([]gitlabEvent, error) {
+	// after and before are exclusive dates
+	query := url.Values{}
+	query.Set("action", "pushed")
+	query.Set("after", since.AddDate(0, 0, -1).Format("2006-01-02"))
+	query.Set("before", until.AddDate(0, 0, 1).Format("2006-01-02"))
+	events, err := getPages[gitlabEvent](ctx, g, "/users/"+url.PathEscape(user.ID)+"/events", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var pushes []gitlabEvent
+	for _, event := range events {
+		if event.PushData == nil || event.CreatedAt.Before(since) || event.CreatedAt.After(until) {
+			continue
+		}
+		pushes = append(pushes, event)
+	}
+	return pushes, nil
+}
+
+// projectLanguages returns the language breakdown of a project in bytes (cached)
+func (g *GitLab) projectLanguages(ctx context.Context, projectID int) (map[string]int, error) {
+	if languages, ok := g.languages[projectID]; ok {
+		return languages, nil
+	}
+
+	var percentages map[string]float64
+	if _, err := g.get(ctx, fmt.Sprintf("/projects/%d/languages", projectID), nil, &percentages); err != nil {
+		return nil, err
+	}
+
+	// Without the repository size, keep the percentages as relative weights
+	size := float64(g.projects[projectID].Statistics.RepositorySize)
+	if size <= 0 {
+		size = 10000
+	}
+	languages := make(map[string]int, len(percentages))
+	for lang, percentage := range percentages {
+		languages[lang] = int(math.Round(percentage / 100 * size))
+	}
+
+	g.languages[projectID] = languages
+	return languages, nil
+}
+
+// getPages fetches all pages of a list endpoint, following the X-Next-Page header
+func getPages[T any](ctx context.Context, g *GitLab, path string, query url.Values) ([]T, error) {
+	pageQuery := url.Values{}
+	for key, values := range query {
+		pageQuery[key] = values
+	}
+	pageQuery.Set("per_page", strconv.Itoa(gitlabPerPage))
+
+	var all []T
+	page := "1"
+	for page != "" {
+		pageQuery.Set("page", page)
+		var items []T
+		header, err := g.get(ctx, path, pageQuery, &items)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		if len(items) == 0 {
+			break
+		}
+		page = header.Get("X-Next-Page")
+	}
+	return all, nil
+}
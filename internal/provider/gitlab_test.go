@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/repository"
+)
+
+// newFakeGitLab starts a fake GitLab API server
+func newFakeGitLab(t *testing.T, now time.Time) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer gl-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": 42, "username": "octo"})
+	})
+	mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("owned") != "true" {
+			t.Errorf("owned = %q, want true", r.URL.Query().Get("owned"))
+		}
+		// Two pages: the second page holds the fork
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("X-Next-Page", "2")
+			json.NewEncoder(w).Encode([]map[string]any{{
+				"id": 1, "path": "app", "namespace": map[string]any{"full_path": "octo"},
+				"star_count": 5, "default_branch": "main",
+				"statistics": map[string]any{"commit_count": 12, "repository_size": 2000},
+			}})
+			return
+		}
+		json.NewEncoder(w).Encode([]map[string]any{{
+			"id": 2, "path": "fork", "namespace": map[string]any{"full_path": "octo"},
+			"forked_from_project": map[string]any{"id": 99},
+			"statistics":          map[string]any{"commit_count": 3},
+		}})
+	})
+	mux.HandleFunc("/api/v4/projects/1/languages", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]float64{"Go": 75, "Shell": 25})
+	})
+	mux.HandleFunc("/api/v4/projects/2/languages", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]float64{"C": 100})
+	})
+	mux.HandleFunc("/api/v4/projects/1/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ref_name") != "main" {
+			t.Errorf("ref_name = %q, want main", r.URL.Query().Get("ref_name"))
+		}
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"committed_date": "2025-03-01T09:00:00Z"},
+			{"committed_date": "2025-03-01T15:00:00+09:00"},
+		})
+	})
+	mux.HandleFunc("/api/v4/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Total", "8")
+		json.NewEncoder(w).Encode([]map[string]any{{"id": 1}})
+	})
+	mux.HandleFunc("/api/v4/users/42/events", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("action") != "pushed" {
+			t.Errorf("action = %q, want pushed", r.URL.Query().Get("action"))
+		}
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"project_id": 1, "created_at": now.Add(-time.Hour), "push_data": map[string]any{"commit_count": 2}},
+			{"project_id": 404, "created_at": now.Add(-2 * time.Hour), "push_data": map[string]any{"commit_count": 1}},
+			{"project_id": 1, "created_at": now.AddDate(-2, 0, 0), "push_data": map[string]any{"commit_count": 5}},
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestGitLab creates a GitLab provider connected to the fake server
+func newTestGitLab(t *testing.T, server *httptest.Server) *GitLab {
+	t.Helper()
+
+	httpClient, err := repository.ClientConfig{Token: "gl-token"}.HTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewGitLab(server.URL+"/api/v4/", httpClient)
+}
+
+// TestGitLab_Repositories verifies that projects are converted with languages in bytes and recent commits
+func TestGitLab_Repositories(t *testing.T) {
+	server := newFakeGitLab(t, time.Now())
+	gitlab := newTestGitLab(t, server)
+	ctx := context.Background()
+
+	user, err := gitlab.Viewer(ctx)
+	if err != nil {
+		t.Fatalf("Viewer() error = %v", err)
+	}
+	if user.Login != "octo" || user.ID != "42" {
+		t.Errorf("Viewer() = %+v, want octo (42)", user)
+	}
+
+	repos, err := gitlab.Repositories(ctx, user, false)
+	if err != nil {
+		t.Fatalf("Repositories() error = %v", err)
+	}
+	if len(repos) != 2 {
+		t.Fatalf("Repositories() returned %d projects, want 2 (all pages)", len(repos))
+	}
+
+	app := repos[0]
+	if app.Owner != "octo" || app.Name != "app" || app.Stars != 5 || app.CommitCount != 12 {
+		t.Errorf("project = %+v, want octo/app with 5 stars and 12 commits", app)
+	}
+	if app.Languages["Go"] != 1500 || app.Languages["Shell"] != 500 {
+		t.Errorf("Languages = %v, want Go=1500, Shell=500", app.Languages)
+	}
+	if len(app.CommitDates) != 2 || app.CommitDates[1].UTC().Hour() != 6 {
+		t.Errorf("CommitDates = %v, want two dates", app.CommitDates)
+	}
+
+	repos, err = gitlab.Repositories(ctx, user, true)
+	if err != nil {
+		t.Fatalf("Repositories() error = %v", err)
+	}
+	if len(repos) != 1 || repos[0].Name != "app" {
+		t.Errorf("Repositories(excludeForks) = %+v, want only app", repos)
+	}
+}
+
+// TestGitLab_Contributions verifies that merge requests, push timestamps and commit languages are fetched
+func TestGitLab_Contributions(t *testing.T) {
+	now := time.Now()
+	server := newFakeGitLab(t, now)
+	gitlab := newTestGitLab(t, server)
+	ctx := context.Background()
+	user := User{Login: "octo", ID: "42"}
+
+	contributions, err := gitlab.Contributions(ctx, user)
+	if err != nil {
+		t.Fatalf("Contributions() error = %v", err)
+	}
+	if contributions.PullRequests != 8 {
+		t.Errorf("PullRequests = %d, want 8", contributions.PullRequests)
+	}
+
+	timestamps, err := gitlab.CommitTimestamps(ctx, user, now.AddDate(-1, 0, 0), now)
+	if err != nil {
+		t.Fatalf("CommitTimestamps() error = %v", err)
+	}
+	if len(timestamps) != 3 {
+		t.Errorf("CommitTimestamps() returned %d timestamps, want 3 (one per pushed commit in range)", len(timestamps))
+	}
+
+	commitLanguages, err := gitlab.CommitLanguages(ctx, user)
+	if err != nil {
+		t.Fatalf("CommitLanguages() error = %v", err)
+	}
+	if len(commitLanguages) != 1 {
+		t.Fatalf("CommitLanguages() = %v, want one push (inaccessible projects skipped)", commitLanguages)
+	}
+	for _, langs := range commitLanguages {
+		// Two commits, each weighted 2 per language
+		if langs["Go"] != 4 || langs["Shell"] != 4 {
+			t.Errorf("languages = %v, want Go=4, Shell=4", langs)
+		}
+	}
+}
+
+// TestGitLab_Error verifies that API errors are returned
+func TestGitLab_Error(t *testing.T) {
+	server := newFakeGitLab(t, time.Now())
+	gitlab := NewGitLab(server.URL+"/api/v4", http.DefaultClient)
+
+	if _, err := gitlab.Viewer(context.Background()); err == nil {
+		t.Error("Viewer() should return error without a token")
+	}
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/logger"
+	"github.com/watsumi/update-gh-profile/internal/repository"
+)
+
+// teamConcurrency maximum number of users fetched at the same time (keeps large teams under the secondary rate limit)
+const teamConcurrency = 4
+
+// TeamContributors fetches the contributions of each user in [since, until] with the user details query
+//
+// Preconditions:
+// - api has a token (see repository.ClientConfig)
+// - logins are GitHub user names
+// - until - since is at most one year
+//
+// Postconditions:
+// - Returns the contributions of the users that could be fetched, in the order of logins
+// - Returns the logins whose contributions could not be fetched (each failure is logged as a warning)
+//
+// Invariants:
+// - Users are fetched concurrently (at most teamConcurrency at a time), a failure does not stop the other users
+func TeamContributors(ctx context.Context, api repository.ClientConfig, logins []string, since, until time.Time) ([]Contributor, []string) {
+	contributors := make([]Contributor, len(logins))
+	errs := make([]error, len(logins))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, teamConcurrency) // Limit concurrency with semaphore
+	for i, login := range logins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			contributors[i], errs[i] = teamContributor(ctx, api, login, since, until)
+		}()
+	}
+	wg.Wait()
+
+	var fetched []Contributor
+	var failed []string
+	for i, login := range logins {
+		if errs[i] != nil {
+			logger.Warning("Failed to fetch contributions of %s: %v (skipping)", login, errs[i])
+			failed = append(failed, login)
+			continue
+		}
+		fetched = append(fetched, contributors[i])
+	}
+	return fetched, failed
+}
+
+// teamContributor fetches the contributions of a user in [since, until]
+func teamContributor(ctx context.Context, api repository.ClientConfig, login string, since, until time.Time) (Contributor, error) {
+	userDetails, err := repository.FetchUserDetailsForPeriodWithGraphQL(ctx, api, login, since, until)
+	if err != nil {
+		return Contributor{}, err
+	}
+	if userDetails.Login == "" {
+		return Contributor{}, fmt.Errorf("user not found: %s", login)
+	}
+
+	contributions := userDetails.ContributionsCollection
+	return Contributor{
+		Login:        userDetails.Login,
+		Commits:      contributions.TotalCommitContributions,
+		PullRequests: contributions.TotalPullRequestContributions,
+		Reviews:      contributions.TotalPullRequestReviewContributions,
+	}, nil
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/repository"
+)
+
+// TestTeamContributors verifies that contributions are fetched per user and failures are skipped
+func TestTeamContributors(t *testing.T) {
+	until := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	since := until.AddDate(0, 0, -30)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Variables map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if request.Variables["from"] != since.Format(time.RFC3339) || request.Variables["to"] != until.Format(time.RFC3339) {
+			t.Errorf("period = %v - %v, want %s - %s", request.Variables["from"], request.Variables["to"], since, until)
+		}
+
+		contributions := map[string]map[string]any{
+			"alice": {"totalCommitContributions": 20, "totalPullRequestContributions": 4, "totalPullRequestReviewContributions": 6},
+			"bob":   {"totalCommitContributions": 5, "totalPullRequestContributions": 1, "totalPullRequestReviewContributions": 0},
+		}
+		login, _ := request.Variables["login"].(string)
+		switch login {
+		case "alice", "bob":
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"user": map[string]any{
+				"login": login, "contributionsCollection": contributions[login],
+			}}})
+		case "ghost":
+			json.NewEncoder(w).Encode(map[string]any{
+				"data":   map[string]any{"user": nil},
+				"errors": []map[string]any{{"message": "Could not resolve to a User with the login of 'ghost'."}},
+			})
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	api := repository.ClientConfig{Token: "test-token", GraphQLURL: server.URL}
+	contributors, failed := TeamContributors(context.Background(), api, []string{"alice", "ghost", "bob", "broken"}, since, until)
+
+	want := []Contributor{
+		{Login: "alice", Commits: 20, PullRequests: 4, Reviews: 6},
+		{Login: "bob", Commits: 5, PullRequests: 1},
+	}
+	if len(contributors) != len(want) {
+		t.Fatalf("TeamContributors() = %+v, want %+v", contributors, want)
+	}
+	for i := range want {
+		if contributors[i] != want[i] {
+			t.Errorf("contributors[%d] = %+v, want %+v", i, contributors[i], want[i])
+		}
+	}
+	if len(failed) != 2 || failed[0] != "ghost" || failed[1] != "broken" {
+		t.Errorf("failed = %v, want [ghost broken]", failed)
+	}
+}
+
+// TestTeamContributors_Concurrency verifies that at most teamConcurrency users are fetched at the same time
+func TestTeamContributors_Concurrency(t *testing.T) {
+	var active, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := active.Add(1)
+		defer active.Add(-1)
+		for {
+			observed := peak.Load()
+			if current <= observed || peak.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		var request struct {
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"user": map[string]any{
+			"login": request.Variables["login"], "contributionsCollection": map[string]any{"totalCommitContributions": 1},
+		}}})
+	}))
+	defer server.Close()
+
+	var logins []string
+	for i := range 3 * teamConcurrency {
+		logins = append(logins, "user"+strconv.Itoa(i))
+	}
+	until := time.Now()
+	contributors, failed := TeamContributors(context.Background(), repository.ClientConfig{Token: "test-token", GraphQLURL: server.URL}, logins, until.AddDate(0, 0, -30), until)
+
+	if len(contributors) != len(logins) || len(failed) != 0 {
+		t.Fatalf("TeamContributors() = %d contributors, failed %v, want %d contributors", len(contributors), failed, len(logins))
+	}
+	if got := peak.Load(); got > teamConcurrency {
+		t.Errorf("%d concurrent requests, want at most %d", got, teamConcurrency)
+	}
+}
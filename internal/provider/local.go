@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/logger"
+	"github.com/watsumi/update-gh-profile/internal/repository"
+)
+
+// Local provider scanning local git clones (no network access)
+type Local struct {
+	root   string
+	emails map[string]bool
+}
+
+// localCommit commit read from git log
+type localCommit struct {
+	Hash  string
+	Date  time.Time      // Author date
+	Files map[string]int // Changed files per language
+}
+
+// NewLocal creates a provider scanning the git repositories under root
+//
+// Preconditions:
+// - root is a directory containing git clones (or a clone itself)
+// - emails are the author emails of the user (compared case-insensitively)
+//
+// Postconditions:
+// - Returns a provider reading commits authored by emails with git log
+func NewLocal(root string, emails []string) *Local {
+	emailSet := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+			emailSet[email] = true
+		}
+	}
+	return &Local{root: root, emails: emailSet}
+}
+
+// Name returns "local"
+func (l *Local) Name() string {
+	return "local"
+}
+
+// Viewer returns a user identified by the author emails
+func (l *Local) Viewer(ctx context.Context) (User, error) {
+	if len(l.emails) == 0 {
+		return User{}, fmt.Errorf("no author emails configured for local repositories")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return User{}, fmt.Errorf("git command not found: %w", err)
+	}
+
+	emails := make([]string, 0, len(l.emails))
+	for email := range l.emails {
+		emails = append(emails, email)
+	}
+	slices.Sort(emails)
+	return User{Login: strings.Join(emails, ",")}, nil
+}
+
+// Repositories returns the git repositories under the root directory
+// Languages are the sizes of the files at HEAD, and commits are those of HEAD authored by the configured emails
+// Commit times and languages are attributed to the repositories, so a clone of a repository listed by another provider is counted once
+// Repositories are named after their origin remote (host, owner and name), or after their path relative to the root
+func (l *Local) Repositories(ctx context.Context, user User, excludeForks bool) ([]Repository, error) {
+	dirs, err := findGitRepositories(l.root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan local repositories: %w", err)
+	}
+
+	since := time.Now().AddDate(-1, 0, 0)
+	var repos []Repository
+	for _, dir := range dirs {
+		repo := l.repositoryName(ctx, dir)
+
+		languages, err := localLanguages(ctx, dir)
+		if err != nil {
+			// Repositories without commits have no HEAD
+			logger.Warning("Skipping local repository %s: %v", dir, err)
+			continue
+		}
+		repo.Languages = languages
+
+		commits, err := l.authoredCommits(ctx, dir)
+		if err != nil {
+			logger.Warning("Skipping local repository %s: %v", dir, err)
+			continue
+		}
+		repo.CommitCount = len(commits)
+		repo.TimeDistribution = make(map[int]int)
+		repo.CommitLanguages = make(map[string]map[string]int)
+		for _, commit := range commits {
+			if !commit.Date.Before(since) {
+				repo.CommitDates = append(repo.CommitDates, commit.Date)
+				repo.TimeDistribution[commit.Date.UTC().Hour()]++
+			}
+			if len(commit.Files) > 0 {
+				repo.CommitLanguages[commit.Hash] = commit.Files
+			}
+		}
+
+		repos = append(repos, repo)
+	}
+
+	return repos, nil
+}
+
+// Contributions returns no contributions (pull requests are not stored in git)
+func (l *Local) Contributions(ctx context.Context, user User) (Contributions, error) {
+	return Contributions{}, nil
+}
+
+// CommitTimestamps returns no timestamps (commit times are attributed to the repositories, see Repositories)
+func (l *Local) CommitTimestamps(ctx context.Context, user User, since, until time.Time) ([]time.Time, error) {
+	return nil, nil
+}
+
+// CommitLanguages returns no languages (the languages of the changed files are attributed to the repositories, see Repositories)
+func (l *Local) CommitLanguages(ctx context.Context, user User) (map[string]map[string]int, error) {
+	return nil, nil
+}
+
+// repositoryName returns the repository named after its origin remote, or after its path relative to the root
+func (l *Local) repositoryName(ctx context.Context, dir string) Repository {
+	if remoteURL, err := runLocalGit(ctx, dir, "remote", "get-url", "origin"); err == nil {
+		if host, owner, name, ok := parseRemoteRepository(strings.TrimSpace(remoteURL)); ok {
+			return Repository{Host: host, Owner: owner, Name: name}
+		}
+	}
+
+	name, err := filepath.Rel(l.root, dir)
+	if err != nil || name == "." {
+		name = filepath.Base(dir)
+	}
+	return Repository{Owner: "local", Name: filepath.ToSlash(name)}
+}
+
+// authoredCommits reads the non-merge commits of HEAD authored by the configured emails
+func (l *Local) authoredCommits(ctx context.Context, dir string) ([]localCommit, error) {
+	// Each commit starts with a record separator, followed by the changed files
+	output, err := runLocalGit(ctx, dir, "log", "HEAD", "--no-merges", "--no-renames", "--name-only", "--format=%x1e%H%x1f%aI%x1f%ae")
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []localCommit
+	for _, record := range strings.Split(output, "\x1e") {
+		header, files, _ := strings.Cut(record, "\n")
+		fields := strings.Split(header, "\x1f")
+		if len(fields) != 3 || !l.emails[strings.ToLower(fields[2])] {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			continue
+		}
+
+		commit := localCommit{Hash: fields[0], Date: date, Files: make(map[string]int)}
+		for _, file := range strings.Split(files, "\n") {
+			if lang := repository.DetectLanguageFromFilename(strings.TrimSpace(file)); lang != "" {
+				commit.Files[lang]++
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// localLanguages returns the bytes per language of the files at HEAD
+func localLanguages(ctx context.Context, dir string) (map[string]int, error) {
+	output, err := runLocalGit(ctx, dir, "ls-tree", "-r", "-l", "-z", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	languages := make(map[string]int)
+	for _, entry := range strings.Split(output, "\x00") {
+		// <mode> <type> <object> <size>\t<path>
+		info, path, ok := strings.Cut(entry, "\t")
+		fields := strings.Fields(info)
+		if !ok || len(fields) != 4 || fields[1] != "blob" {
+			continue
+		}
+		size, err := strconv.Atoi(fields[3])
+		if err != nil {
+			continue
+		}
+		if lang := repository.DetectLanguageFromFilename(path); lang != "" {
+			languages[lang] += size
+		}
+	}
+	return languages, nil
+}
+
+// findGitRepositories returns the directories of the git repositories under root
+// Nested repositories and hidden directories are not scanned
+func findGitRepositories(root string) ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules") {
+			return filepath.SkipDir
+		}
+
+		// .git is a directory in clones and a file in worktrees and submodules
+		if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+			dirs = append(dirs, path)
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return dirs, err
+}
+
+// parseRemoteRepository returns the host name, owner and name of a remote URL (https, ssh or scp-like)
+// The owner is the whole path before the name, like the namespace path of GitLab subgroups (e.g. "group/sub")
+func parseRemoteRepository(remoteURL string) (string, string, string, bool) {
+	var host, path string
+	if u, err := url.Parse(remoteURL); err == nil && u.Scheme != "" && u.Host != "" {
+		host, path = u.Hostname(), u.Path
+	} else if address, rest, ok := strings.Cut(remoteURL, ":"); ok && strings.Contains(address, "@") {
+		// scp-like: user@host:owner/name
+		host, path = address[strings.LastIndex(address, "@")+1:], rest
+	} else {
+		// Local paths are not repository URLs
+		return "", "", "", false
+	}
+
+	trimmed := strings.TrimSuffix(strings.TrimSuffix(path, "/"), ".git")
+	segments := strings.FieldsFunc(trimmed, func(r rune) bool { return r == '/' })
+	if host == "" || len(segments) < 2 {
+		return "", "", "", false
+	}
+	return strings.ToLower(host), strings.Join(segments[:len(segments)-1], "/"), segments[len(segments)-1], true
+}
+
+// runLocalGit executes a read-only git command in dir and returns stdout
+func runLocalGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to execute git %s: %w\nstderr: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
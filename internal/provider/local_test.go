@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// commitFiles writes files and commits them as the given author
+func commitFiles(t *testing.T, dir, email string, date time.Time, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runTestGit(t, dir, []string{
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=" + email, "GIT_AUTHOR_DATE=" + date.Format(time.RFC3339),
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=" + email,
+	}, "add", "-A")
+	runTestGit(t, dir, []string{
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=" + email, "GIT_AUTHOR_DATE=" + date.Format(time.RFC3339),
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=" + email,
+	}, "commit", "-q", "-m", "update")
+}
+
+// runTestGit runs git in dir
+func runTestGit(t *testing.T, dir string, env []string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+}
+
+// TestLocal verifies that local clones are scanned for commits of the configured emails
+func TestLocal(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	app := filepath.Join(root, "work", "app")
+	if err := os.MkdirAll(app, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runTestGit(t, app, nil, "init", "-q")
+	runTestGit(t, app, nil, "remote", "add", "origin", "git@github.com:octo/app.git")
+	commitFiles(t, app, "me@example.com", now.Add(-time.Hour), map[string]string{"main.go": "package main\n", "lib/util.py": "x = 1\n"})
+	commitFiles(t, app, "someone@example.com", now.Add(-2*time.Hour), map[string]string{"README.md": "# app\n"})
+	commitFiles(t, app, "ME@example.com", now.AddDate(-2, 0, 0), map[string]string{"main.go": "package main\n\nfunc main() {}\n"})
+
+	notes := filepath.Join(root, "notes")
+	if err := os.MkdirAll(notes, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runTestGit(t, notes, nil, "init", "-q")
+	commitFiles(t, notes, "me@example.com", now.Add(-3*time.Hour), map[string]string{"notes.rs": "fn main() {}\n"})
+
+	// Repositories without commits are skipped
+	runTestGit(t, root, nil, "init", "-q", filepath.Join(root, "empty"))
+
+	local := NewLocal(root, []string{"me@example.com"})
+	ctx := context.Background()
+	user, err := local.Viewer(ctx)
+	if err != nil {
+		t.Fatalf("Viewer() error = %v", err)
+	}
+
+	data, err := Collect(ctx, local, user, Options{})
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if len(data.Repositories) != 2 {
+		t.Fatalf("Repositories = %+v, want app and notes", data.Repositories)
+	}
+	repos := make(map[string]Repository)
+	for _, repo := range data.Repositories {
+		repos[repo.Owner+"/"+repo.Name] = repo
+	}
+	if repos["octo/app"].CommitCount != 2 {
+		t.Errorf("octo/app = %+v, want 2 commits by me (named after origin)", repos["octo/app"])
+	}
+	if _, ok := repos["local/notes"]; !ok {
+		t.Errorf("repositories without a remote should be named after their path: %v", repos)
+	}
+
+	if data.LanguageTotals["Go"] == 0 || data.LanguageTotals["Python"] == 0 || data.LanguageTotals["Rust"] == 0 {
+		t.Errorf("LanguageTotals = %v, want Go, Python and Rust", data.LanguageTotals)
+	}
+	if data.TotalCommits != 3 {
+		t.Errorf("TotalCommits = %d, want 3", data.TotalCommits)
+	}
+
+	hours := 0
+	for _, distribution := range data.TimeDistributions {
+		for _, count := range distribution {
+			hours += count
+		}
+	}
+	if hours != 2 {
+		t.Errorf("time distribution has %d commits, want 2 (past year, own commits)", hours)
+	}
+
+	if len(data.CommitLanguages) != 3 {
+		t.Errorf("CommitLanguages has %d commits, want 3", len(data.CommitLanguages))
+	}
+	goCommits := 0
+	for _, langs := range data.CommitLanguages {
+		goCommits += langs["Go"]
+	}
+	if goCommits != 2 {
+		t.Errorf("Go was changed in %d commits, want 2", goCommits)
+	}
+}
+
+// TestLocal_NoEmails verifies that author emails are required
+func TestLocal_NoEmails(t *testing.T) {
+	if _, err := NewLocal(t.TempDir(), []string{" "}).Viewer(context.Background()); err == nil {
+		t.Error("Viewer() should return error without author emails")
+	}
+}
+
+// TestParseRemoteRepository verifies that host, owner and name are read from remote URLs
+func TestParseRemoteRepository(t *testing.T) {
+	tests := []struct {
+		remoteURL string
+		host      string
+		owner     string
+		name      string
+		ok        bool
+	}{
+		{"https://github.com/octo/app.git", "github.com", "octo", "app", true},
+		{"git@gitlab.com:group/app.git", "gitlab.com", "group", "app", true},
+		{"ssh://git@Forge.example.com:2222/octo/app", "forge.example.com", "octo", "app", true},
+		{"https://gitlab.com/group/sub/proj.git", "gitlab.com", "group/sub", "proj", true},
+		{"git@gitlab.com:a/x/proj.git", "gitlab.com", "a/x", "proj", true},
+		{"/srv/git/app.git", "", "", "", false},
+		{"file:///srv/git/octo/app.git", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		host, owner, name, ok := parseRemoteRepository(tt.remoteURL)
+		if host != tt.host || owner != tt.owner || name != tt.name || ok != tt.ok {
+			t.Errorf("parseRemoteRepository(%q) = %q, %q, %q, %v, want %q, %q, %q, %v", tt.remoteURL, host, owner, name, ok, tt.host, tt.owner, tt.name, tt.ok)
+		}
+	}
+}
+
+// TestMerge_LocalClone verifies that a local clone of a repository listed by GitHub doesn't count its commits twice
+func TestMerge_LocalClone(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	now := time.Now().UTC().Truncate(time.Hour).Add(-24 * time.Hour)
+	ctx := context.Background()
+
+	// Clone of octo/app (also listed by GitHub) and a repository that only exists locally
+	app := filepath.Join(root, "app")
+	notes := filepath.Join(root, "notes")
+	for _, dir := range []string{app, notes} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		runTestGit(t, dir, nil, "init", "-q")
+	}
+	runTestGit(t, app, nil, "remote", "add", "origin", "https://github.com/octo/app.git")
+	commitFiles(t, app, "me@example.com", now, map[string]string{"main.go": "package main\n"})
+	commitFiles(t, notes, "me@example.com", now.Add(time.Hour), map[string]string{"notes.rs": "fn main() {}\n"})
+
+	github, err := Collect(ctx, &fakeProvider{
+		repos:           []Repository{{Host: "github.com", Owner: "octo", Name: "app", Languages: map[string]int{"Go": 14}, CommitCount: 1, CommitDates: []time.Time{now}}},
+		timestamps:      []time.Time{now},
+		commitLanguages: map[string]map[string]int{now.Format(time.RFC3339): {"Go": 1}},
+	}, User{Login: "octo"}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	local := NewLocal(root, []string{"me@example.com"})
+	user, err := local.Viewer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scanned, err := Collect(ctx, local, user, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merged := Merge(0, github, scanned)
+
+	if len(merged.Repositories) != 2 || merged.TotalCommits != 2 {
+		t.Errorf("Repositories = %+v (%d commits), want octo/app once and local/notes", merged.Repositories, merged.TotalCommits)
+	}
+	hours := make(map[int]int)
+	for _, distribution := range merged.TimeDistributions {
+		for hour, count := range distribution {
+			hours[hour] += count
+		}
+	}
+	if hours[now.Hour()] != 1 || hours[now.Add(time.Hour).Hour()] != 1 {
+		t.Errorf("TimeDistributions = %v, want one commit of octo/app and one of local/notes", merged.TimeDistributions)
+	}
+	if len(merged.CommitLanguages) != 2 {
+		t.Errorf("CommitLanguages = %v, want the GitHub commit of octo/app and the commit of local/notes", merged.CommitLanguages)
+	}
+	languages := make(map[string]int)
+	for _, langs := range merged.CommitLanguages {
+		for lang, weight := range langs {
+			languages[lang] += weight
+		}
+	}
+	if languages["Go"] != 1 || languages["Rust"] != 1 {
+		t.Errorf("commit languages = %v, want Go=1 and Rust=1", languages)
+	}
+}
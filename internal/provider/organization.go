@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/repository"
+)
+
+// Organization provider aggregating the public repositories and members of a GitHub organization
+type Organization struct {
+	api     repository.ClientConfig
+	login   string
+	repos   []Repository  // Repositories fetched by Repositories
+	members []Contributor // Members fetched by Contributions or Contributors
+}
+
+// NewOrganization creates a GitHub organization provider
+//
+// Preconditions:
+// - api has a token (see repository.ClientConfig), with read:org to include members with private membership
+// - login is the organization name
+//
+// Postconditions:
+// - Returns a provider fetching the data of the organization from the GraphQL API of api
+func NewOrganization(api repository.ClientConfig, login string) *Organization {
+	return &Organization{api: api, login: login}
+}
+
+// Name returns "organization"
+func (o *Organization) Name() string {
+	return "organization"
+}
+
+// Viewer returns the organization (not the authenticated user)
+func (o *Organization) Viewer(ctx context.Context) (User, error) {
+	login, id, err := repository.FetchOrganizationWithGraphQL(ctx, o.api, o.login)
+	if err != nil {
+		return User{}, err
+	}
+	return User{Login: login, ID: id}, nil
+}
+
+// Repositories returns the public repositories of the organization
+// Commits of the past year are kept for CommitTimestamps and CommitLanguages
+func (o *Organization) Repositories(ctx context.Context, user User, excludeForks bool) ([]Repository, error) {
+	repoGraphQLData, err := repository.FetchOrganizationRepositoriesWithGraphQL(ctx, o.api, user.Login, time.Now().AddDate(-1, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	restURL, _ := o.api.Endpoints()
+	repos := make([]Repository, 0, len(repoGraphQLData))
+	for _, repoData := range repoGraphQLData {
+		if excludeForks && repoData.IsFork {
+			continue
+		}
+
+		repo := Repository{
+			Host:        webHost(restURL),
+			Owner:       repoData.Owner.Login,
+			Name:        repoData.Name,
+			Stars:       repoData.StargazerCount,
+			Languages:   make(map[string]int, len(repoData.Languages.Edges)),
+			CommitCount: repoData.DefaultBranchRef.Target.Commits.TotalCount,
+		}
+		for _, edge := range repoData.Languages.Edges {
+			repo.Languages[edge.Node.Name] += edge.Size
+		}
+		for _, commit := range repoData.DefaultBranchRef.Target.Recent.Nodes {
+			if t, err := time.Parse(time.RFC3339, commit.CommittedDate); err == nil {
+				repo.CommitDates = append(repo.CommitDates, t)
+			}
+		}
+		repos = append(repos, repo)
+	}
+
+	o.repos = repos
+	return repos, nil
+}
+
+// Contributions returns the number of pull requests opened by the members in the organization over the past year
+func (o *Organization) Contributions(ctx context.Context, user User) (Contributions, error) {
+	members, err := o.Contributors(ctx, user)
+	if err != nil {
+		return Contributions{}, err
+	}
+
+	var contributions Contributions
+	for _, member := range members {
+		contributions.PullRequests += member.PullRequests
+	}
+	return contributions, nil
+}
+
+// CommitTimestamps returns the timestamps of the commits on the default branches of the repositories read by Repositories
+func (o *Organization) CommitTimestamps(ctx context.Context, user User, since, until time.Time) ([]time.Time, error) {
+	var timestamps []time.Time
+	for _, repo := range o.repos {
+		for _, date := range repo.CommitDates {
+			if !date.Before(since) && !date.After(until) {
+				timestamps = append(timestamps, date)
+			}
+		}
+	}
+	return timestamps, nil
+}
+
+// CommitLanguages returns the language weights of the commits of the repositories read by Repositories
+// Each commit is weighted by the languages of its repository, like the GitHub provider
+func (o *Organization) CommitLanguages(ctx context.Context, user User) (map[string]map[string]int, error) {
+	commitLanguages := make(map[string]map[string]int)
+	for _, repo := range o.repos {
+		for _, date := range repo.CommitDates {
+			commitKey := date.UTC().Format(time.RFC3339)
+			if commitLanguages[commitKey] == nil {
+				commitLanguages[commitKey] = make(map[string]int)
+			}
+			for lang, size := range repo.Languages {
+				weight := 1
+				if size > 1000 {
+					weight = 2
+				}
+				commitLanguages[commitKey][lang] += weight
+			}
+		}
+	}
+	return commitLanguages, nil
+}
+
+// Contributors returns the contributions of each member to the organization over the past year
+// Members are fetched once and reused by Contributions
+func (o *Organization) Contributors(ctx context.Context, user User) ([]Contributor, error) {
+	if o.members != nil {
+		return o.members, nil
+	}
+
+	until := time.Now()
+	memberGraphQLData, err := repository.FetchOrganizationMembersWithGraphQL(ctx, o.api, user.Login, user.ID, until.AddDate(-1, 0, 0), until)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]Contributor, 0, len(memberGraphQLData))
+	for _, member := range memberGraphQLData {
+		contributions := member.ContributionsCollection
+		members = append(members, Contributor{
+			Login:        member.Login,
+			Commits:      contributions.TotalCommitContributions,
+			PullRequests: contributions.TotalPullRequestContributions,
+			Reviews:      contributions.TotalPullRequestReviewContributions,
+		})
+	}
+
+	o.members = members
+	return members, nil
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/repository"
+)
+
+// newFakeOrganizationAPI starts a fake GitHub GraphQL API serving the organization "acme"
+func newFakeOrganizationAPI(t *testing.T, now time.Time) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if request.Variables["login"] != "acme" {
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"organization": nil}})
+			return
+		}
+
+		var data map[string]any
+		switch {
+		case strings.Contains(request.Query, "query OrganizationRepositories"):
+			// Two pages: the second page holds the fork
+			if request.Variables["endCursor"] == nil {
+				data = map[string]any{"organization": map[string]any{"repositories": map[string]any{
+					"nodes": []map[string]any{{
+						"name": "api", "owner": map[string]any{"login": "acme"}, "stargazerCount": 10,
+						"languages": map[string]any{"edges": []map[string]any{
+							{"node": map[string]any{"name": "Go"}, "size": 5000},
+							{"node": map[string]any{"name": "Shell"}, "size": 300},
+						}},
+						"defaultBranchRef": map[string]any{"target": map[string]any{
+							"commits": map[string]any{"totalCount": 120},
+							"recent": map[string]any{"nodes": []map[string]any{
+								{"committedDate": now.Add(-time.Hour).UTC().Format(time.RFC3339)},
+								{"committedDate": now.Add(-48 * time.Hour).UTC().Format(time.RFC3339)},
+							}},
+						}},
+					}},
+					"pageInfo": map[string]any{"endCursor": "c1", "hasNextPage": true},
+				}}}
+			} else {
+				data = map[string]any{"organization": map[string]any{"repositories": map[string]any{
+					"nodes": []map[string]any{{
+						"name": "fork", "owner": map[string]any{"login": "acme"}, "isFork": true,
+						"languages":        map[string]any{"edges": []map[string]any{{"node": map[string]any{"name": "C"}, "size": 100}}},
+						"defaultBranchRef": map[string]any{"target": map[string]any{"commits": map[string]any{"totalCount": 3}}},
+					}},
+					"pageInfo": map[string]any{"hasNextPage": false},
+				}}}
+			}
+		case strings.Contains(request.Query, "query OrganizationMembers"):
+			if request.Variables["orgId"] != "O_1" {
+				t.Errorf("orgId = %v, want O_1", request.Variables["orgId"])
+			}
+			member := func(login string, commits, prs, reviews int) map[string]any {
+				return map[string]any{"login": login, "contributionsCollection": map[string]any{
+					"totalCommitContributions":            commits,
+					"totalPullRequestContributions":       prs,
+					"totalPullRequestReviewContributions": reviews,
+				}}
+			}
+			data = map[string]any{"organization": map[string]any{"membersWithRole": map[string]any{
+				"nodes":    []map[string]any{member("alice", 30, 5, 8), member("bob", 12, 2, 0)},
+				"pageInfo": map[string]any{"hasNextPage": false},
+			}}}
+		case strings.Contains(request.Query, "query Organization"):
+			data = map[string]any{"organization": map[string]any{"login": "acme", "id": "O_1"}}
+		default:
+			t.Errorf("unexpected query: %s", request.Query)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestOrganization_Collect verifies that organization repositories and member contributions are aggregated
+func TestOrganization_Collect(t *testing.T) {
+	server := newFakeOrganizationAPI(t, time.Now())
+	org := NewOrganization(repository.ClientConfig{Token: "test-token", GraphQLURL: server.URL}, "acme")
+	ctx := context.Background()
+
+	user, err := org.Viewer(ctx)
+	if err != nil {
+		t.Fatalf("Viewer() error = %v", err)
+	}
+	if user.Login != "acme" || user.ID != "O_1" {
+		t.Errorf("Viewer() = %+v, want acme (O_1)", user)
+	}
+
+	data, err := Collect(ctx, org, user, Options{ExcludeForks: true})
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if len(data.Repositories) != 1 || data.Repositories[0].Name != "api" || data.Repositories[0].Stars != 10 {
+		t.Errorf("Repositories = %+v, want only acme/api (forks excluded)", data.Repositories)
+	}
+	if data.LanguageTotals["Go"] != 5000 || data.LanguageTotals["Shell"] != 300 {
+		t.Errorf("LanguageTotals = %v, want Go=5000, Shell=300", data.LanguageTotals)
+	}
+	if data.TotalCommits != 120 || len(data.CommitHistories["127.0.0.1/acme/api"]) == 0 {
+		t.Errorf("commits = %d, history = %v, want 120 commits with a history", data.TotalCommits, data.CommitHistories)
+	}
+	if data.TotalPullRequests != 7 {
+		t.Errorf("TotalPullRequests = %d, want 7 (sum of members)", data.TotalPullRequests)
+	}
+	if len(data.CommitLanguages) != 2 {
+		t.Errorf("CommitLanguages = %v, want two commits", data.CommitLanguages)
+	}
+	for _, langs := range data.CommitLanguages {
+		if langs["Go"] != 2 || langs["Shell"] != 1 {
+			t.Errorf("languages = %v, want Go=2, Shell=1", langs)
+		}
+	}
+
+	want := []Contributor{{Login: "alice", Commits: 30, PullRequests: 5, Reviews: 8}, {Login: "bob", Commits: 12, PullRequests: 2}}
+	if len(data.Contributors) != len(want) {
+		t.Fatalf("Contributors = %+v, want %+v", data.Contributors, want)
+	}
+	for i := range want {
+		if data.Contributors[i] != want[i] {
+			t.Errorf("Contributors[%d] = %+v, want %+v", i, data.Contributors[i], want[i])
+		}
+	}
+}
+
+// TestOrganization_NotFound verifies that an unknown organization is reported
+func TestOrganization_NotFound(t *testing.T) {
+	server := newFakeOrganizationAPI(t, time.Now())
+	org := NewOrganization(repository.ClientConfig{Token: "test-token", GraphQLURL: server.URL}, "unknown")
+
+	if _, err := org.Viewer(context.Background()); err == nil {
+		t.Error("Viewer() should return error for an unknown organization")
+	}
+}
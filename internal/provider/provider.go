@@ -0,0 +1,335 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/logger"
+)
+
+// User account whose data is fetched
+type User struct {
+	Login string // User name
+	ID    string // Provider-specific user ID
+}
+
+// Repository repository owned by the user
+type Repository struct {
+	Host        string         // Host name of the server (e.g. "github.com"), empty for repositories without a server
+	Owner       string         // Owner (user, group or organization path)
+	Name        string         // Repository name
+	Stars       int            // Star count
+	Languages   map[string]int // Language breakdown (bytes per language)
+	CommitCount int            // Commits on the default branch
+	CommitDates []time.Time    // Timestamps of recent commits on the default branch
+
+	// Commits of the user attributed to the repository, set by providers that read commits per repository (e.g. local clones)
+	// Merge drops them together with a duplicate repository
+	TimeDistribution map[int]int               // Commits per hour (UTC) in the past year
+	CommitLanguages  map[string]map[string]int // Language weights per commit
+}
+
+// fullName returns the name of the repository with its host (e.g. "github.com/octo/app"), or owner/name without a host
+func (r Repository) fullName() string {
+	if r.Host == "" {
+		return r.Owner + "/" + r.Name
+	}
+	return r.Host + "/" + r.Owner + "/" + r.Name
+}
+
+// webHost returns the host name of the web server of an API URL (e.g. "github.com" for "https://api.github.com/")
+func webHost(apiURL string) string {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "api.")
+}
+
+// Contributions contribution counts of the user
+type Contributions struct {
+	PullRequests int // Pull requests (merge requests on GitLab) opened by the user
+}
+
+// Contributor contribution counts of a member of an organization
+type Contributor struct {
+	Login        string // User name
+	Commits      int    // Commit contributions
+	PullRequests int    // Pull requests opened
+	Reviews      int    // Pull request reviews
+}
+
+// Provider source of profile data (GitHub, GitLab, ...)
+type Provider interface {
+	// Name returns the provider name used in logs (e.g. "github")
+	Name() string
+
+	// Viewer returns the authenticated user
+	Viewer(ctx context.Context) (User, error)
+
+	// Repositories returns the repositories owned by the user, with their language breakdown and recent commits
+	Repositories(ctx context.Context, user User, excludeForks bool) ([]Repository, error)
+
+	// Contributions returns the contribution counts of the user
+	Contributions(ctx context.Context, user User) (Contributions, error)
+
+	// CommitTimestamps returns the timestamps of commits contributed by the user in [since, until]
+	CommitTimestamps(ctx context.Context, user User, since, until time.Time) ([]time.Time, error)
+
+	// CommitLanguages returns the language weights of commits contributed by the user (commit key -> language -> weight)
+	CommitLanguages(ctx context.Context, user User) (map[string]map[string]int, error)
+}
+
+// ContributorProvider provider that can also break contributions down by contributor (e.g. organization members)
+type ContributorProvider interface {
+	Provider
+
+	// Contributors returns the contribution counts of each contributor of the user (organization) over the past year
+	Contributors(ctx context.Context, user User) ([]Contributor, error)
+}
+
+// Options options of Collect
+type Options struct {
+	ExcludeForks    bool // Whether to exclude forked repositories
+	MaxRepositories int  // Maximum number of repositories aggregated over all providers (0 = all), applied by Merge
+}
+
+// Data aggregation inputs fetched from one or more providers
+type Data struct {
+	LanguageTotals    map[string]int            // Bytes per language
+	CommitHistories   map[string]map[string]int // Commit count per date (YYYY-MM-DD) per repository (see Repository.fullName)
+	TimeDistributions map[string]map[int]int    // Commit count per hour (UTC) per source
+	CommitLanguages   map[string]map[string]int // Language weights per commit
+	TotalCommits      int                       // Commits on the default branches of the repositories
+	TotalPullRequests int                       // Pull requests opened by the user
+	Repositories      []Repository              // Repositories (for summary statistics)
+	Contributors      []Contributor             // Contribution counts per contributor (only from a ContributorProvider)
+}
+
+// newData returns empty aggregation inputs
+func newData() *Data {
+	return &Data{
+		LanguageTotals:    make(map[string]int),
+		CommitHistories:   make(map[string]map[string]int),
+		TimeDistributions: make(map[string]map[int]int),
+		CommitLanguages:   make(map[string]map[string]int),
+	}
+}
+
+// Collect fetches the data of a user from a provider
+//
+// Preconditions:
+// - ctx is a valid context.Context
+// - p is an initialized provider and user is its authenticated user
+//
+// Postconditions:
+// - Returns the aggregation inputs of the user
+// - Returns error if repositories cannot be fetched
+//
+// Invariants:
+// - Failures of contributions, commit timestamps and commit languages are logged, and the data is left empty
+// - Commit timestamps cover the past year
+// - opts.MaxRepositories is not applied (see Merge)
+func Collect(ctx context.Context, p Provider, user User, opts Options) (*Data, error) {
+	logger.Info("Fetching repository information from %s", p.Name())
+
+	// 1. Fetch repositories with their languages and commits
+	repos, err := p.Repositories(ctx, user, opts.ExcludeForks)
+	if err != nil {
+		logger.LogError(err, "Failed to fetch repository information")
+		return nil, fmt.Errorf("failed to fetch repository information from %s: %w", p.Name(), err)
+	}
+
+	logger.Info("Fetched %d repository information items", len(repos))
+
+	// 2. Fetch contribution counts
+	contributions, err := p.Contributions(ctx, user)
+	if err != nil {
+		// Treat temporary errors like 502 Bad Gateway as warnings (not fatal)
+		logger.Warning("Failed to fetch contributions from %s: %v (continuing)", p.Name(), err)
+	}
+
+	// 3. Fetch commit timestamps (past 1 year)
+	until := time.Now()
+	since := until.AddDate(-1, 0, 0)
+	timestamps, err := p.CommitTimestamps(ctx, user, since, until)
+	if err != nil {
+		logger.LogError(err, "Failed to fetch commit time distribution")
+	}
+
+	// 4. Fetch languages per commit
+	commitLanguages, err := p.CommitLanguages(ctx, user)
+	if err != nil {
+		logger.LogError(err, "Failed to fetch commit language information")
+	}
+
+	// 5. Fetch contributions per contributor (organizations only)
+	var contributors []Contributor
+	if cp, ok := p.(ContributorProvider); ok {
+		contributors, err = cp.Contributors(ctx, user)
+		if err != nil {
+			logger.Warning("Failed to fetch contributors from %s: %v (continuing)", p.Name(), err)
+		}
+	}
+
+	// 6. Aggregate data
+	data := newData()
+	data.Contributors = contributors
+	data.TotalPullRequests = contributions.PullRequests
+	for _, repo := range repos {
+		data.addRepository(repo)
+	}
+
+	// Commit time distribution of all repositories (treated as a single entry)
+	if len(timestamps) > 0 {
+		distribution := make(map[int]int)
+		for _, timestamp := range timestamps {
+			distribution[timestamp.UTC().Hour()]++
+		}
+		data.TimeDistributions[p.Name()] = distribution
+	}
+
+	for commit, langs := range commitLanguages {
+		data.CommitLanguages[commit] = langs
+	}
+
+	logger.Info("Data aggregation completed: languages=%d, commit histories=%d, time distributions=%d",
+		len(data.LanguageTotals), len(data.CommitHistories), len(data.TimeDistributions))
+
+	return data, nil
+}
+
+// addRepository adds a repository and its languages, commit history and commit count
+func (d *Data) addRepository(repo Repository) {
+	d.Repositories = append(d.Repositories, repo)
+
+	for lang, size := range repo.Languages {
+		d.LanguageTotals[lang] += size
+	}
+
+	// Commit history (by date)
+	history := make(map[string]int)
+	for _, date := range repo.CommitDates {
+		history[date.UTC().Format("2006-01-02")]++
+	}
+	if len(history) > 0 {
+		d.CommitHistories[repo.fullName()] = history
+	}
+
+	if repo.CommitCount > 0 {
+		d.TotalCommits += repo.CommitCount
+	}
+
+	// Commits attributed to the repository (see repositoryCommitKeys)
+	if len(repo.TimeDistribution) > 0 {
+		d.TimeDistributions[repo.fullName()] = repo.TimeDistribution
+	}
+	for commit, langs := range repo.CommitLanguages {
+		d.CommitLanguages[commit] = langs
+	}
+}
+
+// repositoryCommitKeys returns the time distribution and commit language keys added by the repositories
+// Merge adds these entries with their repository only, so that a duplicate repository doesn't count its commits twice
+func repositoryCommitKeys(repos []Repository) (map[string]bool, map[string]bool) {
+	sources := make(map[string]bool)
+	commits := make(map[string]bool)
+	for _, repo := range repos {
+		if len(repo.TimeDistribution) > 0 {
+			sources[repo.fullName()] = true
+		}
+		for commit := range repo.CommitLanguages {
+			commits[commit] = true
+		}
+	}
+	return sources, commits
+}
+
+// Merge merges the data of several accounts into one profile
+//
+// Preconditions:
+// - maxRepositories is the maximum number of repositories kept (0 = all)
+// - data are results of Collect (nil entries are skipped)
+//
+// Postconditions:
+// - Returns the data of the repositories of all accounts, with contributions summed
+// - A repository listed by several accounts (same host, owner and name, case-insensitive) is counted once, as listed by the first account
+// - At most maxRepositories repositories are kept, in the order of data
+//
+// Invariants:
+// - Languages, commit histories and total commits are recomputed from the kept repositories
+// - Commit languages of the same commit key are merged by taking the larger weight (the same commit seen by two accounts)
+// - Commits attributed to a duplicate or dropped repository (Repository.TimeDistribution and CommitLanguages) are dropped with it
+// - Contributors with the same login (case-insensitive) are summed
+// - The inputs are not modified
+func Merge(maxRepositories int, data ...*Data) *Data {
+	merged := newData()
+	seen := make(map[string]bool)
+	contributorIndex := make(map[string]int)
+	limited := false
+	for _, d := range data {
+		if d == nil {
+			continue
+		}
+
+		for _, repo := range d.Repositories {
+			key := strings.ToLower(repo.fullName())
+			if seen[key] {
+				logger.Debug("Skipping duplicate repository %s", repo.fullName())
+				continue
+			}
+			if maxRepositories > 0 && len(merged.Repositories) >= maxRepositories {
+				limited = true
+				continue
+			}
+			seen[key] = true
+			merged.addRepository(repo)
+		}
+
+		// Commits attributed to repositories were added (or dropped) with their repository above
+		repoSources, repoCommits := repositoryCommitKeys(d.Repositories)
+		for source, counts := range d.TimeDistributions {
+			if repoSources[source] {
+				continue
+			}
+			if merged.TimeDistributions[source] == nil {
+				merged.TimeDistributions[source] = make(map[int]int, len(counts))
+			}
+			for hour, count := range counts {
+				merged.TimeDistributions[source][hour] += count
+			}
+		}
+		for commit, langs := range d.CommitLanguages {
+			if repoCommits[commit] {
+				continue
+			}
+			if merged.CommitLanguages[commit] == nil {
+				merged.CommitLanguages[commit] = make(map[string]int, len(langs))
+			}
+			for lang, weight := range langs {
+				merged.CommitLanguages[commit][lang] = max(merged.CommitLanguages[commit][lang], weight)
+			}
+		}
+		merged.TotalPullRequests += d.TotalPullRequests
+
+		for _, contributor := range d.Contributors {
+			index, ok := contributorIndex[strings.ToLower(contributor.Login)]
+			if !ok {
+				contributorIndex[strings.ToLower(contributor.Login)] = len(merged.Contributors)
+				merged.Contributors = append(merged.Contributors, contributor)
+				continue
+			}
+			merged.Contributors[index].Commits += contributor.Commits
+			merged.Contributors[index].PullRequests += contributor.PullRequests
+			merged.Contributors[index].Reviews += contributor.Reviews
+		}
+	}
+
+	if limited {
+		logger.Info("Limited to %d repositories", maxRepositories)
+	}
+	return merged
+}
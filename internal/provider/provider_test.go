@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeProvider provider returning fixed data
+type fakeProvider struct {
+	repos           []Repository
+	reposErr        error
+	contributions   Contributions
+	timestamps      []time.Time
+	commitLanguages map[string]map[string]int
+}
+
+func (f *fakeProvider) Name() string { return "fake" }
+
+func (f *fakeProvider) Viewer(ctx context.Context) (User, error) {
+	return User{Login: "octo", ID: "1"}, nil
+}
+
+func (f *fakeProvider) Repositories(ctx context.Context, user User, excludeForks bool) ([]Repository, error) {
+	return f.repos, f.reposErr
+}
+
+func (f *fakeProvider) Contributions(ctx context.Context, user User) (Contributions, error) {
+	return f.contributions, nil
+}
+
+func (f *fakeProvider) CommitTimestamps(ctx context.Context, user User, since, until time.Time) ([]time.Time, error) {
+	return f.timestamps, nil
+}
+
+func (f *fakeProvider) CommitLanguages(ctx context.Context, user User) (map[string]map[string]int, error) {
+	if f.commitLanguages == nil {
+		return nil, errors.New("unavailable")
+	}
+	return f.commitLanguages, nil
+}
+
+// TestCollect verifies that provider data is aggregated into the aggregation inputs
+func TestCollect(t *testing.T) {
+	day := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	p := &fakeProvider{
+		repos: []Repository{
+			{Owner: "octo", Name: "a", Stars: 3, Languages: map[string]int{"Go": 100}, CommitCount: 10, CommitDates: []time.Time{day, day.Add(time.Hour)}},
+			{Owner: "octo", Name: "b", Languages: map[string]int{"Go": 50, "Rust": 20}, CommitCount: 5},
+			{Owner: "octo", Name: "c", Languages: map[string]int{"Python": 999}, CommitCount: 7},
+		},
+		contributions: Contributions{PullRequests: 4},
+		timestamps:    []time.Time{day, day, day.Add(2 * time.Hour)},
+	}
+
+	// The repository limit is applied by Merge
+	data, err := Collect(context.Background(), p, User{Login: "octo"}, Options{MaxRepositories: 2})
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if data.LanguageTotals["Go"] != 150 || data.LanguageTotals["Rust"] != 20 || data.LanguageTotals["Python"] != 999 {
+		t.Errorf("LanguageTotals = %v, want Go=150, Rust=20, Python=999", data.LanguageTotals)
+	}
+	if got := data.CommitHistories["octo/a"]["2025-03-01"]; got != 2 {
+		t.Errorf("CommitHistories[octo/a][2025-03-01] = %d, want 2", got)
+	}
+	if _, ok := data.CommitHistories["octo/b"]; ok {
+		t.Error("repositories without commit dates should not have a history")
+	}
+	if got := data.TimeDistributions["fake"]; got[9] != 2 || got[11] != 1 {
+		t.Errorf("TimeDistributions = %v, want 9:2 and 11:1", data.TimeDistributions)
+	}
+	if data.TotalCommits != 22 || data.TotalPullRequests != 4 || len(data.Repositories) != 3 {
+		t.Errorf("totals = %d commits, %d PRs, %d repositories, want 22, 4, 3", data.TotalCommits, data.TotalPullRequests, len(data.Repositories))
+	}
+	if len(data.CommitLanguages) != 0 {
+		t.Errorf("CommitLanguages = %v, want empty after a failure", data.CommitLanguages)
+	}
+
+	p.reposErr = errors.New("boom")
+	if _, err := Collect(context.Background(), p, User{Login: "octo"}, Options{}); err == nil {
+		t.Error("Collect() should return error when repositories cannot be fetched")
+	}
+}
+
+// TestMerge verifies that the data of several accounts is merged with repositories deduplicated
+func TestMerge(t *testing.T) {
+	day := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	shared := Repository{Owner: "octo", Name: "a", Stars: 4, Languages: map[string]int{"Go": 10}, CommitCount: 3, CommitDates: []time.Time{day}}
+
+	a, err := Collect(context.Background(), &fakeProvider{
+		repos:           []Repository{shared},
+		contributions:   Contributions{PullRequests: 1},
+		timestamps:      []time.Time{day},
+		commitLanguages: map[string]map[string]int{"2025-03-01T09:00:00Z": {"Go": 2}},
+	}, User{Login: "octo"}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The second account also lists octo/a (e.g. as a collaborator), with a different case
+	duplicate := shared
+	duplicate.Owner = "Octo"
+	b, err := Collect(context.Background(), &fakeProvider{
+		repos:         []Repository{duplicate, {Owner: "work", Name: "b", Stars: 1, Languages: map[string]int{"Go": 5, "Ruby": 2}, CommitCount: 4, CommitDates: []time.Time{day, day}}},
+		contributions: Contributions{PullRequests: 2},
+		timestamps:    []time.Time{day, day},
+		commitLanguages: map[string]map[string]int{
+			"2025-03-01T09:00:00Z": {"Go": 2, "Ruby": 1},
+			"2025-03-02T09:00:00Z": {"Ruby": 1},
+		},
+	}, User{Login: "octo-work"}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a.Contributors = []Contributor{{Login: "alice", Commits: 3, Reviews: 1}}
+	b.Contributors = []Contributor{{Login: "Alice", Commits: 2, PullRequests: 1}, {Login: "bob", Commits: 1}}
+
+	merged := Merge(0, a, nil, b)
+
+	if len(merged.Repositories) != 2 {
+		t.Fatalf("Repositories = %+v, want octo/a once and work/b", merged.Repositories)
+	}
+	if merged.LanguageTotals["Go"] != 15 || merged.LanguageTotals["Ruby"] != 2 {
+		t.Errorf("LanguageTotals = %v, want Go=15, Ruby=2", merged.LanguageTotals)
+	}
+	if len(merged.CommitHistories) != 2 || merged.CommitHistories["octo/a"]["2025-03-01"] != 1 || merged.CommitHistories["work/b"]["2025-03-01"] != 2 {
+		t.Errorf("CommitHistories = %v, want one history per repository", merged.CommitHistories)
+	}
+	if merged.TimeDistributions["fake"][9] != 3 {
+		t.Errorf("TimeDistributions = %v, want commits of both accounts", merged.TimeDistributions)
+	}
+	if got := merged.CommitLanguages["2025-03-01T09:00:00Z"]; got["Go"] != 2 || got["Ruby"] != 1 {
+		t.Errorf("CommitLanguages = %v, want the same commit counted once", merged.CommitLanguages)
+	}
+	if len(merged.CommitLanguages) != 2 {
+		t.Errorf("CommitLanguages has %d commits, want 2", len(merged.CommitLanguages))
+	}
+	if merged.TotalCommits != 7 || merged.TotalPullRequests != 3 {
+		t.Errorf("totals = %d commits, %d PRs, want 7, 3", merged.TotalCommits, merged.TotalPullRequests)
+	}
+	if len(merged.Contributors) != 2 || merged.Contributors[0] != (Contributor{Login: "alice", Commits: 5, PullRequests: 1, Reviews: 1}) {
+		t.Errorf("Contributors = %+v, want alice summed and bob", merged.Contributors)
+	}
+	if len(a.Repositories) != 1 || a.LanguageTotals["Go"] != 10 || a.Contributors[0].Commits != 3 {
+		t.Error("Merge() should not modify its inputs")
+	}
+}
+
+// TestMerge_Hosts verifies that repositories with the same owner and name on different servers are kept apart
+func TestMerge_Hosts(t *testing.T) {
+	day := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	github := &Data{Repositories: []Repository{{Host: "github.com", Owner: "octo", Name: "app", Languages: map[string]int{"Go": 10}, CommitCount: 3, CommitDates: []time.Time{day}}}}
+	enterprise := &Data{Repositories: []Repository{
+		{Host: "ghe.example.com", Owner: "octo", Name: "app", Languages: map[string]int{"Go": 5}, CommitCount: 2, CommitDates: []time.Time{day}},
+		{Host: "GitHub.com", Owner: "Octo", Name: "app", Languages: map[string]int{"Go": 10}, CommitCount: 3},
+	}}
+
+	merged := Merge(0, github, enterprise)
+
+	if len(merged.Repositories) != 2 || merged.LanguageTotals["Go"] != 15 || merged.TotalCommits != 5 {
+		t.Errorf("Repositories = %+v, want octo/app of github.com and of ghe.example.com", merged.Repositories)
+	}
+	if len(merged.CommitHistories) != 2 {
+		t.Errorf("CommitHistories = %v, want one history per server", merged.CommitHistories)
+	}
+}
+
+// TestMerge_MaxRepositories verifies that the repository limit applies to the merged repositories
+func TestMerge_MaxRepositories(t *testing.T) {
+	a := &Data{Repositories: []Repository{
+		{Owner: "octo", Name: "a", Languages: map[string]int{"Go": 10}, CommitCount: 1},
+		{Owner: "octo", Name: "b", Languages: map[string]int{"Rust": 5}, CommitCount: 2},
+	}}
+	b := &Data{
+		Repositories: []Repository{
+			{Owner: "octo", Name: "a", Languages: map[string]int{"Go": 10}, CommitCount: 1},
+			{Owner: "work", Name: "c", Languages: map[string]int{"Ruby": 3}, CommitCount: 4, TimeDistribution: map[int]int{9: 4}},
+			{Owner: "work", Name: "d", Languages: map[string]int{"Python": 7}, CommitCount: 8},
+		},
+		TimeDistributions: map[string]map[int]int{"work/c": {9: 4}},
+	}
+
+	merged := Merge(3, a, b)
+
+	if len(merged.Repositories) != 3 || merged.Repositories[2].Name != "c" {
+		t.Fatalf("Repositories = %+v, want octo/a, octo/b and work/c", merged.Repositories)
+	}
+	if merged.LanguageTotals["Python"] != 0 || merged.TotalCommits != 7 {
+		t.Errorf("LanguageTotals = %v (%d commits), want work/d dropped", merged.LanguageTotals, merged.TotalCommits)
+	}
+	if merged.TimeDistributions["work/c"][9] != 4 {
+		t.Errorf("TimeDistributions = %v, want the commits of work/c", merged.TimeDistributions)
+	}
+}
+
+// TestWebHost verifies that API URLs are mapped to the host of their web server
+func TestWebHost(t *testing.T) {
+	tests := map[string]string{
+		"https://api.github.com/":            "github.com",
+		"https://GHE.example.com/api/v3/":    "ghe.example.com",
+		"https://gitlab.com/api/v4":          "gitlab.com",
+		"https://gitea.example.com:3000/api": "gitea.example.com",
+		"::":                                 "",
+	}
+	for apiURL, want := range tests {
+		if got := webHost(apiURL); got != want {
+			t.Errorf("webHost(%q) = %q, want %q", apiURL, got, want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// restClient JSON REST API client shared by the REST providers
+type restClient struct {
+	name    string // API name used in error messages (e.g. "GitLab")
+	baseURL string // API base URL without a trailing slash
+	client  *http.Client
+}
+
+// get sends a GET request and decodes the JSON response into v
+func (c *restClient) get(ctx context.Context, path string, query url.Values, v any) (http.Header, error) {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s API request failed: %w", c.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s API %s returned %s: %s", c.name, path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return nil, fmt.Errorf("failed to decode %s API response of %s: %w", c.name, path, err)
+	}
+	return resp.Header, nil
+}
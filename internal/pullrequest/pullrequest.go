@@ -0,0 +1,133 @@
+package pullrequest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v76/github"
+)
+
+// DefaultBranch default branch name used for pull requests
+const DefaultBranch = "update-gh-profile/metrics"
+
+// Options pull request to open or update
+type Options struct {
+	Owner string // Repository owner
+	Repo  string // Repository name
+	Head  string // Branch containing the changes
+	Base  string // Branch to merge into
+	Title string // Pull request title
+	Body  string // Pull request body
+}
+
+// Ensure opens a pull request, or updates the title and body of the open one
+//
+// Preconditions:
+// - ctx is a valid context.Context
+// - client is an authenticated GitHub API client
+// - opts contains the repository, branches, title and body
+//
+// Postconditions:
+// - Returns the pull request and true if it was created, false if it was updated
+// - Returns error if the API request fails
+//
+// Invariants:
+// - At most one open pull request exists for the head branch
+func Ensure(ctx context.Context, client *github.Client, opts Options) (*github.PullRequest, bool, error) {
+	if opts.Owner == "" || opts.Repo == "" || opts.Head == "" || opts.Base == "" {
+		return nil, false, fmt.Errorf("owner, repo, head and base are required")
+	}
+
+	existing, _, err := client.PullRequests.List(ctx, opts.Owner, opts.Repo, &github.PullRequestListOptions{
+		State: "open",
+		Head:  opts.Owner + ":" + opts.Head,
+		Base:  opts.Base,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+
+	if len(existing) > 0 {
+		pr, _, err := client.PullRequests.Edit(ctx, opts.Owner, opts.Repo, existing[0].GetNumber(), &github.PullRequest{
+			Title: github.Ptr(opts.Title),
+			Body:  github.Ptr(opts.Body),
+		})
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to update pull request #%d: %w", existing[0].GetNumber(), err)
+		}
+		return pr, false, nil
+	}
+
+	pr, _, err := client.PullRequests.Create(ctx, opts.Owner, opts.Repo, &github.NewPullRequest{
+		Title: github.Ptr(opts.Title),
+		Head:  github.Ptr(opts.Head),
+		Base:  github.Ptr(opts.Base),
+		Body:  github.Ptr(opts.Body),
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	return pr, true, nil
+}
+
+// BuildBody builds a pull request body from metric changes
+//
+// Preconditions:
+// - changes is a list of metric change descriptions (may be empty)
+//
+// Postconditions:
+// - Returns Markdown listing the changes
+func BuildBody(changes []string) string {
+	var body strings.Builder
+	body.WriteString("Automated update of GitHub profile metrics.\n\n")
+	body.WriteString("## Metric changes\n\n")
+
+	if len(changes) == 0 {
+		body.WriteString("Charts were regenerated.\n")
+		return body.String()
+	}
+
+	for _, change := range changes {
+		body.WriteString("- " + change + "\n")
+	}
+
+	return body.String()
+}
+
+// ParseRepository extracts owner and repository name
+//
+// Preconditions:
+// - value is "owner/repo" or a remote URL (e.g., "https://github.com/owner/repo.git", "git@github.com:owner/repo.git")
+//
+// Postconditions:
+// - Returns owner and repository name
+// - Returns error if the value cannot be parsed
+func ParseRepository(value string) (string, string, error) {
+	path := strings.TrimSpace(value)
+
+	switch {
+	case strings.Contains(path, "://"):
+		u, err := url.Parse(path)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid repository URL: %s", value)
+		}
+		path = u.Path
+	case strings.HasPrefix(path, "git@"):
+		_, after, ok := strings.Cut(path, ":")
+		if !ok {
+			return "", "", fmt.Errorf("invalid repository URL: %s", value)
+		}
+		path = after
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[len(parts)-2] == "" || parts[len(parts)-1] == "" {
+		return "", "", fmt.Errorf("invalid repository: %s", value)
+	}
+
+	return parts[len(parts)-2], parts[len(parts)-1], nil
+}
@@ -0,0 +1,147 @@
+package readme
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// SectionTag section tags found in README content
+type SectionTag struct {
+	Name       string            // Section name (e.g., "LANGUAGE_STATS")
+	StartTag   string            // Start tag as written in README (including attributes)
+	EndTag     string            // End tag as written in README
+	Attributes map[string]string // Attributes written in the start tag (e.g., {"theme": "light"})
+}
+
+// attributePattern matches key=value, key="quoted value" or bare key
+var attributePattern = regexp.MustCompile(`([A-Za-z0-9_-]+)(?:=(?:"([^"]*)"|'([^']*)'|(\S+)))?`)
+
+// FindSectionTag finds the start and end tags of a section, allowing attributes in the start tag
+//
+// Preconditions:
+// - content is the content of a loaded file
+// - sectionName is a section name (e.g., "LANGUAGE_STATS")
+//
+// Postconditions:
+// - Returns the tags as written in content and the parsed attributes
+// - Returns error if the tags are not found or are in the wrong order
+//
+// Invariants:
+// - Supports "<!-- START_NAME key=value -->" and "<!--START_SECTION:NAME key=value-->" formats
+// - Section name matching is case-insensitive
+func FindSectionTag(content, sectionName string) (*SectionTag, error) {
+	name := regexp.QuoteMeta(strings.ToUpper(strings.TrimSpace(sectionName)))
+
+	startPattern := regexp.MustCompile(`(?i)<!--\s*START(?:_SECTION:|_)` + name + `(?:\s+([^>]*?))?\s*-->`)
+	startLoc := startPattern.FindStringSubmatchIndex(content)
+	if startLoc == nil {
+		return nil, fmt.Errorf("start tag not found: %s", sectionName)
+	}
+
+	endPattern := regexp.MustCompile(`(?i)<!--\s*END(?:_SECTION:|_)` + name + `\s*-->`)
+	endLoc := endPattern.FindStringIndex(content[startLoc[1]:])
+	if endLoc == nil {
+		return nil, fmt.Errorf("end tag not found: %s", sectionName)
+	}
+
+	tag := &SectionTag{
+		Name:       strings.ToUpper(strings.TrimSpace(sectionName)),
+		StartTag:   content[startLoc[0]:startLoc[1]],
+		EndTag:     content[startLoc[1]+endLoc[0] : startLoc[1]+endLoc[1]],
+		Attributes: map[string]string{},
+	}
+	if startLoc[2] >= 0 {
+		tag.Attributes = ParseTagAttributes(content[startLoc[2]:startLoc[3]])
+	}
+
+	return tag, nil
+}
+
+// ParseTagAttributes parses attributes written in a section start tag
+//
+// Preconditions:
+// - attrs is an attribute string (e.g., `theme=light max=8 title="My Chart"`)
+//
+// Postconditions:
+// - Returns a map of attribute name to value
+// - Attributes without a value are set to "true"
+//
+// Invariants:
+// - Attribute names are converted to lowercase
+func ParseTagAttributes(attrs string) map[string]string {
+	result := make(map[string]string)
+
+	for _, match := range attributePattern.FindAllStringSubmatch(attrs, -1) {
+		key := strings.ToLower(match[1])
+		switch {
+		case match[2] != "":
+			result[key] = match[2]
+		case match[3] != "":
+			result[key] = match[3]
+		case match[4] != "":
+			result[key] = match[4]
+		default:
+			result[key] = "true"
+		}
+	}
+
+	return result
+}
+
+// ReadSectionAttributes reads attributes of the specified section in README.md
+//
+// Preconditions:
+// - readmePath is a README.md file path
+// - sectionName is a section name (e.g., "LANGUAGE_STATS")
+//
+// Postconditions:
+// - Returns the attributes of the section start tag
+// - Returns empty map if README.md or the section doesn't exist
+//
+// Invariants:
+// - README.md is not modified
+func ReadSectionAttributes(readmePath, sectionName string) map[string]string {
+	content, err := os.ReadFile(readmePath)
+	if err != nil {
+		return map[string]string{}
+	}
+
+	tag, err := FindSectionTag(string(content), sectionName)
+	if err != nil {
+		return map[string]string{}
+	}
+
+	return tag.Attributes
+}
+
+// UpdateSectionByName replaces the content of the named section, preserving start tag attributes
+//
+// Preconditions:
+// - readmePath is a valid README.md file path
+// - sectionName is a section name or HTML comment tag
+//
+// Postconditions:
+// - Content of the section is replaced with newContent
+// - Tags written in README.md (including attributes) are preserved
+// - Normalized tags are appended to the end of the file if the section doesn't exist
+//
+// Invariants:
+// - Returns error if file doesn't exist
+func UpdateSectionByName(readmePath, sectionName, newContent string) error {
+	startTag, endTag := NormalizeTags(sectionName)
+
+	if !strings.HasPrefix(sectionName, "<!--") {
+		content, err := os.ReadFile(readmePath)
+		if err != nil {
+			return fmt.Errorf("failed to read README file: %w", err)
+		}
+
+		if tag, err := FindSectionTag(string(content), sectionName); err == nil {
+			startTag, endTag = tag.StartTag, tag.EndTag
+		}
+	}
+
+	return UpdateSection(readmePath, startTag, endTag, newContent)
+}
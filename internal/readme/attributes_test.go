@@ -0,0 +1,147 @@
+package readme
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFindSectionTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		section   string
+		wantStart string
+		wantEnd   string
+		wantAttrs map[string]string
+		wantError bool
+	}{
+		{
+			name:      "正常系: 属性なしのタグ",
+			content:   "<!-- START_LANGUAGE_STATS -->\nold\n<!-- END_LANGUAGE_STATS -->",
+			section:   "LANGUAGE_STATS",
+			wantStart: "<!-- START_LANGUAGE_STATS -->",
+			wantEnd:   "<!-- END_LANGUAGE_STATS -->",
+			wantAttrs: map[string]string{},
+		},
+		{
+			name:      "正常系: START_SECTION 形式と属性",
+			content:   "<!--START_SECTION:LANGUAGE_STATS theme=light max=8 layout=donut-->\nold\n<!--END_SECTION:LANGUAGE_STATS-->",
+			section:   "LANGUAGE_STATS",
+			wantStart: "<!--START_SECTION:LANGUAGE_STATS theme=light max=8 layout=donut-->",
+			wantEnd:   "<!--END_SECTION:LANGUAGE_STATS-->",
+			wantAttrs: map[string]string{"theme": "light", "max": "8", "layout": "donut"},
+		},
+		{
+			name:      "正常系: 既存形式のタグに属性",
+			content:   "<!-- START_COMMIT_TIME theme=light -->\n<!-- END_COMMIT_TIME -->",
+			section:   "commit_time",
+			wantStart: "<!-- START_COMMIT_TIME theme=light -->",
+			wantEnd:   "<!-- END_COMMIT_TIME -->",
+			wantAttrs: map[string]string{"theme": "light"},
+		},
+		{
+			name:      "正常系: 前方一致する別セクションは無視する",
+			content:   "<!-- START_COMMIT_LANGUAGES_EXTRA -->\n<!-- END_COMMIT_LANGUAGES_EXTRA -->\n<!-- START_COMMIT_LANGUAGES -->\n<!-- END_COMMIT_LANGUAGES -->",
+			section:   "COMMIT_LANGUAGES",
+			wantStart: "<!-- START_COMMIT_LANGUAGES -->",
+			wantEnd:   "<!-- END_COMMIT_LANGUAGES -->",
+			wantAttrs: map[string]string{},
+		},
+		{
+			name:      "異常系: 終了タグがない",
+			content:   "<!-- START_LANGUAGE_STATS theme=light -->\nold",
+			section:   "LANGUAGE_STATS",
+			wantError: true,
+		},
+		{
+			name:      "異常系: 開始タグがない",
+			content:   "# README",
+			section:   "LANGUAGE_STATS",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, err := FindSectionTag(tt.content, tt.section)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("FindSectionTag() エラーが返されませんでした")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindSectionTag() エラー = %v", err)
+			}
+			if tag.StartTag != tt.wantStart {
+				t.Errorf("FindSectionTag() StartTag = %q, want %q", tag.StartTag, tt.wantStart)
+			}
+			if tag.EndTag != tt.wantEnd {
+				t.Errorf("FindSectionTag() EndTag = %q, want %q", tag.EndTag, tt.wantEnd)
+			}
+			if len(tag.Attributes) != len(tt.wantAttrs) {
+				t.Errorf("FindSectionTag() Attributes = %v, want %v", tag.Attributes, tt.wantAttrs)
+			}
+			for key, want := range tt.wantAttrs {
+				if tag.Attributes[key] != want {
+					t.Errorf("FindSectionTag() Attributes[%s] = %q, want %q", key, tag.Attributes[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseTagAttributes(t *testing.T) {
+	attrs := ParseTagAttributes(`Theme=light title="My Languages" label='a b' compact`)
+
+	want := map[string]string{
+		"theme":   "light",
+		"title":   "My Languages",
+		"label":   "a b",
+		"compact": "true",
+	}
+	for key, value := range want {
+		if attrs[key] != value {
+			t.Errorf("ParseTagAttributes()[%s] = %q, want %q", key, attrs[key], value)
+		}
+	}
+}
+
+func TestEmbedSVGWithCustomPath_PreservesAttributes(t *testing.T) {
+	testDir := t.TempDir()
+	testReadme := filepath.Join(testDir, "README.md")
+	initialContent := `# Test README
+
+<!--START_SECTION:LANGUAGE_STATS theme=light max=8-->
+old content
+<!--END_SECTION:LANGUAGE_STATS-->
+`
+	if err := os.WriteFile(testReadme, []byte(initialContent), 0644); err != nil {
+		t.Fatalf("テストファイルの作成に失敗しました: %v", err)
+	}
+
+	if attrs := ReadSectionAttributes(testReadme, "LANGUAGE_STATS"); attrs["max"] != "8" {
+		t.Errorf("ReadSectionAttributes() = %v, max=8 を期待", attrs)
+	}
+
+	if err := EmbedSVGWithCustomPath(testReadme, "language_chart.svg", "LANGUAGE_STATS", ""); err != nil {
+		t.Fatalf("EmbedSVGWithCustomPath() エラー = %v", err)
+	}
+
+	content, err := ReadFile(testReadme)
+	if err != nil {
+		t.Fatalf("ファイルの読み込みに失敗しました: %v", err)
+	}
+
+	if !strings.Contains(content, "<!--START_SECTION:LANGUAGE_STATS theme=light max=8-->\n![Language Chart](language_chart.svg)\n<!--END_SECTION:LANGUAGE_STATS-->") {
+		t.Errorf("EmbedSVGWithCustomPath() 属性付きタグのセクションが更新されていません: %q", content)
+	}
+	if strings.Contains(content, "old content") {
+		t.Errorf("EmbedSVGWithCustomPath() 古いコンテンツが残っています")
+	}
+	if strings.Contains(content, "<!-- START_LANGUAGE_STATS -->") {
+		t.Errorf("EmbedSVGWithCustomPath() 正規化タグが追記されました")
+	}
+}
@@ -90,10 +90,8 @@ func GenerateSVGMarkdown(svgFiles []string) string {
 //
 // Invariants:
 // - パスは相対パスまたは絶対パスが使用可能
+// - 開始タグに書かれた属性（例: <!-- START_LANGUAGE_STATS theme=light -->）は保持される
 func EmbedSVGWithCustomPath(readmePath, svgFilePath, sectionTag, description string) error {
-	// セクションタグを正規化
-	startTag, endTag := NormalizeTags(sectionTag)
-
 	// 説明文がない場合はファイル名から生成
 	if description == "" {
 		fileName := filepath.Base(svgFilePath)
@@ -105,8 +103,8 @@ func EmbedSVGWithCustomPath(readmePath, svgFilePath, sectionTag, description str
 	// Markdown 記法を生成
 	markdown := fmt.Sprintf("![%s](%s)", description, svgFilePath)
 
-	// セクションを更新
-	err := UpdateSection(readmePath, startTag, endTag, markdown)
+	// セクションを更新（開始タグの属性は保持される）
+	err := UpdateSectionByName(readmePath, sectionTag, markdown)
 	if err != nil {
 		return fmt.Errorf("SVG グラフの埋め込みに失敗しました: %w", err)
 	}
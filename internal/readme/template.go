@@ -0,0 +1,130 @@
+package readme
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+)
+
+// DefaultTemplateFile default file name of the README template
+const DefaultTemplateFile = "README.tmpl.md"
+
+// ChartNames chart names that can be referenced with {{ chart "NAME" }} in templates
+var ChartNames = []string{
+	"language",
+	"commit_history",
+	"commit_time",
+	"commit_languages",
+	"summary",
+	"contributors",
+	"leaderboard",
+}
+
+// TemplateData data passed to README templates
+type TemplateData struct {
+	Summary         aggregator.SummaryStats      // Summary statistics (stars, repositories, commits, PRs)
+	Languages       []aggregator.LanguageStat    // All ranked languages (excluded languages removed)
+	TopLanguages    []aggregator.LanguageStat    // Top ranked languages
+	CommitLanguages map[string]int               // Top languages by commit
+	Contributors    []aggregator.ContributorStat // Ranked contributors (organization mode only)
+	Leaderboard     *aggregator.Leaderboard      // Team leaderboard (nil if no team is configured)
+	LastUpdated     string                       // Formatted update timestamp
+	Charts          map[string]string            // Chart name -> image path (relative to README.md)
+}
+
+// RenderTemplate renders README template content with metrics data
+//
+// Preconditions:
+// - templateContent is Go text/template content
+// - data contains the metrics to embed
+//
+// Postconditions:
+// - Returns the rendered Markdown
+// - Returns error if the template cannot be parsed or executed
+//
+// Invariants:
+// - {{ chart "NAME" }} expands to Markdown image syntax, or empty string if the chart was not generated
+// - Unknown chart names are reported as errors
+func RenderTemplate(templateContent string, data TemplateData) (string, error) {
+	tmpl, err := template.New("readme").Funcs(templateFuncs(data)).Parse(templateContent)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse README template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render README template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// RenderTemplateFile renders a README template file and writes the result
+//
+// Preconditions:
+// - templatePath is a valid template file path
+// - outputPath is the README.md path to write
+//
+// Postconditions:
+// - outputPath is overwritten with the rendered template
+//
+// Invariants:
+// - Template file itself is not modified
+func RenderTemplateFile(templatePath, outputPath string, data TemplateData) error {
+	content, err := ReadFile(templatePath)
+	if err != nil {
+		return fmt.Errorf("failed to read README template: %w", err)
+	}
+
+	rendered, err := RenderTemplate(content, data)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outputPath, []byte(rendered), 0644); err != nil {
+		return fmt.Errorf("failed to write README file: %w", err)
+	}
+
+	return nil
+}
+
+// templateFuncs builds helper functions available in README templates
+func templateFuncs(data TemplateData) template.FuncMap {
+	return template.FuncMap{
+		"chart": func(name string) (string, error) {
+			if !isKnownChart(name) {
+				return "", fmt.Errorf("unknown chart: %s (available: %s)", name, strings.Join(ChartNames, ", "))
+			}
+			path, ok := data.Charts[name]
+			if !ok {
+				return "", nil
+			}
+			return fmt.Sprintf("![%s](%s)", chartDescription(path), path), nil
+		},
+		"percent": func(value float64) string {
+			return fmt.Sprintf("%.1f%%", value)
+		},
+	}
+}
+
+// isKnownChart checks if the chart name is supported
+func isKnownChart(name string) bool {
+	for _, chartName := range ChartNames {
+		if chartName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// chartDescription generates image alt text from the chart file name
+func chartDescription(path string) string {
+	description := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	description = strings.ReplaceAll(description, "_", " ")
+	return strings.Title(description)
+}
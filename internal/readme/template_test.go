@@ -0,0 +1,128 @@
+package readme
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+)
+
+func testTemplateData() TemplateData {
+	return TemplateData{
+		Summary: aggregator.SummaryStats{
+			TotalStars:        42,
+			RepositoryCount:   7,
+			TotalCommits:      1234,
+			TotalPullRequests: 56,
+		},
+		TopLanguages: []aggregator.LanguageStat{
+			{Language: "Go", Percentage: 62.5},
+			{Language: "Python", Percentage: 37.5},
+		},
+		LastUpdated: "2024-01-15T10:30:00Z",
+		Charts: map[string]string{
+			"language": "images/language_chart.svg",
+		},
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		want      string
+		wantError bool
+	}{
+		{
+			name:     "正常系: サマリー値の埋め込み",
+			template: "I have {{ .Summary.TotalStars }} stars across {{ .Summary.RepositoryCount }} repos.",
+			want:     "I have 42 stars across 7 repos.",
+		},
+		{
+			name:     "正常系: 言語の range",
+			template: "{{ range .TopLanguages }}- {{ .Language }} {{ percent .Percentage }}\n{{ end }}",
+			want:     "- Go 62.5%\n- Python 37.5%\n",
+		},
+		{
+			name:     "正常系: チャートの埋め込み",
+			template: `{{ chart "language" }}`,
+			want:     "![Language Chart](images/language_chart.svg)",
+		},
+		{
+			name:     "正常系: 生成されていないチャートは空文字",
+			template: `[{{ chart "commit_time" }}]`,
+			want:     "[]",
+		},
+		{
+			name:     "正常系: 更新日時",
+			template: "*Last updated: {{ .LastUpdated }}*",
+			want:     "*Last updated: 2024-01-15T10:30:00Z*",
+		},
+		{
+			name:      "異常系: 未知のチャート名",
+			template:  `{{ chart "unknown" }}`,
+			wantError: true,
+		},
+		{
+			name:      "異常系: 構文エラー",
+			template:  "{{ .Summary.TotalStars ",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := RenderTemplate(tt.template, testTemplateData())
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("RenderTemplate() エラーが返されませんでした")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RenderTemplate() エラー = %v", err)
+			}
+			if result != tt.want {
+				t.Errorf("RenderTemplate() = %q, want %q", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTemplateFile(t *testing.T) {
+	testDir := t.TempDir()
+	templatePath := filepath.Join(testDir, DefaultTemplateFile)
+	readmePath := filepath.Join(testDir, "README.md")
+
+	templateContent := "# Profile\n\n⭐ {{ .Summary.TotalStars }}\n\n{{ chart \"language\" }}\n"
+	if err := os.WriteFile(templatePath, []byte(templateContent), 0644); err != nil {
+		t.Fatalf("テンプレートファイルの作成に失敗しました: %v", err)
+	}
+
+	if err := RenderTemplateFile(templatePath, readmePath, testTemplateData()); err != nil {
+		t.Fatalf("RenderTemplateFile() エラー = %v", err)
+	}
+
+	content, err := ReadFile(readmePath)
+	if err != nil {
+		t.Fatalf("ファイルの読み込みに失敗しました: %v", err)
+	}
+
+	if !strings.Contains(content, "⭐ 42") {
+		t.Errorf("RenderTemplateFile() 値が埋め込まれていません: %q", content)
+	}
+	if !strings.Contains(content, "![Language Chart](images/language_chart.svg)") {
+		t.Errorf("RenderTemplateFile() チャートが埋め込まれていません: %q", content)
+	}
+
+	// テンプレート自体は変更されない
+	original, err := ReadFile(templatePath)
+	if err != nil {
+		t.Fatalf("テンプレートの読み込みに失敗しました: %v", err)
+	}
+	if original != templateContent {
+		t.Errorf("RenderTemplateFile() テンプレートが変更されました")
+	}
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	ghgraphql "github.com/watsumi/update-gh-profile/internal/graphql"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/hasura/go-graphql-client"
+	"golang.org/x/oauth2"
+)
+
+// GitHub API endpoints of github.com
+const (
+	DefaultRESTURL    = "https://api.github.com/"
+	DefaultGraphQLURL = "https://api.github.com/graphql"
+)
+
+// ClientConfig connection settings of the GitHub API
+// The zero value (with a token) connects to github.com
+type ClientConfig struct {
+	Token       string             // GitHub token
+	TokenSource oauth2.TokenSource // Source of refreshed tokens, e.g. GitHub App installation tokens (nil = Token is used as is)
+	RESTURL     string             // REST API base URL (empty = derived from GraphQLURL, or DefaultRESTURL)
+	GraphQLURL  string             // GraphQL API URL (empty = derived from RESTURL, or DefaultGraphQLURL)
+	CABundle    string             // PEM file of CA certificates trusted in addition to the system roots (empty = system roots only)
+	Proxy       string             // Proxy URL (empty = HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables)
+}
+
+// Endpoints returns the REST and GraphQL API URLs
+//
+// Preconditions:
+// - RESTURL and GraphQLURL are empty or absolute URLs
+//
+// Postconditions:
+// - Returns the REST API base URL (with a trailing slash) and the GraphQL API URL
+//
+// Invariants:
+// - An unset URL is derived from the other one (GHES: HOST/api/v3 and HOST/api/graphql, otherwise: BASE and BASE/graphql)
+func (c ClientConfig) Endpoints() (string, string) {
+	restURL := strings.TrimSuffix(c.RESTURL, "/")
+	graphQLURL := strings.TrimSuffix(c.GraphQLURL, "/")
+
+	switch {
+	case restURL == "" && graphQLURL == "":
+		return DefaultRESTURL, DefaultGraphQLURL
+	case graphQLURL == "":
+		if strings.HasSuffix(restURL, "/api/v3") {
+			graphQLURL = strings.TrimSuffix(restURL, "/v3") + "/graphql"
+		} else {
+			graphQLURL = restURL + "/graphql"
+		}
+	case restURL == "":
+		if strings.HasSuffix(graphQLURL, "/api/graphql") {
+			restURL = strings.TrimSuffix(graphQLURL, "/graphql") + "/v3"
+		} else {
+			restURL = strings.TrimSuffix(graphQLURL, "/graphql")
+		}
+	}
+
+	return restURL + "/", graphQLURL
+}
+
+// HTTPClient creates an HTTP client authenticated with the token
+//
+// Preconditions:
+// - CABundle is empty or a readable PEM file
+// - Proxy is empty or a valid URL
+//
+// Postconditions:
+// - Returns a client sending the token as a bearer token (no authentication if Token and TokenSource are unset)
+// - TokenSource takes precedence over Token, so tokens refreshed during the run are used
+// - Returns error if the CA bundle or the proxy URL is invalid
+//
+// Invariants:
+// - Requests time out after 60 seconds, connections after 30 seconds
+func (c ClientConfig) HTTPClient() (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout: 30 * time.Second,
+	}).DialContext
+
+	if c.Proxy != "" {
+		proxyURL, err := url.Parse(c.Proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL: %s", c.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if c.CABundle != "" {
+		pem, err := os.ReadFile(c.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", c.CABundle)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	var roundTripper http.RoundTripper = transport
+	switch {
+	case c.TokenSource != nil:
+		roundTripper = &oauth2.Transport{Source: c.TokenSource, Base: transport}
+	case c.Token != "":
+		roundTripper = &oauth2.Transport{
+			Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.Token}),
+			Base:   transport,
+		}
+	}
+
+	return &http.Client{
+		Transport: roundTripper,
+		Timeout:   60 * time.Second,
+	}, nil
+}
+
+// RESTClient creates a GitHub REST API client
+//
+// Preconditions:
+// - c is a valid ClientConfig (see HTTPClient)
+//
+// Postconditions:
+// - Returns a client using the REST API URL of Endpoints
+// - Returns error if the configuration is invalid
+//
+// Invariants:
+// - The URL is used as is (e.g., "https://github.example.com/api/v3" or a local test server)
+func (c ClientConfig) RESTClient() (*github.Client, error) {
+	httpClient, err := c.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	client := github.NewClient(httpClient)
+	restURL, _ := c.Endpoints()
+	baseURL, err := url.Parse(restURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API URL: %w", err)
+	}
+	client.BaseURL = baseURL
+
+	return client, nil
+}
+
+// newGraphQLClient creates a GraphQL client
+func newGraphQLClient(api ClientConfig) (*graphql.Client, error) {
+	if api.Token == "" && api.TokenSource == nil {
+		return nil, fmt.Errorf("authentication token is not set")
+	}
+
+	httpClient, err := api.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	_, graphQLURL := api.Endpoints()
+	return ghgraphql.NewGraphQLClient(graphQLURL, httpClient), nil
+}
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+// TestClientConfig_Endpoints verifies that unset API URLs are derived from the other one
+func TestClientConfig_Endpoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      ClientConfig
+		wantREST    string
+		wantGraphQL string
+	}{
+		{
+			name:        "github.com by default",
+			config:      ClientConfig{},
+			wantREST:    DefaultRESTURL,
+			wantGraphQL: DefaultGraphQLURL,
+		},
+		{
+			name:        "GHES REST URL",
+			config:      ClientConfig{RESTURL: "https://github.example.com/api/v3"},
+			wantREST:    "https://github.example.com/api/v3/",
+			wantGraphQL: "https://github.example.com/api/graphql",
+		},
+		{
+			name:        "GHES GraphQL URL",
+			config:      ClientConfig{GraphQLURL: "https://github.example.com/api/graphql"},
+			wantREST:    "https://github.example.com/api/v3/",
+			wantGraphQL: "https://github.example.com/api/graphql",
+		},
+		{
+			name:        "github.com layout",
+			config:      ClientConfig{RESTURL: "http://127.0.0.1:8080/"},
+			wantREST:    "http://127.0.0.1:8080/",
+			wantGraphQL: "http://127.0.0.1:8080/graphql",
+		},
+		{
+			name:        "both URLs",
+			config:      ClientConfig{RESTURL: "https://rest.example.com", GraphQLURL: "https://graphql.example.com/query"},
+			wantREST:    "https://rest.example.com/",
+			wantGraphQL: "https://graphql.example.com/query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rest, graphQL := tt.config.Endpoints()
+			if rest != tt.wantREST || graphQL != tt.wantGraphQL {
+				t.Errorf("Endpoints() = %q, %q, want %q, %q", rest, graphQL, tt.wantREST, tt.wantGraphQL)
+			}
+		})
+	}
+}
+
+// TestClientConfig_GraphQL verifies that GraphQL fetchers use the configured endpoint and token
+func TestClientConfig_GraphQL(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/graphql" {
+			t.Errorf("request path = %s, want /api/graphql", r.URL.Path)
+		}
+		authorization = r.Header.Get("Authorization")
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"viewer": map[string]any{"login": "octo", "id": "U_1"}},
+		})
+	}))
+	defer server.Close()
+
+	api := ClientConfig{Token: "test-token", RESTURL: server.URL + "/api/v3"}
+	login, id, err := FetchViewerGenerated(context.Background(), api)
+	if err != nil {
+		t.Fatalf("FetchViewerGenerated() error = %v", err)
+	}
+	if login != "octo" || id != "U_1" {
+		t.Errorf("FetchViewerGenerated() = %q, %q, want octo, U_1", login, id)
+	}
+	if authorization != "Bearer test-token" {
+		t.Errorf("Authorization = %q, want bearer token", authorization)
+	}
+}
+
+// countingTokenSource token source returning a new token on every call
+type countingTokenSource struct{ calls int }
+
+func (s *countingTokenSource) Token() (*oauth2.Token, error) {
+	s.calls++
+	return &oauth2.Token{AccessToken: fmt.Sprintf("token-%d", s.calls)}, nil
+}
+
+// TestClientConfig_TokenSource verifies that the token source takes precedence over the static token
+func TestClientConfig_TokenSource(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"viewer": map[string]any{"login": "octo", "id": "U_1"}},
+		})
+	}))
+	defer server.Close()
+
+	api := ClientConfig{Token: "static-token", TokenSource: &countingTokenSource{}, GraphQLURL: server.URL}
+	for range 2 {
+		if _, _, err := FetchViewerGenerated(context.Background(), api); err != nil {
+			t.Fatalf("FetchViewerGenerated() error = %v", err)
+		}
+	}
+	if len(authorizations) != 2 || authorizations[0] != "Bearer token-1" || authorizations[1] != "Bearer token-2" {
+		t.Errorf("Authorization = %v, want a token of the source per request", authorizations)
+	}
+
+	// A token source alone is enough to create a GraphQL client
+	api.Token = ""
+	if _, _, err := FetchViewerGenerated(context.Background(), api); err != nil {
+		t.Errorf("FetchViewerGenerated() without static token error = %v", err)
+	}
+}
+
+// TestClientConfig_REST verifies that the REST client uses the configured base URL
+func TestClientConfig_REST(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/users/octo" {
+			t.Errorf("request path = %s, want /api/v3/users/octo", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"login": "octo"})
+	}))
+	defer server.Close()
+
+	client, err := ClientConfig{Token: "test-token", GraphQLURL: server.URL + "/api/graphql"}.RESTClient()
+	if err != nil {
+		t.Fatalf("RESTClient() error = %v", err)
+	}
+	user, _, err := client.Users.Get(context.Background(), "octo")
+	if err != nil {
+		t.Fatalf("Users.Get() error = %v", err)
+	}
+	if user.GetLogin() != "octo" {
+		t.Errorf("login = %q, want octo", user.GetLogin())
+	}
+}
+
+// TestClientConfig_CABundle verifies that certificates of the CA bundle are trusted
+func TestClientConfig_CABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, certPEM, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	untrusted, err := ClientConfig{}.HTTPClient()
+	if err != nil {
+		t.Fatalf("HTTPClient() error = %v", err)
+	}
+	if _, err := untrusted.Get(server.URL); err == nil {
+		t.Error("request should fail without the CA bundle")
+	}
+
+	trusted, err := ClientConfig{CABundle: bundle}.HTTPClient()
+	if err != nil {
+		t.Fatalf("HTTPClient() error = %v", err)
+	}
+	resp, err := trusted.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request with CA bundle failed: %v", err)
+	}
+	resp.Body.Close()
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(empty, []byte("not a certificate"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (ClientConfig{CABundle: empty}).HTTPClient(); err == nil {
+		t.Error("HTTPClient() should return error for a bundle without certificates")
+	}
+}
+
+// TestClientConfig_Proxy verifies that requests are sent through the proxy
+func TestClientConfig_Proxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+	}))
+	defer proxy.Close()
+
+	client, err := ClientConfig{Proxy: proxy.URL}.HTTPClient()
+	if err != nil {
+		t.Fatalf("HTTPClient() error = %v", err)
+	}
+	resp, err := client.Get("http://github.example.com/api/v3/")
+	if err != nil {
+		t.Fatalf("request through proxy failed: %v", err)
+	}
+	resp.Body.Close()
+	if proxiedHost != "github.example.com" {
+		t.Errorf("proxied host = %q, want github.example.com", proxiedHost)
+	}
+
+	if _, err := (ClientConfig{Proxy: "://invalid"}).HTTPClient(); err == nil {
+		t.Error("HTTPClient() should return error for an invalid proxy URL")
+	}
+}
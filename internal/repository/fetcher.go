@@ -3,7 +3,8 @@ package repository
 import (
 	"context"
 	"fmt"
-	"log"
+
+	"github.com/watsumi/update-gh-profile/internal/logger"
 
 	"github.com/google/go-github/v76/github"
 )
@@ -32,7 +33,7 @@ func FetchUserRepositories(ctx context.Context, client *github.Client, username
 		return nil, fmt.Errorf("this tool can only fetch repositories owned by the authenticated user")
 	}
 
-	log.Printf("Fetching repository list: authenticated user=%s, exclude forks=%v", username, excludeForks)
+	logger.Info("Fetching repository list: authenticated user=%s, exclude forks=%v", username, excludeForks)
 
 	// Options for pagination
 	// Type: "all" allows fetching private repositories as well
@@ -73,21 +74,21 @@ func FetchUserRepositories(ctx context.Context, client *github.Client, username
 		allRepos = append(allRepos, repos...)
 
 		// Debug: output pagination information to log
-		log.Printf("Fetched repositories: %d (total: %d)", len(repos), len(allRepos))
-		log.Printf("Pagination info: current page=%d (manual=%d), next page=%d, last page=%d, PerPage=%d",
+		logger.Info("Fetched repositories: %d (total: %d)", len(repos), len(allRepos))
+		logger.Info("Pagination info: current page=%d (manual=%d), next page=%d, last page=%d, PerPage=%d",
 			opt.Page, pageNum, resp.NextPage, resp.LastPage, opt.PerPage)
 
 		// Check if there is a next page (using common function)
 		paginationResult := CheckPagination(resp, len(repos), opt.PerPage)
 
 		if !paginationResult.HasNextPage {
-			log.Printf("No next page, ending pagination (fetched: %d, PerPage: %d)", len(repos), opt.PerPage)
+			logger.Info("No next page, ending pagination (fetched: %d, PerPage: %d)", len(repos), opt.PerPage)
 			break
 		}
 
 		// Check max page count (before advancing to next page)
 		if pageNum >= MaxPages {
-			log.Printf("Warning: reached max page count (%d). Ending pagination (total: %d)", MaxPages, len(allRepos))
+			logger.Warning("reached max page count (%d). Ending pagination (total: %d)", MaxPages, len(allRepos))
 			break
 		}
 
@@ -95,10 +96,10 @@ func FetchUserRepositories(ctx context.Context, client *github.Client, username
 		if paginationResult.NextPageNum != 0 {
 			pageNum = paginationResult.NextPageNum - 1 // -1 because pageNum is incremented in the loop
 		}
-		log.Printf("Fetching next page (page number: %d / max: %d)...", pageNum+1, MaxPages)
+		logger.Info("Fetching next page (page number: %d / max: %d)...", pageNum+1, MaxPages)
 	}
 
-	log.Printf("Finished fetching all repositories: %d", len(allRepos))
+	logger.Info("Finished fetching all repositories: %d", len(allRepos))
 
 	// Exclude fork repositories
 	if excludeForks {
@@ -110,7 +111,7 @@ func FetchUserRepositories(ctx context.Context, client *github.Client, username
 			}
 		}
 		allRepos = filteredRepos
-		log.Printf("Repositories after excluding forks: %d", len(allRepos))
+		logger.Info("Repositories after excluding forks: %d", len(allRepos))
 	}
 
 	return allRepos, nil
@@ -168,7 +169,7 @@ func FetchCommits(ctx context.Context, client *github.Client, owner, repo string
 		return nil, err
 	}
 
-	log.Printf("Fetching commit history for repository %s/%s...", owner, repo)
+	logger.Info("Fetching commit history for repository %s/%s...", owner, repo)
 
 	// Options for pagination
 	opt := &github.CommitsListOptions{
@@ -202,19 +203,19 @@ func FetchCommits(ctx context.Context, client *github.Client, owner, repo string
 		// Add fetched commits
 		allCommits = append(allCommits, commits...)
 
-		log.Printf("Fetched commits: %d (total: %d)", len(commits), len(allCommits))
+		logger.Info("Fetched commits: %d (total: %d)", len(commits), len(allCommits))
 
 		// Check if there is a next page (using common function)
 		paginationResult := CheckPagination(resp, len(commits), opt.PerPage)
 
 		if !paginationResult.HasNextPage {
-			log.Printf("No next page, ending pagination (fetched: %d)", len(commits))
+			logger.Info("No next page, ending pagination (fetched: %d)", len(commits))
 			break
 		}
 
 		// Check max page count
 		if pageNum >= MaxPages {
-			log.Printf("Warning: reached max page count (%d). Ending pagination (total: %d)", MaxPages, len(allCommits))
+			logger.Warning("reached max page count (%d). Ending pagination (total: %d)", MaxPages, len(allCommits))
 			break
 		}
 
@@ -224,7 +225,7 @@ func FetchCommits(ctx context.Context, client *github.Client, owner, repo string
 		}
 	}
 
-	log.Printf("Finished fetching commit history for repository %s/%s: %d", owner, repo, len(allCommits))
+	logger.Info("Finished fetching commit history for repository %s/%s: %d", owner, repo, len(allCommits))
 	return allCommits, nil
 }
 
@@ -260,7 +261,7 @@ func FetchCommitHistory(ctx context.Context, client *github.Client, owner, repo
 		history[dateStr]++
 	}
 
-	log.Printf("Finished aggregating commit history for repository %s/%s: %d days", owner, repo, len(history))
+	logger.Info("Finished aggregating commit history for repository %s/%s: %d days", owner, repo, len(history))
 	return history, nil
 }
 
@@ -296,7 +297,7 @@ func FetchCommitTimeDistribution(ctx context.Context, client *github.Client, own
 		distribution[hour]++
 	}
 
-	log.Printf("Finished aggregating commit time distribution for repository %s/%s: %d time slots", owner, repo, len(distribution))
+	logger.Info("Finished aggregating commit time distribution for repository %s/%s: %d time slots", owner, repo, len(distribution))
 	return distribution, nil
 }
 
@@ -318,7 +319,7 @@ func FetchCommitLanguages(ctx context.Context, client *github.Client, owner, rep
 		return nil, err
 	}
 
-	log.Printf("Fetching language usage per commit for repository %s/%s...", owner, repo)
+	logger.Info("Fetching language usage per commit for repository %s/%s...", owner, repo)
 
 	// First, fetch commit list
 	commits, err := FetchCommits(ctx, client, owner, repo)
@@ -336,7 +337,7 @@ func FetchCommitLanguages(ctx context.Context, client *github.Client, owner, rep
 		maxCommits = len(commits)
 	}
 
-	log.Printf("Fetching language information per commit: processing %d commits", maxCommits)
+	logger.Info("Fetching language information per commit: processing %d commits", maxCommits)
 
 	for i := 0; i < maxCommits; i++ {
 		commit := commits[i]
@@ -345,7 +346,7 @@ func FetchCommitLanguages(ctx context.Context, client *github.Client, owner, rep
 		// Fetch detailed information for commit (including changed file information)
 		commitDetail, resp, err := client.Repositories.GetCommit(ctx, owner, repo, sha, &github.ListOptions{})
 		if err != nil {
-			log.Printf("Warning: failed to fetch details for commit %s: %v", sha[:7], err)
+			logger.Warning("failed to fetch details for commit %s: %v", sha[:7], err)
 			continue
 		}
 
@@ -373,11 +374,11 @@ func FetchCommitLanguages(ctx context.Context, client *github.Client, owner, rep
 
 		// Output progress to log (every 10 commits)
 		if (i+1)%10 == 0 {
-			log.Printf("Progress: processed %d/%d commits", i+1, maxCommits)
+			logger.Info("Progress: processed %d/%d commits", i+1, maxCommits)
 		}
 	}
 
-	log.Printf("Finished fetching language usage per commit for repository %s/%s: %d commits", owner, repo, len(commitLanguages))
+	logger.Info("Finished fetching language usage per commit for repository %s/%s: %d commits", owner, repo, len(commitLanguages))
 	return commitLanguages, nil
 }
 
@@ -399,7 +400,7 @@ func FetchPullRequests(ctx context.Context, client *github.Client, owner, repo s
 		return 0, err
 	}
 
-	log.Printf("Fetching pull request count for repository %s/%s...", owner, repo)
+	logger.Info("Fetching pull request count for repository %s/%s...", owner, repo)
 
 	// Options for pagination
 	// State: "all" fetches PRs in all states (open, closed)
@@ -435,19 +436,19 @@ func FetchPullRequests(ctx context.Context, client *github.Client, owner, repo s
 		// Add fetched PR count
 		totalCount += len(pullRequests)
 
-		log.Printf("Fetched pull requests: %d (total: %d)", len(pullRequests), totalCount)
+		logger.Info("Fetched pull requests: %d (total: %d)", len(pullRequests), totalCount)
 
 		// Check if there is a next page (using common function)
 		paginationResult := CheckPagination(resp, len(pullRequests), opt.PerPage)
 
 		if !paginationResult.HasNextPage {
-			log.Printf("No next page, ending pagination (fetched: %d)", len(pullRequests))
+			logger.Info("No next page, ending pagination (fetched: %d)", len(pullRequests))
 			break
 		}
 
 		// Check max page count
 		if pageNum >= MaxPages {
-			log.Printf("Warning: reached max page count (%d). Ending pagination (total: %d)", MaxPages, totalCount)
+			logger.Warning("reached max page count (%d). Ending pagination (total: %d)", MaxPages, totalCount)
 			break
 		}
 
@@ -457,6 +458,6 @@ func FetchPullRequests(ctx context.Context, client *github.Client, owner, repo s
 		}
 	}
 
-	log.Printf("Finished fetching pull request count for repository %s/%s: %d", owner, repo, totalCount)
+	logger.Info("Finished fetching pull request count for repository %s/%s: %d", owner, repo, totalCount)
 	return totalCount, nil
 }
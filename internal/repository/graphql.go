@@ -3,12 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
-	"net"
-	"net/http"
 	"time"
-
-	"github.com/hasura/go-graphql-client"
-	"golang.org/x/oauth2"
 )
 
 // GraphQLQueries GraphQL query definitions
@@ -59,10 +54,12 @@ query ReposPerLanguage($login: String!, $endCursor: String) {
 }`
 
 	// QueryUserDetails Query to fetch user details
+	// Contributions cover [$from, $to] (the past year when omitted)
 	QueryUserDetails = `
-query UserDetails($login: String!) {
+query UserDetails($login: String!, $from: DateTime, $to: DateTime) {
   user(login: $login) {
     id
+    login
     name
     email
     createdAt
@@ -81,7 +78,10 @@ query UserDetails($login: String!) {
         }
       }
     }
-    contributionsCollection {
+    contributionsCollection(from: $from, to: $to) {
+      totalCommitContributions
+      totalPullRequestContributions
+      totalPullRequestReviewContributions
       contributionCalendar {
         weeks {
           contributionDays {
@@ -188,41 +188,9 @@ query Viewer {
 }`
 )
 
-// newGraphQLClient creates a GraphQL client
-func newGraphQLClient(ctx context.Context, token string) (*graphql.Client, error) {
-	if token == "" {
-		return nil, fmt.Errorf("authentication token is not set")
-	}
-
-	// Create HTTP client with OAuth2 token
-	httpClient := oauth2.NewClient(ctx, oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	))
-
-	// Set timeout for HTTP client
-	// Read timeout: 60 seconds
-	httpClient.Timeout = 60 * time.Second
-
-	// Set connection timeout for Transport
-	if oauth2Transport, ok := httpClient.Transport.(*oauth2.Transport); ok {
-		if baseTransport, ok := oauth2Transport.Base.(*http.Transport); ok {
-			if baseTransport.DialContext == nil {
-				baseTransport.DialContext = (&net.Dialer{
-					Timeout: 30 * time.Second,
-				}).DialContext
-			}
-		}
-	}
-
-	// Create GraphQL client
-	graphqlClient := graphql.NewClient("https://api.github.com/graphql", httpClient)
-
-	return graphqlClient, nil
-}
-
 // FetchViewer fetches authenticated user information using GraphQL
-func FetchViewer(ctx context.Context, token string) (string, string, error) {
-	graphqlClient, err := newGraphQLClient(ctx, token)
+func FetchViewer(ctx context.Context, api ClientConfig) (string, string, error) {
+	graphqlClient, err := newGraphQLClient(api)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to create GraphQL client: %w", err)
 	}
@@ -245,8 +213,8 @@ func FetchViewer(ctx context.Context, token string) (string, string, error) {
 }
 
 // FetchRepositoriesWithGraphQL fetches repository information in bulk using GraphQL
-func FetchRepositoriesWithGraphQL(ctx context.Context, token string, username string, excludeForks bool) ([]*RepositoryGraphQLData, error) {
-	graphqlClient, err := newGraphQLClient(ctx, token)
+func FetchRepositoriesWithGraphQL(ctx context.Context, api ClientConfig, username string, excludeForks bool) ([]*RepositoryGraphQLData, error) {
+	graphqlClient, err := newGraphQLClient(api)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
 	}
@@ -329,14 +297,34 @@ type OwnerData struct {
 }
 
 // FetchUserDetailsWithGraphQL fetches user details using GraphQL
-func FetchUserDetailsWithGraphQL(ctx context.Context, token string, username string) (*UserDetailsGraphQLData, error) {
-	graphqlClient, err := newGraphQLClient(ctx, token)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
+func FetchUserDetailsWithGraphQL(ctx context.Context, api ClientConfig, username string) (*UserDetailsGraphQLData, error) {
+	return fetchUserDetails(ctx, api, map[string]interface{}{
+		"login": username,
+	})
+}
 
-	variables := map[string]interface{}{
+// FetchUserDetailsForPeriodWithGraphQL fetches user details with the contributions in [since, until] using GraphQL
+//
+// Preconditions:
+// - username is a GitHub user name
+// - until - since is at most one year (limit of contributionsCollection)
+//
+// Postconditions:
+// - Returns the user details, ContributionsCollection covers [since, until]
+// - Returns error if the user does not exist
+func FetchUserDetailsForPeriodWithGraphQL(ctx context.Context, api ClientConfig, username string, since, until time.Time) (*UserDetailsGraphQLData, error) {
+	return fetchUserDetails(ctx, api, map[string]interface{}{
 		"login": username,
+		"from":  since.Format(time.RFC3339),
+		"to":    until.Format(time.RFC3339),
+	})
+}
+
+// fetchUserDetails executes QueryUserDetails with the variables
+func fetchUserDetails(ctx context.Context, api ClientConfig, variables map[string]interface{}) (*UserDetailsGraphQLData, error) {
+	graphqlClient, err := newGraphQLClient(api)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
 	}
 
 	var response struct {
@@ -354,6 +342,7 @@ func FetchUserDetailsWithGraphQL(ctx context.Context, token string, username str
 // UserDetailsGraphQLData User details data fetched from GraphQL
 type UserDetailsGraphQLData struct {
 	ID           string `json:"id"`
+	Login        string `json:"login"`
 	Name         string `json:"name"`
 	Email        string `json:"email"`
 	CreatedAt    string `json:"createdAt"`
@@ -371,7 +360,10 @@ type UserDetailsGraphQLData struct {
 		} `json:"nodes"`
 	} `json:"repositories"`
 	ContributionsCollection struct {
-		ContributionCalendar struct {
+		TotalCommitContributions            int `json:"totalCommitContributions"`
+		TotalPullRequestContributions       int `json:"totalPullRequestContributions"`
+		TotalPullRequestReviewContributions int `json:"totalPullRequestReviewContributions"`
+		ContributionCalendar                struct {
 			Weeks []struct {
 				ContributionDays []struct {
 					ContributionCount int    `json:"contributionCount"`
@@ -390,8 +382,8 @@ type UserDetailsGraphQLData struct {
 
 // FetchCommitLanguagesWithGraphQL fetches language usage per commit using GraphQL
 // Uses multiple language information per repository to fetch more languages
-func FetchCommitLanguagesWithGraphQL(ctx context.Context, token string, username string) (map[string]map[string]int, error) {
-	graphqlClient, err := newGraphQLClient(ctx, token)
+func FetchCommitLanguagesWithGraphQL(ctx context.Context, api ClientConfig, username string) (map[string]map[string]int, error) {
+	graphqlClient, err := newGraphQLClient(api)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
 	}
@@ -496,9 +488,9 @@ func FetchCommitLanguagesWithGraphQL(ctx context.Context, token string, username
 	return commitLanguages, nil
 }
 
-// FetchProductiveTimeWithGraphQL fetches commit time distribution using GraphQL
-func FetchProductiveTimeWithGraphQL(ctx context.Context, token string, username, userID string, since, until time.Time) (map[int]int, error) {
-	graphqlClient, err := newGraphQLClient(ctx, token)
+// FetchCommitTimestampsWithGraphQL fetches the timestamps of commits contributed by the user using GraphQL
+func FetchCommitTimestampsWithGraphQL(ctx context.Context, api ClientConfig, username, userID string, since, until time.Time) ([]time.Time, error) {
+	graphqlClient, err := newGraphQLClient(api)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
 	}
@@ -539,18 +531,16 @@ func FetchProductiveTimeWithGraphQL(ctx context.Context, token string, username,
 		return nil, fmt.Errorf("failed to execute GraphQL query: %w", err)
 	}
 
-	// Aggregate commit count by time slot
-	timeDistribution := make(map[int]int)
+	var timestamps []time.Time
 	for _, repoContrib := range response.User.ContributionsCollection.CommitContributionsByRepository {
 		for _, edge := range repoContrib.Repository.DefaultBranchRef.Target.History.Edges {
 			committedDate, err := time.Parse(time.RFC3339, edge.Node.CommittedDate)
 			if err != nil {
 				continue
 			}
-			hour := committedDate.UTC().Hour()
-			timeDistribution[hour]++
+			timestamps = append(timestamps, committedDate)
 		}
 	}
 
-	return timeDistribution, nil
+	return timestamps, nil
 }
@@ -11,8 +11,8 @@ import (
 )
 
 // FetchViewerGenerated fetches authenticated user information using generated types
-func FetchViewerGenerated(ctx context.Context, token string) (string, string, error) {
-	graphqlClient, err := newGraphQLClient(ctx, token)
+func FetchViewerGenerated(ctx context.Context, api ClientConfig) (string, string, error) {
+	graphqlClient, err := newGraphQLClient(api)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to create GraphQL client: %w", err)
 	}
@@ -27,8 +27,8 @@ func FetchViewerGenerated(ctx context.Context, token string) (string, string, er
 }
 
 // FetchRepositoriesWithGraphQLGenerated fetches repository information in bulk using generated types
-func FetchRepositoriesWithGraphQLGenerated(ctx context.Context, token string, username string, excludeForks bool) ([]*RepositoryGraphQLData, error) {
-	graphqlClient, err := newGraphQLClient(ctx, token)
+func FetchRepositoriesWithGraphQLGenerated(ctx context.Context, api ClientConfig, username string, excludeForks bool) ([]*RepositoryGraphQLData, error) {
+	graphqlClient, err := newGraphQLClient(api)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
 	}
@@ -280,8 +280,8 @@ func FetchRepositoriesWithGraphQLGenerated(ctx context.Context, token string, us
 }
 
 // FetchUserDetailsWithGraphQLGenerated fetches user details using generated types
-func FetchUserDetailsWithGraphQLGenerated(ctx context.Context, token string, username string) (*UserDetailsGraphQLData, error) {
-	graphqlClient, err := newGraphQLClient(ctx, token)
+func FetchUserDetailsWithGraphQLGenerated(ctx context.Context, api ClientConfig, username string) (*UserDetailsGraphQLData, error) {
+	graphqlClient, err := newGraphQLClient(api)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
 	}
@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Organization GraphQL query definitions
+var (
+	// QueryOrganization Query to fetch organization information
+	QueryOrganization = `
+query Organization($login: String!) {
+  organization(login: $login) {
+    login
+    id
+  }
+}`
+
+	// QueryOrganizationRepositories Query to fetch the public repositories of an organization
+	// commits is the total commit count of the default branch, recent holds the commits since $since
+	QueryOrganizationRepositories = `
+query OrganizationRepositories($login: String!, $since: GitTimestamp!, $endCursor: String) {
+  organization(login: $login) {
+    repositories(first: 50, after: $endCursor, privacy: PUBLIC, orderBy: {field: PUSHED_AT, direction: DESC}) {
+      nodes {
+        name
+        owner {
+          login
+        }
+        isFork
+        stargazerCount
+        languages(first: 20, orderBy: {field: SIZE, direction: DESC}) {
+          edges {
+            node {
+              name
+            }
+            size
+          }
+        }
+        defaultBranchRef {
+          target {
+            ... on Commit {
+              commits: history {
+                totalCount
+              }
+              recent: history(first: 100, since: $since) {
+                nodes {
+                  committedDate
+                }
+              }
+            }
+          }
+        }
+      }
+      pageInfo {
+        endCursor
+        hasNextPage
+      }
+    }
+  }
+}`
+
+	// QueryOrganizationMembers Query to fetch the contributions of organization members to the organization
+	QueryOrganizationMembers = `
+query OrganizationMembers($login: String!, $orgId: ID!, $from: DateTime!, $to: DateTime!, $endCursor: String) {
+  organization(login: $login) {
+    membersWithRole(first: 25, after: $endCursor) {
+      nodes {
+        login
+        contributionsCollection(organizationID: $orgId, from: $from, to: $to) {
+          totalCommitContributions
+          totalPullRequestContributions
+          totalPullRequestReviewContributions
+        }
+      }
+      pageInfo {
+        endCursor
+        hasNextPage
+      }
+    }
+  }
+}`
+)
+
+// OrganizationRepositoryGraphQLData Organization repository data fetched from GraphQL
+type OrganizationRepositoryGraphQLData struct {
+	Name           string    `json:"name"`
+	Owner          OwnerData `json:"owner"`
+	IsFork         bool      `json:"isFork"`
+	StargazerCount int       `json:"stargazerCount"`
+	Languages      struct {
+		Edges []struct {
+			Node struct {
+				Name string `json:"name"`
+			} `json:"node"`
+			Size int `json:"size"`
+		} `json:"edges"`
+	} `json:"languages"`
+	DefaultBranchRef struct {
+		Target struct {
+			Commits struct {
+				TotalCount int `json:"totalCount"`
+			} `json:"commits"`
+			Recent struct {
+				Nodes []struct {
+					CommittedDate string `json:"committedDate"`
+				} `json:"nodes"`
+			} `json:"recent"`
+		} `json:"target"`
+	} `json:"defaultBranchRef"`
+}
+
+// OrganizationMemberGraphQLData Organization member data fetched from GraphQL
+type OrganizationMemberGraphQLData struct {
+	Login                   string `json:"login"`
+	ContributionsCollection struct {
+		TotalCommitContributions            int `json:"totalCommitContributions"`
+		TotalPullRequestContributions       int `json:"totalPullRequestContributions"`
+		TotalPullRequestReviewContributions int `json:"totalPullRequestReviewContributions"`
+	} `json:"contributionsCollection"`
+}
+
+// FetchOrganizationWithGraphQL fetches the login and node ID of an organization using GraphQL
+//
+// Preconditions:
+// - login is an organization name
+//
+// Postconditions:
+// - Returns the login and node ID of the organization
+// - Returns error if the organization does not exist
+func FetchOrganizationWithGraphQL(ctx context.Context, api ClientConfig, login string) (string, string, error) {
+	graphqlClient, err := newGraphQLClient(api)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create GraphQL client: %w", err)
+	}
+
+	variables := map[string]interface{}{
+		"login": login,
+	}
+
+	var response struct {
+		Organization *struct {
+			Login string `json:"login"`
+			ID    string `json:"id"`
+		} `json:"organization"`
+	}
+
+	err = graphqlClient.Exec(ctx, QueryOrganization, &response, variables)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to execute GraphQL query: %w", err)
+	}
+	if response.Organization == nil {
+		return "", "", fmt.Errorf("organization not found: %s", login)
+	}
+
+	return response.Organization.Login, response.Organization.ID, nil
+}
+
+// FetchOrganizationRepositoriesWithGraphQL fetches the public repositories of an organization using GraphQL
+// Commits of the default branch since the given time are included (up to 100 per repository)
+func FetchOrganizationRepositoriesWithGraphQL(ctx context.Context, api ClientConfig, login string, since time.Time) ([]*OrganizationRepositoryGraphQLData, error) {
+	graphqlClient, err := newGraphQLClient(api)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
+	}
+
+	var allRepos []*OrganizationRepositoryGraphQLData
+	var endCursor *string
+
+	for {
+		variables := map[string]interface{}{
+			"login": login,
+			"since": since.Format(time.RFC3339),
+		}
+		if endCursor != nil {
+			variables["endCursor"] = *endCursor
+		}
+
+		var response struct {
+			Organization struct {
+				Repositories struct {
+					Nodes    []*OrganizationRepositoryGraphQLData `json:"nodes"`
+					PageInfo struct {
+						EndCursor   string `json:"endCursor"`
+						HasNextPage bool   `json:"hasNextPage"`
+					} `json:"pageInfo"`
+				} `json:"repositories"`
+			} `json:"organization"`
+		}
+
+		err := graphqlClient.Exec(ctx, QueryOrganizationRepositories, &response, variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute GraphQL query: %w", err)
+		}
+
+		allRepos = append(allRepos, response.Organization.Repositories.Nodes...)
+
+		if !response.Organization.Repositories.PageInfo.HasNextPage {
+			break
+		}
+		endCursor = &response.Organization.Repositories.PageInfo.EndCursor
+	}
+
+	return allRepos, nil
+}
+
+// FetchOrganizationMembersWithGraphQL fetches the contributions of organization members in [since, until] using GraphQL
+// Only contributions to repositories of the organization are counted
+// Members with private membership are only listed when the token can read the organization members
+func FetchOrganizationMembersWithGraphQL(ctx context.Context, api ClientConfig, login, orgID string, since, until time.Time) ([]*OrganizationMemberGraphQLData, error) {
+	graphqlClient, err := newGraphQLClient(api)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
+	}
+
+	var allMembers []*OrganizationMemberGraphQLData
+	var endCursor *string
+
+	for {
+		variables := map[string]interface{}{
+			"login": login,
+			"orgId": orgID,
+			"from":  since.Format(time.RFC3339),
+			"to":    until.Format(time.RFC3339),
+		}
+		if endCursor != nil {
+			variables["endCursor"] = *endCursor
+		}
+
+		var response struct {
+			Organization struct {
+				MembersWithRole struct {
+					Nodes    []*OrganizationMemberGraphQLData `json:"nodes"`
+					PageInfo struct {
+						EndCursor   string `json:"endCursor"`
+						HasNextPage bool   `json:"hasNextPage"`
+					} `json:"pageInfo"`
+				} `json:"membersWithRole"`
+			} `json:"organization"`
+		}
+
+		err := graphqlClient.Exec(ctx, QueryOrganizationMembers, &response, variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute GraphQL query: %w", err)
+		}
+
+		allMembers = append(allMembers, response.Organization.MembersWithRole.Nodes...)
+
+		if !response.Organization.MembersWithRole.PageInfo.HasNextPage {
+			break
+		}
+		endCursor = &response.Organization.MembersWithRole.PageInfo.EndCursor
+	}
+
+	return allMembers, nil
+}
@@ -2,7 +2,8 @@ package repository
 
 import (
 	"fmt"
-	"log"
+
+	"github.com/watsumi/update-gh-profile/internal/logger"
 
 	"github.com/google/go-github/v76/github"
 )
@@ -29,7 +30,7 @@ type PaginationResult struct {
 func CheckPagination(resp *github.Response, currentCount, perPage int) PaginationResult {
 	// 1. If resp.NextPage is not 0, use information from GitHub API response headers
 	if resp.NextPage != 0 {
-		log.Printf("Next page (%d) detected from response headers", resp.NextPage)
+		logger.Info("Next page (%d) detected from response headers", resp.NextPage)
 		return PaginationResult{
 			HasNextPage: true,
 			NextPageNum: resp.NextPage,
@@ -38,7 +39,7 @@ func CheckPagination(resp *github.Response, currentCount, perPage int) Paginatio
 
 	// 2. Even if NextPage is 0, if retrieved count reaches PerPage, try next page
 	if currentCount >= perPage {
-		log.Printf("Warning: Could not get next page info from response headers, but retrieved count (%d) reached PerPage (%d), so trying next page", currentCount, perPage)
+		logger.Warning("Could not get next page info from response headers, but retrieved count (%d) reached PerPage (%d), so trying next page", currentCount, perPage)
 		return PaginationResult{
 			HasNextPage: true,
 			NextPageNum: 0, // Manually increment
@@ -47,7 +48,7 @@ func CheckPagination(resp *github.Response, currentCount, perPage int) Paginatio
 
 	// 3. If retrieved count is 30 (GitHub API default), there might be a next page
 	if currentCount == DefaultPageSize {
-		log.Printf("Warning: Could not get next page info from response headers, but retrieved count is %d (GitHub API default), so trying next page", DefaultPageSize)
+		logger.Warning("Could not get next page info from response headers, but retrieved count is %d (GitHub API default), so trying next page", DefaultPageSize)
 		return PaginationResult{
 			HasNextPage: true,
 			NextPageNum: 0, // Manually increment
@@ -56,7 +57,7 @@ func CheckPagination(resp *github.Response, currentCount, perPage int) Paginatio
 
 	// 4. If retrieved count is 0, determine there is no next page
 	if currentCount == 0 {
-		log.Printf("Retrieved 0 items, ending pagination")
+		logger.Info("Retrieved 0 items, ending pagination")
 		return PaginationResult{
 			HasNextPage: false,
 			NextPageNum: 0,
@@ -2,9 +2,10 @@ package repository
 
 import (
 	"context"
-	"log"
 	"time"
 
+	"github.com/watsumi/update-gh-profile/internal/logger"
+
 	"github.com/google/go-github/v76/github"
 )
 
@@ -35,7 +36,7 @@ func HandleRateLimit(ctx context.Context, resp *github.Response) error {
 		waitDuration += time.Second
 
 		if waitDuration > 0 {
-			log.Printf("Rate limit reached. Waiting %v...", waitDuration)
+			logger.Info("Rate limit reached. Waiting %v...", waitDuration)
 			select {
 			case <-ctx.Done():
 				return ctx.Err() // Context was cancelled
@@ -45,7 +46,7 @@ func HandleRateLimit(ctx context.Context, resp *github.Response) error {
 		}
 	} else {
 		// Log remaining requests if rate limit has room
-		log.Printf("Rate limit remaining: %d/%d (reset time: %v)",
+		logger.Info("Rate limit remaining: %d/%d (reset time: %v)",
 			resp.Rate.Remaining,
 			resp.Rate.Limit,
 			resp.Rate.Reset.Time.Format("2006-01-02 15:04:05"))
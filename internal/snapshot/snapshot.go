@@ -0,0 +1,420 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+)
+
+// DefaultFile default file name of the metrics snapshot (saved next to README.md)
+const DefaultFile = ".profile-metrics.json"
+
+// FormatVersion version of the snapshot file format
+const FormatVersion = 2
+
+// Snapshot metrics snapshot saved with each commit to detect material changes
+type Snapshot struct {
+	Version                int                          `json:"version"`
+	Languages              []aggregator.LanguageStat    `json:"languages"`
+	CommitHistory          map[string]int               `json:"commit_history"`
+	CommitTimeDistribution map[int]int                  `json:"commit_time_distribution"`
+	CommitLanguages        map[string]int               `json:"commit_languages"`
+	Summary                aggregator.SummaryStats      `json:"summary"`
+	Contributors           []aggregator.ContributorStat `json:"contributors,omitempty"`
+	Leaderboard            *aggregator.Leaderboard      `json:"leaderboard,omitempty"`
+}
+
+// Thresholds minimum differences regarded as material changes
+type Thresholds struct {
+	LanguagePercentage float64 // Minimum change of a language percentage (percentage points)
+	Count              int     // Minimum change of counts (stars, repositories, commits, PRs)
+}
+
+// DefaultThresholds returns the default thresholds
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		LanguagePercentage: 0.5,
+		Count:              1,
+	}
+}
+
+// FromMetrics creates a snapshot from aggregated metrics
+//
+// Preconditions:
+// - metrics is aggregated metrics
+//
+// Postconditions:
+// - Returns a snapshot containing the metrics shown in charts
+//
+// Invariants:
+// - Byte counts are not compared, so they are omitted
+func FromMetrics(metrics *aggregator.AggregatedMetrics) *Snapshot {
+	languages := make([]aggregator.LanguageStat, 0, len(metrics.Languages))
+	for _, lang := range metrics.Languages {
+		languages = append(languages, aggregator.LanguageStat{
+			Language:   lang.Language,
+			Percentage: math.Round(lang.Percentage*100) / 100,
+		})
+	}
+
+	return &Snapshot{
+		Version:                FormatVersion,
+		Languages:              languages,
+		CommitHistory:          copyMap(metrics.CommitHistory),
+		CommitTimeDistribution: copyMap(metrics.CommitTimeDistribution),
+		CommitLanguages:        copyMap(metrics.CommitLanguages),
+		Summary:                metrics.SummaryStats,
+		Contributors:           slices.Clone(metrics.Contributors),
+		Leaderboard:            copyLeaderboard(metrics.Leaderboard),
+	}
+}
+
+// Load loads a snapshot file
+//
+// Preconditions:
+// - path is a snapshot file path
+//
+// Postconditions:
+// - Returns the loaded snapshot
+// - Returns nil (without error) if the file doesn't exist
+// - Returns error if the file cannot be parsed
+//
+// Invariants:
+// - The file is not modified
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+
+	return &s, nil
+}
+
+// Save saves a snapshot file
+//
+// Preconditions:
+// - path is a valid file path
+// - s is a snapshot
+//
+// Postconditions:
+// - Snapshot is written as indented JSON
+//
+// Invariants:
+// - The same snapshot always produces the same file (map keys are sorted)
+// - Directories are automatically created if they don't exist
+func Save(path string, s *Snapshot) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// Compare lists material changes between the previous and current snapshots
+//
+// Preconditions:
+// - previous is the previously saved snapshot (nil if there is none)
+// - current is the snapshot of the current metrics
+// - thresholds are the minimum differences regarded as material
+//
+// Postconditions:
+// - Returns descriptions of material changes (empty if nothing material changed)
+//
+// Invariants:
+// - A missing previous snapshot or a different format version is always a material change
+// - Dates that only moved out of the commit history window are not material
+// - Result order is deterministic
+func Compare(previous, current *Snapshot, thresholds Thresholds) []string {
+	if previous == nil {
+		return []string{"no previous snapshot"}
+	}
+	if previous.Version != current.Version {
+		return []string{fmt.Sprintf("snapshot format changed (%d -> %d)", previous.Version, current.Version)}
+	}
+
+	var changes []string
+
+	// Summary statistics
+	counts := []struct {
+		name          string
+		before, after int
+	}{
+		{"stars", previous.Summary.TotalStars, current.Summary.TotalStars},
+		{"repositories", previous.Summary.RepositoryCount, current.Summary.RepositoryCount},
+		{"commits", previous.Summary.TotalCommits, current.Summary.TotalCommits},
+		{"pull requests", previous.Summary.TotalPullRequests, current.Summary.TotalPullRequests},
+	}
+	for _, c := range counts {
+		if exceedsCount(c.after-c.before, thresholds) {
+			changes = append(changes, fmt.Sprintf("%s: %d -> %d", c.name, c.before, c.after))
+		}
+	}
+
+	// Language ranking
+	changes = append(changes, compareLanguages(previous.Languages, current.Languages, thresholds)...)
+
+	// Commit history (only dates in both snapshots and new dates)
+	for _, date := range sortedKeys(current.CommitHistory) {
+		before, existed := previous.CommitHistory[date]
+		after := current.CommitHistory[date]
+		if !existed && after == 0 {
+			continue
+		}
+		if exceedsCount(after-before, thresholds) {
+			changes = append(changes, fmt.Sprintf("commits on %s: %d -> %d", date, before, after))
+		}
+	}
+
+	// Commit time distribution
+	diff := 0
+	for hour := 0; hour < 24; hour++ {
+		diff += absInt(current.CommitTimeDistribution[hour] - previous.CommitTimeDistribution[hour])
+	}
+	if exceedsCount(diff, thresholds) {
+		changes = append(changes, fmt.Sprintf("commit time distribution changed by %d commits", diff))
+	}
+
+	// Top languages by commit
+	for _, lang := range sortedKeys(mergeKeys(previous.CommitLanguages, current.CommitLanguages)) {
+		before, after := previous.CommitLanguages[lang], current.CommitLanguages[lang]
+		if exceedsCount(after-before, thresholds) {
+			changes = append(changes, fmt.Sprintf("commit language %s: %d -> %d", lang, before, after))
+		}
+	}
+
+	// Top contributors (organization mode)
+	changes = append(changes, compareContributors("contributor", previous.Contributors, current.Contributors, thresholds)...)
+
+	// Team leaderboard
+	changes = append(changes, compareLeaderboards(previous.Leaderboard, current.Leaderboard, thresholds)...)
+
+	return changes
+}
+
+// Delta differences of summary statistics between two snapshots
+type Delta struct {
+	Stars        int
+	Repositories int
+	Commits      int
+	PullRequests int
+}
+
+// Deltas computes differences of summary statistics
+//
+// Preconditions:
+// - previous is the previously saved snapshot (nil if there is none)
+// - current is the snapshot of the current metrics
+//
+// Postconditions:
+// - Returns current minus previous for each summary statistic
+//
+// Invariants:
+// - All differences are zero if there is no previous snapshot
+func Deltas(previous, current *Snapshot) Delta {
+	if previous == nil {
+		return Delta{}
+	}
+
+	return Delta{
+		Stars:        current.Summary.TotalStars - previous.Summary.TotalStars,
+		Repositories: current.Summary.RepositoryCount - previous.Summary.RepositoryCount,
+		Commits:      current.Summary.TotalCommits - previous.Summary.TotalCommits,
+		PullRequests: current.Summary.TotalPullRequests - previous.Summary.TotalPullRequests,
+	}
+}
+
+// String returns non-zero differences (e.g. "stars +3, commits +42")
+// Returns empty string if nothing changed
+func (d Delta) String() string {
+	var parts []string
+	for _, c := range []struct {
+		name string
+		diff int
+	}{
+		{"stars", d.Stars},
+		{"repositories", d.Repositories},
+		{"commits", d.Commits},
+		{"pull requests", d.PullRequests},
+	} {
+		if c.diff != 0 {
+			parts = append(parts, fmt.Sprintf("%s %+d", c.name, c.diff))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// compareLanguages compares language rankings
+func compareLanguages(previous, current []aggregator.LanguageStat, thresholds Thresholds) []string {
+	var changes []string
+
+	previousPercentages := make(map[string]float64, len(previous))
+	for _, lang := range previous {
+		previousPercentages[lang.Language] = lang.Percentage
+	}
+
+	for i, lang := range current {
+		before, existed := previousPercentages[lang.Language]
+		if !existed {
+			changes = append(changes, fmt.Sprintf("language added: %s", lang.Language))
+			continue
+		}
+		if math.Abs(lang.Percentage-before) >= thresholds.LanguagePercentage && lang.Percentage != before {
+			changes = append(changes, fmt.Sprintf("language %s: %.1f%% -> %.1f%%", lang.Language, before, lang.Percentage))
+		} else if i < len(previous) && previous[i].Language != lang.Language {
+			changes = append(changes, fmt.Sprintf("language rank changed: %s is #%d", lang.Language, i+1))
+		}
+	}
+
+	currentLanguages := make(map[string]bool, len(current))
+	for _, lang := range current {
+		currentLanguages[lang.Language] = true
+	}
+	for _, lang := range previous {
+		if !currentLanguages[lang.Language] {
+			changes = append(changes, fmt.Sprintf("language removed: %s", lang.Language))
+		}
+	}
+
+	return changes
+}
+
+// compareLeaderboards compares team leaderboards (nil if no team is configured)
+func compareLeaderboards(previous, current *aggregator.Leaderboard, thresholds Thresholds) []string {
+	switch {
+	case previous == nil && current == nil:
+		return nil
+	case previous == nil:
+		return []string{"leaderboard added"}
+	case current == nil:
+		return []string{"leaderboard removed"}
+	}
+
+	var changes []string
+	if previous.Days != current.Days {
+		changes = append(changes, fmt.Sprintf("leaderboard period: %d -> %d days", previous.Days, current.Days))
+	}
+	if !slices.Equal(previous.Failed, current.Failed) {
+		changes = append(changes, fmt.Sprintf("leaderboard failed users: [%s] -> [%s]", strings.Join(previous.Failed, ", "), strings.Join(current.Failed, ", ")))
+	}
+	return append(changes, compareContributors("leaderboard", previous.Entries, current.Entries, thresholds)...)
+}
+
+// compareContributors compares contributor rankings
+// A contributor changed if the commits, pull requests and reviews changed by at least the count threshold in total
+func compareContributors(name string, previous, current []aggregator.ContributorStat, thresholds Thresholds) []string {
+	var changes []string
+
+	previousStats := make(map[string]aggregator.ContributorStat, len(previous))
+	for _, stat := range previous {
+		previousStats[stat.Login] = stat
+	}
+
+	for i, stat := range current {
+		before, existed := previousStats[stat.Login]
+		if !existed {
+			changes = append(changes, fmt.Sprintf("%s added: %s", name, stat.Login))
+			continue
+		}
+		diff := absInt(stat.Commits-before.Commits) + absInt(stat.PullRequests-before.PullRequests) + absInt(stat.Reviews-before.Reviews)
+		if exceedsCount(diff, thresholds) {
+			changes = append(changes, fmt.Sprintf("%s %s: %d -> %d contributions", name, stat.Login, before.Total(), stat.Total()))
+		} else if i < len(previous) && previous[i].Login != stat.Login {
+			changes = append(changes, fmt.Sprintf("%s rank changed: %s is #%d", name, stat.Login, i+1))
+		}
+	}
+
+	currentLogins := make(map[string]bool, len(current))
+	for _, stat := range current {
+		currentLogins[stat.Login] = true
+	}
+	for _, stat := range previous {
+		if !currentLogins[stat.Login] {
+			changes = append(changes, fmt.Sprintf("%s removed: %s", name, stat.Login))
+		}
+	}
+
+	return changes
+}
+
+// exceedsCount checks if a count difference is material
+func exceedsCount(diff int, thresholds Thresholds) bool {
+	if diff == 0 {
+		return false
+	}
+	return absInt(diff) >= thresholds.Count
+}
+
+// absInt returns the absolute value of an integer
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// copyMap copies a map (returns an empty map for nil)
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	result := make(map[K]V, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
+
+// copyLeaderboard copies a leaderboard (returns nil for nil)
+func copyLeaderboard(l *aggregator.Leaderboard) *aggregator.Leaderboard {
+	if l == nil {
+		return nil
+	}
+	return &aggregator.Leaderboard{
+		Days:    l.Days,
+		Entries: slices.Clone(l.Entries),
+		Failed:  slices.Clone(l.Failed),
+	}
+}
+
+// mergeKeys returns a map containing the keys of both maps
+func mergeKeys(a, b map[string]int) map[string]int {
+	merged := copyMap(a)
+	for k := range b {
+		merged[k] = 0
+	}
+	return merged
+}
+
+// sortedKeys returns the keys of a map in ascending order
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,251 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+)
+
+func baseSnapshot() *Snapshot {
+	return FromMetrics(&aggregator.AggregatedMetrics{
+		Languages: []aggregator.LanguageStat{
+			{Language: "Go", Bytes: 6000, Percentage: 60.0},
+			{Language: "Python", Bytes: 4000, Percentage: 40.0},
+		},
+		CommitHistory:          map[string]int{"2024-01-01": 3, "2024-01-02": 5},
+		CommitTimeDistribution: map[int]int{9: 4, 21: 4},
+		CommitLanguages:        map[string]int{"Go": 5, "Python": 3},
+		SummaryStats:           aggregator.SummaryStats{TotalStars: 10, RepositoryCount: 3, TotalCommits: 8, TotalPullRequests: 2},
+	})
+}
+
+// withContributors adds top contributors to a snapshot
+func withContributors(s *Snapshot) *Snapshot {
+	s.Contributors = []aggregator.ContributorStat{
+		{Login: "alice", Commits: 10, PullRequests: 2, Reviews: 3},
+		{Login: "bob", Commits: 4, PullRequests: 1},
+	}
+	return s
+}
+
+// withLeaderboard adds a team leaderboard to a snapshot
+func withLeaderboard(s *Snapshot) *Snapshot {
+	s.Leaderboard = &aggregator.Leaderboard{
+		Days: 30,
+		Entries: []aggregator.ContributorStat{
+			{Login: "alice", Commits: 10, PullRequests: 2, Reviews: 3},
+			{Login: "bob", Commits: 4, PullRequests: 1},
+		},
+		Failed: []string{"ghost"},
+	}
+	return s
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name       string
+		previous   *Snapshot
+		modify     func(s *Snapshot)
+		thresholds Thresholds
+		want       []string
+	}{
+		{
+			name:       "No previous snapshot",
+			previous:   nil,
+			modify:     func(s *Snapshot) {},
+			thresholds: DefaultThresholds(),
+			want:       []string{"no previous snapshot"},
+		},
+		{
+			name:       "No changes",
+			previous:   baseSnapshot(),
+			modify:     func(s *Snapshot) {},
+			thresholds: DefaultThresholds(),
+			want:       nil,
+		},
+		{
+			name:     "Star count changed",
+			previous: baseSnapshot(),
+			modify: func(s *Snapshot) {
+				s.Summary.TotalStars = 11
+			},
+			thresholds: DefaultThresholds(),
+			want:       []string{"stars: 10 -> 11"},
+		},
+		{
+			name:     "Star count change below threshold",
+			previous: baseSnapshot(),
+			modify: func(s *Snapshot) {
+				s.Summary.TotalStars = 11
+			},
+			thresholds: Thresholds{LanguagePercentage: 0.5, Count: 5},
+			want:       nil,
+		},
+		{
+			name:     "Small language percentage change",
+			previous: baseSnapshot(),
+			modify: func(s *Snapshot) {
+				s.Languages[0].Percentage = 60.2
+				s.Languages[1].Percentage = 39.8
+			},
+			thresholds: DefaultThresholds(),
+			want:       nil,
+		},
+		{
+			name:     "Large language percentage change",
+			previous: baseSnapshot(),
+			modify: func(s *Snapshot) {
+				s.Languages[0].Percentage = 65.0
+				s.Languages[1].Percentage = 35.0
+			},
+			thresholds: DefaultThresholds(),
+			want:       []string{"language Go: 60.0% -> 65.0%", "language Python: 40.0% -> 35.0%"},
+		},
+		{
+			name:     "Language added",
+			previous: baseSnapshot(),
+			modify: func(s *Snapshot) {
+				s.Languages = append(s.Languages, aggregator.LanguageStat{Language: "Rust", Percentage: 0.1})
+			},
+			thresholds: DefaultThresholds(),
+			want:       []string{"language added: Rust"},
+		},
+		{
+			name:     "Commit history window moved",
+			previous: baseSnapshot(),
+			modify: func(s *Snapshot) {
+				delete(s.CommitHistory, "2024-01-01")
+				s.CommitHistory["2024-01-03"] = 0
+			},
+			thresholds: DefaultThresholds(),
+			want:       nil,
+		},
+		{
+			name:     "Only contributors changed",
+			previous: withContributors(baseSnapshot()),
+			modify: func(s *Snapshot) {
+				withContributors(s)
+				s.Contributors[0].Commits += 2
+				s.Contributors = append(s.Contributors, aggregator.ContributorStat{Login: "carol", Commits: 1})
+			},
+			thresholds: DefaultThresholds(),
+			want:       []string{"contributor alice: 15 -> 17 contributions", "contributor added: carol"},
+		},
+		{
+			name:     "Only the leaderboard changed",
+			previous: withLeaderboard(baseSnapshot()),
+			modify: func(s *Snapshot) {
+				withLeaderboard(s)
+				s.Leaderboard.Entries[1].Reviews += 3
+				s.Leaderboard.Failed = nil
+			},
+			thresholds: DefaultThresholds(),
+			want:       []string{"leaderboard failed users: [ghost] -> []", "leaderboard bob: 5 -> 8 contributions"},
+		},
+		{
+			name:     "Leaderboard rank changed",
+			previous: withLeaderboard(baseSnapshot()),
+			modify: func(s *Snapshot) {
+				withLeaderboard(s)
+				s.Leaderboard.Entries[0], s.Leaderboard.Entries[1] = s.Leaderboard.Entries[1], s.Leaderboard.Entries[0]
+			},
+			thresholds: DefaultThresholds(),
+			want:       []string{"leaderboard rank changed: bob is #1", "leaderboard rank changed: alice is #2"},
+		},
+		{
+			name:     "Leaderboard added",
+			previous: baseSnapshot(),
+			modify: func(s *Snapshot) {
+				withLeaderboard(s)
+			},
+			thresholds: DefaultThresholds(),
+			want:       []string{"leaderboard added"},
+		},
+		{
+			name:     "New commits",
+			previous: baseSnapshot(),
+			modify: func(s *Snapshot) {
+				s.CommitHistory["2024-01-03"] = 2
+				s.CommitTimeDistribution[10] = 2
+			},
+			thresholds: DefaultThresholds(),
+			want:       []string{"commits on 2024-01-03: 0 -> 2", "commit time distribution changed by 2 commits"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := baseSnapshot()
+			tt.modify(current)
+
+			got := Compare(tt.previous, current, tt.thresholds)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Compare() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", DefaultFile)
+
+	loaded, err := Load(path)
+	if err != nil || loaded != nil {
+		t.Fatalf("Load() of missing file = %v, %v, want nil, nil", loaded, err)
+	}
+
+	if err := Save(path, baseSnapshot()); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	first, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read snapshot: %v", err)
+	}
+
+	loaded, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if changes := Compare(loaded, baseSnapshot(), Thresholds{}); len(changes) != 0 {
+		t.Errorf("loaded snapshot differs: %q", changes)
+	}
+
+	// Saving the same snapshot produces the same file
+	if err := Save(path, loaded); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	second, _ := os.ReadFile(path)
+	if string(first) != string(second) {
+		t.Errorf("Save() output is not deterministic")
+	}
+
+	if err := os.WriteFile(path, []byte("{invalid"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() of invalid file should return error")
+	}
+}
+
+func TestDeltas(t *testing.T) {
+	current := baseSnapshot()
+	current.Summary.TotalStars += 3
+	current.Summary.TotalCommits += 42
+	current.Summary.RepositoryCount--
+
+	delta := Deltas(baseSnapshot(), current)
+	want := Delta{Stars: 3, Repositories: -1, Commits: 42}
+	if delta != want {
+		t.Errorf("Deltas() = %+v, want %+v", delta, want)
+	}
+	if got := delta.String(); got != "stars +3, repositories -1, commits +42" {
+		t.Errorf("Delta.String() = %q", got)
+	}
+
+	if delta := Deltas(nil, current); delta != (Delta{}) || delta.String() != "" {
+		t.Errorf("Deltas() without previous snapshot = %+v, want zero", delta)
+	}
+}
@@ -0,0 +1,102 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/watsumi/update-gh-profile/internal/git"
+	"github.com/watsumi/update-gh-profile/internal/logger"
+	"github.com/watsumi/update-gh-profile/internal/pullrequest"
+)
+
+// defaultServerURL GitHub server URL used when GITHUB_SERVER_URL is not set
+const defaultServerURL = "https://github.com"
+
+// assetsTarget branch where chart images are published instead of the profile repository branch
+type assetsTarget struct {
+	dir     string // Working directory of the assets branch (temporary)
+	branch  string // Assets branch name
+	baseURL string // URL of the branch root referenced from README.md
+}
+
+// prepareAssets checks out the assets branch into a temporary directory
+// The branch is created as an orphan branch on the first push
+func prepareAssets(token, repoPath string, config Config) (*assetsTarget, error) {
+	serverURL := githubServerURL(config)
+
+	var owner, repo, remoteURL string
+	var err error
+	if config.AssetsRepository != "" {
+		owner, repo, err = pullrequest.ParseRepository(config.AssetsRepository)
+		if err != nil {
+			return nil, err
+		}
+		remoteURL = fmt.Sprintf("%s/%s/%s.git", serverURL, owner, repo)
+	} else {
+		owner, repo, err = resolveRepository(repoPath)
+		if err != nil {
+			return nil, err
+		}
+		remoteURL, err = git.GetRemoteURL(repoPath, "origin")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get remote URL: %w", err)
+		}
+	}
+
+	tempDir, err := os.MkdirTemp("", "update-gh-profile-assets-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create assets directory: %w", err)
+	}
+	dir := filepath.Join(tempDir, repo)
+
+	existed, err := git.CheckoutRemoteBranch(dir, remoteURL, config.AssetsBranch, token)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		return nil, fmt.Errorf("failed to checkout assets branch %s: %w", config.AssetsBranch, err)
+	}
+	if existed {
+		logger.Info("Checked out assets branch %s of %s/%s", config.AssetsBranch, owner, repo)
+	} else {
+		logger.Info("Assets branch %s doesn't exist in %s/%s, it will be created", config.AssetsBranch, owner, repo)
+	}
+
+	return &assetsTarget{
+		dir:     dir,
+		branch:  config.AssetsBranch,
+		baseURL: rawBaseURL(serverURL, owner, repo, config.AssetsBranch),
+	}, nil
+}
+
+// cleanup removes the temporary working directory
+func (a *assetsTarget) cleanup() {
+	os.RemoveAll(filepath.Dir(a.dir))
+}
+
+// publish commits and pushes the assets branch
+func (a *assetsTarget) publish(token, commitMsg string, attempts int) error {
+	err := git.CommitAndPushWithRetry(a.dir, commitMsg, nil, "origin", a.branch, token, nil, git.RetryOptions{Attempts: attempts})
+	if err != nil {
+		return fmt.Errorf("failed to push assets branch %s: %w", a.branch, err)
+	}
+	return nil
+}
+
+// githubServerURL returns the GitHub server URL without a trailing slash
+func githubServerURL(config Config) string {
+	serverURL := strings.TrimSuffix(config.GitHubServerURL, "/")
+	if serverURL == "" {
+		return defaultServerURL
+	}
+	return serverURL
+}
+
+// rawBaseURL returns the URL serving raw files of a branch (or a commit)
+// github.com uses raw.githubusercontent.com, GitHub Enterprise Server uses /raw/ paths
+func rawBaseURL(serverURL, owner, repo, branch string) string {
+	if serverURL == defaultServerURL {
+		return fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s", owner, repo, branch)
+	}
+	return fmt.Sprintf("%s/%s/%s/raw/%s", serverURL, owner, repo, branch)
+}
@@ -0,0 +1,221 @@
+package workflow
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+	"github.com/watsumi/update-gh-profile/internal/generator"
+)
+
+// chartSpec chart output definition
+type chartSpec struct {
+	Name        string // Chart name (referenced from README templates)
+	Section     string // README section tag name
+	File        string // SVG file name
+	Title       string // Title used in text output
+	Description string // Description used in progress output
+}
+
+// Output formats
+const (
+	// OutputFormatSVG generate SVG charts and update README.md (default)
+	OutputFormatSVG = "svg"
+
+	// OutputFormatText print text charts to stdout without updating files
+	OutputFormatText = "text"
+)
+
+// chartSpecs charts generated by the workflow (in README embedding order)
+var chartSpecs = []chartSpec{
+	{Name: "language", Title: "Language Ranking", Section: "LANGUAGE_STATS", File: "language_chart.svg", Description: "language ranking"},
+	{Name: "commit_history", Title: "Commit History", Section: "COMMIT_HISTORY", File: "commit_history_chart.svg", Description: "commit history"},
+	{Name: "commit_time", Title: "Commit Time Distribution", Section: "COMMIT_TIME", File: "commit_time_chart.svg", Description: "commit time distribution"},
+	{Name: "commit_languages", Title: "Top 5 Languages by Commit", Section: "COMMIT_LANGUAGES", File: "commit_languages_chart.svg", Description: "top 5 languages by commit"},
+	{Name: "summary", Title: "Summary", Section: "SUMMARY_STATS", File: "summary_card.svg", Description: "summary card"},
+	{Name: "contributors", Title: "Top Contributors", Section: "TOP_CONTRIBUTORS", File: "contributors_chart.svg", Description: "top contributors"},
+	{Name: "leaderboard", Title: "Team Leaderboard", Section: "TEAM_LEADERBOARD", File: "leaderboard_card.svg", Description: "team leaderboard"},
+}
+
+// ParseCharts parses a comma-separated list of chart names
+//
+// Preconditions:
+// - value is a comma-separated list of chart names (language, commit_history, commit_time, commit_languages, summary, contributors, leaderboard)
+//
+// Postconditions:
+// - Returns the chart names (empty value returns nil = all charts)
+// - Returns error if a name is unknown
+func ParseCharts(value string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		known := false
+		for _, spec := range chartSpecs {
+			known = known || spec.Name == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown chart: %s (use %s)", name, strings.Join(chartNames(), ", "))
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// chartNames returns the names of all charts
+func chartNames() []string {
+	names := make([]string, len(chartSpecs))
+	for i, spec := range chartSpecs {
+		names[i] = spec.Name
+	}
+	return names
+}
+
+// selectedChartSpecs returns the charts to generate (empty names = all charts)
+func selectedChartSpecs(names []string) []chartSpec {
+	if len(names) == 0 {
+		return chartSpecs
+	}
+
+	var specs []chartSpec
+	for _, spec := range chartSpecs {
+		for _, name := range names {
+			if spec.Name == name {
+				specs = append(specs, spec)
+				break
+			}
+		}
+	}
+	return specs
+}
+
+// defaultChartOptions returns the chart options before README section attributes are applied
+func defaultChartOptions(config Config) generator.ChartOptions {
+	opts := generator.DefaultChartOptions()
+	if theme, err := generator.ThemeByName(config.Theme); err == nil {
+		opts.Theme = theme
+	}
+	return opts
+}
+
+// generateChart generates the SVG for the chart
+// Returns false if there is no data to draw the chart
+func generateChart(spec chartSpec, metrics *aggregator.AggregatedMetrics, opts generator.ChartOptions) (string, bool, error) {
+	var svg string
+	var err error
+
+	switch spec.Name {
+	case "language":
+		if len(metrics.Languages) == 0 {
+			return "", false, nil
+		}
+		svg, err = generator.GenerateLanguageChartWithOptions(metrics.Languages, opts)
+	case "commit_history":
+		if len(metrics.CommitHistory) == 0 {
+			return "", false, nil
+		}
+		svg, err = generator.GenerateCommitHistoryChartWithOptions(metrics.CommitHistory, opts)
+	case "commit_time":
+		if len(metrics.CommitTimeDistribution) == 0 {
+			return "", false, nil
+		}
+		svg, err = generator.GenerateCommitTimeChartWithOptions(metrics.CommitTimeDistribution, opts)
+	case "commit_languages":
+		if len(metrics.CommitLanguages) == 0 {
+			return "", false, nil
+		}
+		svg, err = generator.GenerateCommitLanguagesChartWithOptions(metrics.CommitLanguages, opts)
+	case "summary":
+		if metrics.SummaryStats.RepositoryCount == 0 {
+			return "", false, nil
+		}
+		svg, err = generator.GenerateSummaryCardWithOptions(metrics.SummaryStats, opts)
+	case "contributors":
+		// Only available in organization mode
+		if len(metrics.Contributors) == 0 {
+			return "", false, nil
+		}
+		svg, err = generator.GenerateContributorsChartWithOptions(metrics.Contributors, opts)
+	case "leaderboard":
+		// Only available when a team is configured (rendered even if every user failed, to show the failures)
+		if metrics.Leaderboard == nil {
+			return "", false, nil
+		}
+		svg, err = generator.GenerateLeaderboardCardWithOptions(*metrics.Leaderboard, opts)
+	default:
+		return "", false, fmt.Errorf("unknown chart: %s", spec.Name)
+	}
+
+	if err != nil {
+		return "", false, err
+	}
+	return svg, true, nil
+}
+
+// generateChartText generates the text rendering of the chart
+// Returns false if there is no data to draw the chart
+func generateChartText(spec chartSpec, metrics *aggregator.AggregatedMetrics, opts generator.ChartOptions) (string, bool) {
+	switch spec.Name {
+	case "language":
+		if len(metrics.Languages) == 0 {
+			return "", false
+		}
+		return generator.GenerateLanguageTable(metrics.Languages, opts.MaxItems), true
+	case "commit_history":
+		if len(metrics.CommitHistory) == 0 {
+			return "", false
+		}
+		return generator.GenerateCommitHistorySparkline(metrics.CommitHistory, opts.MaxItems), true
+	case "commit_time":
+		if len(metrics.CommitTimeDistribution) == 0 {
+			return "", false
+		}
+		return generator.GenerateCommitTimeBars(metrics.CommitTimeDistribution), true
+	case "commit_languages":
+		if len(metrics.CommitLanguages) == 0 {
+			return "", false
+		}
+		return generator.GenerateCommitLanguagesBars(metrics.CommitLanguages, opts.MaxItems), true
+	case "summary":
+		if metrics.SummaryStats.RepositoryCount == 0 {
+			return "", false
+		}
+		return generator.GenerateSummaryText(metrics.SummaryStats), true
+	case "contributors":
+		if len(metrics.Contributors) == 0 {
+			return "", false
+		}
+		return generator.GenerateContributorsBars(metrics.Contributors, opts.MaxItems), true
+	case "leaderboard":
+		if metrics.Leaderboard == nil {
+			return "", false
+		}
+		return generator.GenerateLeaderboardTable(*metrics.Leaderboard, opts.MaxItems), true
+	default:
+		return "", false
+	}
+}
+
+// textSectionMarkdown converts the text rendering into Markdown for README sections
+// The language ranking and the leaderboard are already Markdown tables, other charts are wrapped in a code block
+func textSectionMarkdown(spec chartSpec, text string) string {
+	if spec.Name == "language" || spec.Name == "leaderboard" {
+		return text
+	}
+	return "```text\n" + text + "\n```"
+}
+
+// printTextCharts writes the text rendering of the selected charts
+func printTextCharts(w io.Writer, metrics *aggregator.AggregatedMetrics, config Config) {
+	for _, spec := range selectedChartSpecs(config.Charts) {
+		text, ok := generateChartText(spec, metrics, defaultChartOptions(config))
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(w, "## %s\n\n%s\n\n", spec.Title, strings.TrimSpace(textSectionMarkdown(spec, text)))
+	}
+}
@@ -0,0 +1,93 @@
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+	"github.com/watsumi/update-gh-profile/internal/generator"
+)
+
+// TestParseCharts verifies that chart names are trimmed, empty entries are skipped and unknown names are rejected
+func TestParseCharts(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr string
+	}{
+		{name: "empty value selects all charts", value: "", want: nil},
+		{name: "only separators", value: " , ,", want: nil},
+		{name: "single chart", value: "summary", want: []string{"summary"}},
+		{name: "whitespace and empty entries", value: " language ,, commit_time ,", want: []string{"language", "commit_time"}},
+		{name: "unknown chart", value: "language,pie", wantErr: "unknown chart: pie"},
+		{name: "names are case sensitive", value: "Language", wantErr: "unknown chart: Language"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCharts(tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseCharts(%q) error = %v, want %q", tt.value, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCharts(%q) error = %v", tt.value, err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") || (got == nil) != (tt.want == nil) {
+				t.Errorf("ParseCharts(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSelectedChartSpecs verifies that selected charts keep the order of chartSpecs
+func TestSelectedChartSpecs(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  []string
+	}{
+		{name: "all charts", names: nil, want: chartNames()},
+		{name: "chartSpecs order", names: []string{"summary", "language", "commit_time"}, want: []string{"language", "commit_time", "summary"}},
+		{name: "duplicates are selected once", names: []string{"summary", "summary"}, want: []string{"summary"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs := selectedChartSpecs(tt.names)
+			got := make([]string, len(specs))
+			for i, spec := range specs {
+				got[i] = spec.Name
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("selectedChartSpecs(%v) = %v, want %v", tt.names, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGenerateChart_LeaderboardFailures verifies that a leaderboard whose users all failed is rendered with the failures
+func TestGenerateChart_LeaderboardFailures(t *testing.T) {
+	spec := selectedChartSpecs([]string{"leaderboard"})[0]
+	metrics := &aggregator.AggregatedMetrics{Leaderboard: &aggregator.Leaderboard{Days: 30, Failed: []string{"ghost"}}}
+
+	svg, ok, err := generateChart(spec, metrics, generator.DefaultChartOptions())
+	if err != nil || !ok {
+		t.Fatalf("generateChart() = %v, %v, want the leaderboard card", ok, err)
+	}
+	if !strings.Contains(svg, "1 user unavailable") {
+		t.Error("leaderboard card should count the unavailable users")
+	}
+
+	text, ok := generateChartText(spec, metrics, generator.DefaultChartOptions())
+	if !ok || !strings.Contains(text, "Unavailable: ghost") {
+		t.Errorf("generateChartText() = %q, %v, want the unavailable users", text, ok)
+	}
+
+	if _, ok, _ := generateChart(spec, &aggregator.AggregatedMetrics{}, generator.DefaultChartOptions()); ok {
+		t.Error("generateChart() without a team should have no data")
+	}
+}
@@ -0,0 +1,73 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+	"github.com/watsumi/update-gh-profile/internal/snapshot"
+)
+
+// DefaultCommitMessage commit message used when none is configured
+const DefaultCommitMessage = "chore: update GitHub profile metrics"
+
+// commitMessageData data passed to commit message templates
+type commitMessageData struct {
+	Username     string                  // Authenticated user
+	Date         string                  // Date in the configured timezone (YYYY-MM-DD)
+	Deltas       string                  // Non-zero changes since the last snapshot (e.g. "stars +3, commits +42")
+	Stars        int                     // Change of total stars
+	Repositories int                     // Change of repository count
+	Commits      int                     // Change of total commits
+	PullRequests int                     // Change of total pull requests
+	Summary      aggregator.SummaryStats // Current totals
+}
+
+// newCommitMessageData builds commit message data
+func newCommitMessageData(username, timezone string, delta snapshot.Delta, summary aggregator.SummaryStats) commitMessageData {
+	now := time.Now()
+	if loc, err := time.LoadLocation(timezone); err == nil {
+		now = now.In(loc)
+	}
+
+	return commitMessageData{
+		Username:     username,
+		Date:         now.Format("2006-01-02"),
+		Deltas:       delta.String(),
+		Stars:        delta.Stars,
+		Repositories: delta.Repositories,
+		Commits:      delta.Commits,
+		PullRequests: delta.PullRequests,
+		Summary:      summary,
+	}
+}
+
+// renderCommitMessage renders a commit message template (Go text/template)
+// Messages without template actions are returned as is
+func renderCommitMessage(messageTemplate string, data commitMessageData) (string, error) {
+	if messageTemplate == "" {
+		messageTemplate = DefaultCommitMessage
+	}
+
+	tmpl, err := template.New("commit").Funcs(template.FuncMap{
+		// signed formats a difference with its sign, like the changes of the job summary
+		"signed": formatChange,
+	}).Parse(messageTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse commit message template: %w", err)
+	}
+
+	var message strings.Builder
+	if err := tmpl.Execute(&message, data); err != nil {
+		return "", fmt.Errorf("failed to render commit message template: %w", err)
+	}
+
+	result := strings.TrimSpace(message.String())
+	if result == "" {
+		return "", fmt.Errorf("commit message template rendered an empty message")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,38 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+	"github.com/watsumi/update-gh-profile/internal/snapshot"
+)
+
+// TestRenderCommitMessage verifies that templates are rendered with the deltas, formatted like the job summary
+func TestRenderCommitMessage(t *testing.T) {
+	data := newCommitMessageData("octo", "UTC", snapshot.Delta{Stars: 3, Repositories: -1}, aggregator.SummaryStats{TotalStars: 10})
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{name: "default message", template: "", want: DefaultCommitMessage},
+		{name: "deltas", template: "chore: update metrics{{with .Deltas}} ({{.}}){{end}}", want: "chore: update metrics (stars +3, repositories -1)"},
+		{name: "signed changes", template: "{{signed .Stars}} {{signed .Repositories}} {{signed .Commits}} of {{.Summary.TotalStars}}", want: "+3 -1 0 of 10"},
+		{name: "empty message", template: "{{if false}}x{{end}}", wantErr: true},
+		{name: "invalid template", template: "{{.Stars", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderCommitMessage(tt.template, data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("renderCommitMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("renderCommitMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+)
+
+// DefaultMetricsFile file name of the metrics written by the fetch command (in the temporary directory by default)
+const DefaultMetricsFile = "update-gh-profile-metrics.json"
+
+// metricsFileVersion version of the metrics file format
+const metricsFileVersion = 1
+
+// ErrMetricsNotFound returned by LoadFetchResult when the metrics file doesn't exist
+var ErrMetricsNotFound = errors.New("metrics file not found (run the fetch command first)")
+
+// FetchResult metrics fetched from GitHub
+// It is passed between the fetch, render, readme and commit commands as a JSON file
+type FetchResult struct {
+	Version   int                           `json:"version"`
+	Username  string                        `json:"username"`
+	FetchedAt time.Time                     `json:"fetched_at"`
+	Metrics   *aggregator.AggregatedMetrics `json:"metrics"`
+}
+
+// SaveFetchResult saves fetched metrics to a file
+//
+// Preconditions:
+// - path is a valid file path
+// - result contains aggregated metrics
+//
+// Postconditions:
+// - Metrics are written as indented JSON
+//
+// Invariants:
+// - Directories are automatically created if they don't exist
+func SaveFetchResult(path string, result *FetchResult) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode metrics: %w", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to save metrics: %w", err)
+	}
+
+	return nil
+}
+
+// LoadFetchResult loads metrics saved by SaveFetchResult
+//
+// Preconditions:
+// - path is a metrics file path
+//
+// Postconditions:
+// - Returns the loaded metrics
+// - Returns ErrMetricsNotFound if the file doesn't exist
+// - Returns error if the file cannot be parsed or has another format version
+//
+// Invariants:
+// - The file is not modified
+func LoadFetchResult(path string) (*FetchResult, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrMetricsNotFound, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metrics: %w", err)
+	}
+
+	var result FetchResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+	if result.Version != metricsFileVersion {
+		return nil, fmt.Errorf("unsupported metrics file version %d (expected %d), run the fetch command again", result.Version, metricsFileVersion)
+	}
+	if result.Metrics == nil {
+		return nil, fmt.Errorf("metrics file %s doesn't contain metrics", path)
+	}
+
+	return &result, nil
+}
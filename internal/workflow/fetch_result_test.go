@@ -0,0 +1,88 @@
+package workflow
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+)
+
+// TestSaveFetchResult_RoundTrip verifies that saved metrics are loaded unchanged
+func TestSaveFetchResult_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", DefaultMetricsFile)
+	fetchedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	result := &FetchResult{
+		Version:   metricsFileVersion,
+		Username:  "octo",
+		FetchedAt: fetchedAt,
+		Metrics: &aggregator.AggregatedMetrics{
+			Languages:     []aggregator.LanguageStat{{Language: "Go", Bytes: 300, Percentage: 75}},
+			CommitHistory: map[string]int{"2025-02-28": 4},
+			SummaryStats:  aggregator.SummaryStats{TotalStars: 12, RepositoryCount: 3},
+		},
+	}
+
+	if err := SaveFetchResult(path, result); err != nil {
+		t.Fatalf("SaveFetchResult() error = %v", err)
+	}
+	loaded, err := LoadFetchResult(path)
+	if err != nil {
+		t.Fatalf("LoadFetchResult() error = %v", err)
+	}
+
+	if loaded.Username != "octo" || !loaded.FetchedAt.Equal(fetchedAt) {
+		t.Errorf("loaded = %s at %s, want octo at %s", loaded.Username, loaded.FetchedAt, fetchedAt)
+	}
+	if loaded.Metrics.SummaryStats != result.Metrics.SummaryStats {
+		t.Errorf("SummaryStats = %+v, want %+v", loaded.Metrics.SummaryStats, result.Metrics.SummaryStats)
+	}
+	if len(loaded.Metrics.Languages) != 1 || loaded.Metrics.Languages[0] != result.Metrics.Languages[0] {
+		t.Errorf("Languages = %+v, want %+v", loaded.Metrics.Languages, result.Metrics.Languages)
+	}
+	if loaded.Metrics.CommitHistory["2025-02-28"] != 4 {
+		t.Errorf("CommitHistory = %v, want 4 commits on 2025-02-28", loaded.Metrics.CommitHistory)
+	}
+}
+
+// TestLoadFetchResult_Errors verifies that missing, outdated and incomplete metrics files are reported
+func TestLoadFetchResult_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadFetchResult(filepath.Join(dir, "missing.json"))
+		if !errors.Is(err, ErrMetricsNotFound) {
+			t.Errorf("LoadFetchResult() error = %v, want ErrMetricsNotFound", err)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "wrong version", content: `{"version": 99, "metrics": {}}`, wantErr: "unsupported metrics file version 99"},
+		{name: "missing metrics", content: `{"version": 1}`, wantErr: "doesn't contain metrics"},
+		{name: "invalid JSON", content: `{"version": `, wantErr: "failed to parse metrics"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(tt.name, " ", "_")+".json")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write metrics file: %v", err)
+			}
+
+			_, err := LoadFetchResult(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("LoadFetchResult() error = %v, want %q", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrMetricsNotFound) {
+				t.Errorf("LoadFetchResult() error = %v, want an error other than ErrMetricsNotFound", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+	"github.com/watsumi/update-gh-profile/internal/generator"
+	"github.com/watsumi/update-gh-profile/internal/logger"
+	"github.com/watsumi/update-gh-profile/internal/readme"
+)
+
+// outputPaths paths of the files updated by the workflow
+type outputPaths struct {
+	repoRoot       string // Repository root
+	readmeBasePath string // Directory containing README.md
+	readmePath     string // README.md path
+	templatePath   string // README template path (empty = update sections in README.md)
+	svgOutputDir   string // Output directory for SVG files
+	imageBaseURL   string // URL of svgOutputDir referenced from README.md (empty = relative paths)
+}
+
+// resolvePaths determines the repository, README.md and output paths from the configuration
+func resolvePaths(config Config) outputPaths {
+	// Use GITHUB_WORKSPACE in GitHub Actions environment (when RepoPath is empty or ".")
+	repoRoot := config.RepoPath
+	if repoRoot == "" || repoRoot == "." {
+		if workspace := os.Getenv("GITHUB_WORKSPACE"); workspace != "" {
+			repoRoot = workspace
+		} else {
+			repoRoot = "."
+		}
+	}
+
+	readmePath := config.ReadmePath
+	if readmePath == "" {
+		readmePath = "README.md"
+		// Organization profiles are read from profile/README.md of the .github repository
+		if config.Organization != "" {
+			readmePath = filepath.Join("profile", "README.md")
+		}
+	}
+	if !filepath.IsAbs(readmePath) {
+		readmePath = filepath.Join(repoRoot, readmePath)
+	}
+	readmeBasePath := filepath.Dir(readmePath)
+
+	// SVG files are written to the directory of README.md by default
+	svgOutputDir := readmeBasePath
+	if config.SVGOutputDir != "" && config.SVGOutputDir != "." {
+		svgOutputDir = config.SVGOutputDir
+		if !filepath.IsAbs(svgOutputDir) {
+			svgOutputDir = filepath.Join(readmeBasePath, svgOutputDir)
+		}
+	}
+
+	return outputPaths{
+		repoRoot:       repoRoot,
+		readmeBasePath: readmeBasePath,
+		readmePath:     readmePath,
+		templatePath:   resolveTemplatePath(config.TemplatePath, readmeBasePath),
+		svgOutputDir:   svgOutputDir,
+	}
+}
+
+// writeOutputs generates charts and updates README.md, and returns the chart paths referenced from README.md
+// It is called again after rebasing onto the remote branch, so it only depends on metrics and the files on disk
+func writeOutputs(config Config, metrics *aggregator.AggregatedMetrics, paths outputPaths) (map[string]string, error) {
+	images, err := renderCharts(config, metrics, paths)
+	if err != nil {
+		return nil, err
+	}
+	return updateReadme(config, metrics, paths, images)
+}
+
+// sectionOptions returns the chart options and attributes of the README section of a chart
+// Chart options can be customized with README section tag attributes
+// (e.g., <!-- START_LANGUAGE_STATS theme=light max=8 layout=donut -->)
+// Returns the defaults and an error if the attributes are invalid
+func sectionOptions(config Config, paths outputPaths, spec chartSpec) (generator.ChartOptions, map[string]string, error) {
+	opts := defaultChartOptions(config)
+	if paths.templatePath != "" {
+		return opts, map[string]string{}, nil
+	}
+
+	attrs := readme.ReadSectionAttributes(paths.readmePath, spec.Section)
+	opts, err := generator.ParseChartOptions(attrs, opts)
+	return opts, attrs, err
+}
+
+// renderCharts generates the SVG (and PNG) files of the selected charts
+// Returns the image paths referenced from README.md by chart name
+// Sections with format=text are skipped, their text is generated when README.md is updated
+func renderCharts(config Config, metrics *aggregator.AggregatedMetrics, paths outputPaths) (map[string]string, error) {
+	// Create output directory
+	err := os.MkdirAll(paths.svgOutputDir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	images := make(map[string]string)
+
+	for _, spec := range selectedChartSpecs(config.Charts) {
+		opts, attrs, err := sectionOptions(config, paths, spec)
+		if err != nil {
+			logger.Warning("Invalid attributes in section %s, using defaults: %v", spec.Section, err)
+		}
+		if attrs["format"] == sectionFormatText {
+			continue
+		}
+
+		svgContent, ok, err := generateChart(spec, metrics, opts)
+		if err != nil {
+			logger.LogErrorWithContext(err, spec.Name, "Failed to generate chart")
+			continue
+		}
+		if !ok {
+			logger.Debug("No data for chart %s, skipping", spec.Name)
+			continue
+		}
+
+		svgPath := filepath.Join(paths.svgOutputDir, spec.File)
+		err = generator.SaveSVG(svgContent, svgPath)
+		if err != nil {
+			logger.LogErrorWithContext(err, spec.Name, "Failed to save SVG")
+			continue
+		}
+		images[spec.Name] = svgPath
+		logger.Info("Generated %s SVG: %s", spec.Description, svgPath)
+		logger.Print("  ✅ Generated %s SVG: %s", spec.Description, svgPath)
+
+		// Rasterize to PNG if enabled or requested by the section (format=png)
+		if config.PNGScale > 0 || attrs["format"] == sectionFormatPNG {
+			pngPath := generator.PNGPath(svgPath)
+			err = generator.SavePNG(svgContent, pngPath, pngScale(config.PNGScale, attrs))
+			if err != nil {
+				logger.LogErrorWithContext(err, spec.Name, "Failed to save PNG")
+				continue
+			}
+			logger.Info("Generated %s PNG: %s", spec.Description, pngPath)
+			logger.Print("  ✅ Generated %s PNG: %s", spec.Description, pngPath)
+
+			// Reference the PNG instead of the SVG in README.md
+			if attrs["format"] == sectionFormatPNG {
+				images[spec.Name] = pngPath
+			}
+		}
+	}
+
+	return images, nil
+}
+
+// existingImages returns the images of the selected charts that were already generated
+// Used when README.md is updated without rendering the charts in the same run
+func existingImages(config Config, paths outputPaths) map[string]string {
+	images := make(map[string]string)
+	for _, spec := range selectedChartSpecs(config.Charts) {
+		_, attrs, _ := sectionOptions(config, paths, spec)
+		if attrs["format"] == sectionFormatText {
+			continue
+		}
+
+		imagePath := filepath.Join(paths.svgOutputDir, spec.File)
+		if attrs["format"] == sectionFormatPNG {
+			imagePath = generator.PNGPath(imagePath)
+		}
+		if _, err := os.Stat(imagePath); err != nil {
+			logger.Debug("No image for chart %s: %s", spec.Name, imagePath)
+			continue
+		}
+		images[spec.Name] = imagePath
+	}
+	return images
+}
+
+// chartTexts generates the text of the sections with format=text
+// Returns nothing if metrics are not available
+func chartTexts(config Config, metrics *aggregator.AggregatedMetrics, paths outputPaths) map[string]string {
+	texts := make(map[string]string)
+	for _, spec := range selectedChartSpecs(config.Charts) {
+		opts, attrs, _ := sectionOptions(config, paths, spec)
+		if attrs["format"] != sectionFormatText {
+			continue
+		}
+		if metrics == nil {
+			logger.WarningFile(filepath.Base(paths.readmePath), "Section %s has format=text, but no metrics are available (run the fetch command first)", spec.Section)
+			continue
+		}
+
+		if text, ok := generateChartText(spec, metrics, opts); ok {
+			texts[spec.Name] = textSectionMarkdown(spec, text)
+			logger.Info("Generated %s text", spec.Description)
+			logger.Print("  ✅ Generated %s text", spec.Description)
+		}
+	}
+	return texts
+}
+
+// chartReferences converts image paths to the paths referenced from README.md
+// Paths are relative to README.md, or URLs in assets branch mode
+func chartReferences(paths outputPaths, images map[string]string) map[string]string {
+	chartPaths := make(map[string]string)
+	for _, spec := range chartSpecs {
+		imagePath, ok := images[spec.Name]
+		if !ok {
+			continue
+		}
+
+		if paths.imageBaseURL != "" {
+			relPath, err := filepath.Rel(paths.svgOutputDir, imagePath)
+			if err != nil {
+				relPath = filepath.Base(imagePath)
+			}
+			chartPaths[spec.Name] = paths.imageBaseURL + "/" + filepath.ToSlash(relPath)
+			continue
+		}
+
+		relPath, err := filepath.Rel(paths.readmeBasePath, imagePath)
+		if err != nil {
+			relPath = filepath.Base(imagePath)
+		}
+		chartPaths[spec.Name] = relPath
+	}
+	return chartPaths
+}
+
+// updateReadme updates README.md sections (or renders the README template) with the chart images
+// metrics can be nil when only images are embedded (text sections and templates need metrics)
+// Returns the chart paths referenced from README.md
+func updateReadme(config Config, metrics *aggregator.AggregatedMetrics, paths outputPaths, images map[string]string) (map[string]string, error) {
+	// 5. Update README.md
+	logger.Group("📝 Updating README.md...")
+
+	chartPaths := chartReferences(paths, images)
+
+	if paths.templatePath != "" {
+		if metrics == nil {
+			return nil, fmt.Errorf("README template %s needs metrics (run the fetch command first)", paths.templatePath)
+		}
+
+		// Template mode: render README.md from the template
+		lastUpdated, err := readme.FormatTimestampWithTimezone(time.Now(), timezoneOrDefault(config.Timezone))
+		if err != nil {
+			logger.Warning("Invalid timezone %q, using UTC: %v", config.Timezone, err)
+			lastUpdated = readme.FormatTimestamp(time.Now().UTC(), "")
+		}
+
+		topLanguages := metrics.Languages
+		if len(topLanguages) > topLanguageCount {
+			topLanguages = topLanguages[:topLanguageCount]
+		}
+
+		templateData := readme.TemplateData{
+			Summary:         metrics.SummaryStats,
+			Languages:       metrics.Languages,
+			TopLanguages:    topLanguages,
+			CommitLanguages: metrics.CommitLanguages,
+			Contributors:    metrics.Contributors,
+			Leaderboard:     metrics.Leaderboard,
+			LastUpdated:     lastUpdated,
+			Charts:          chartPaths,
+		}
+
+		err = readme.RenderTemplateFile(paths.templatePath, paths.readmePath, templateData)
+		if err != nil {
+			logger.LogError(err, "Failed to render README template")
+			return nil, fmt.Errorf("failed to render README template: %w", err)
+		}
+		logger.Info("Rendered README template: %s", paths.templatePath)
+		logger.Print("  ✅ Rendered README.md from template %s", paths.templatePath)
+	} else {
+		// Create README if it doesn't exist (profile/README.md of organizations may not have a directory yet)
+		if _, err := os.Stat(paths.readmePath); os.IsNotExist(err) {
+			if err := os.MkdirAll(paths.readmeBasePath, 0755); err != nil {
+				return nil, fmt.Errorf("failed to create README.md directory: %w", err)
+			}
+			err = os.WriteFile(paths.readmePath, []byte("# GitHub Profile\n\n"), 0644)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create README.md: %w", err)
+			}
+			logger.Print("  ℹ️  Created README.md")
+		}
+
+		// Embed SVG charts (or text charts)
+		sectionTexts := chartTexts(config, metrics, paths)
+		for _, spec := range chartSpecs {
+			var err error
+			if text, ok := sectionTexts[spec.Name]; ok {
+				err = readme.UpdateSectionByName(paths.readmePath, spec.Section, text)
+			} else if relPath, ok := chartPaths[spec.Name]; ok {
+				err = readme.EmbedSVGWithCustomPath(paths.readmePath, relPath, spec.Section, "")
+			} else {
+				continue
+			}
+
+			if err != nil {
+				logger.WarningFile(filepath.Base(paths.readmePath), "Failed to update section %s: %v", spec.Section, err)
+			} else {
+				logger.Info("Updated section %s", spec.Section)
+				logger.Print("  ✅ Updated section %s", spec.Section)
+			}
+		}
+	}
+
+	return chartPaths, nil
+}
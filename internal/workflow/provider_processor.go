@@ -0,0 +1,159 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+	"github.com/watsumi/update-gh-profile/internal/logger"
+	"github.com/watsumi/update-gh-profile/internal/provider"
+	"github.com/watsumi/update-gh-profile/internal/repository"
+
+	"github.com/google/go-github/v76/github"
+)
+
+// newProviders creates the data providers of the run (GitHub first, then additional accounts, GitLab, Gitea and local clones if configured)
+// In organization mode, the organization replaces the authenticated user as the first provider
+func newProviders(token string, config Config) ([]provider.Provider, error) {
+	providers := []provider.Provider{provider.NewGitHub(apiClientConfig(token, config))}
+	if config.Organization != "" {
+		providers[0] = provider.NewOrganization(apiClientConfig(token, config), config.Organization)
+	}
+
+	for _, account := range config.Accounts {
+		providers = append(providers, provider.NewGitHub(repository.ClientConfig{
+			Token:      account.Token,
+			RESTURL:    account.APIURL,
+			GraphQLURL: account.GraphQLURL,
+			CABundle:   config.CABundle,
+			Proxy:      config.Proxy,
+		}))
+	}
+
+	if config.GitLabToken != "" {
+		httpClient, err := repository.ClientConfig{
+			Token:    config.GitLabToken,
+			CABundle: config.CABundle,
+			Proxy:    config.Proxy,
+		}.HTTPClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitLab client: %w", err)
+		}
+		providers = append(providers, provider.NewGitLab(config.GitLabURL, httpClient))
+	}
+
+	if config.GiteaToken != "" {
+		httpClient, err := repository.ClientConfig{
+			Token:    config.GiteaToken,
+			CABundle: config.CABundle,
+			Proxy:    config.Proxy,
+		}.HTTPClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Gitea client: %w", err)
+		}
+		providers = append(providers, provider.NewGitea(config.GiteaURL, httpClient))
+	}
+
+	if config.LocalRepositories != "" {
+		providers = append(providers, provider.NewLocal(config.LocalRepositories, config.LocalEmails))
+	}
+
+	return providers, nil
+}
+
+// AggregateProviderData fetches the data of the authenticated user from every provider concurrently and merges it
+//
+// Preconditions:
+// - ctx is a valid context.Context
+// - providers has at least one provider (the first one is the primary account)
+//
+// Postconditions:
+// - Returns the merged aggregation inputs and the user name of the primary account (the organization in organization mode)
+// - Returns error if the user or repositories of any provider cannot be fetched
+//
+// Invariants:
+// - Data is merged in the order of providers, so repositories listed by several accounts are taken from the first one
+// - opts.MaxRepositories limits the merged repositories, in the order of providers
+func AggregateProviderData(ctx context.Context, providers []provider.Provider, opts provider.Options) (*provider.Data, string, error) {
+	users := make([]provider.User, len(providers))
+	data := make([]*provider.Data, len(providers))
+	errs := make([]error, len(providers))
+
+	var wg sync.WaitGroup
+	for i, p := range providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			users[i], data[i], errs[i] = collectProvider(ctx, p, opts)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return provider.Merge(opts.MaxRepositories, data...), users[0].Login, nil
+}
+
+// collectProvider fetches the authenticated user of a provider and its data
+func collectProvider(ctx context.Context, p provider.Provider, opts provider.Options) (provider.User, *provider.Data, error) {
+	user, err := p.Viewer(ctx)
+	if err != nil {
+		logger.LogError(err, "Failed to fetch authenticated user information")
+		return provider.User{}, nil, fmt.Errorf("failed to fetch authenticated user information from %s: %w", p.Name(), err)
+	}
+	logger.Info("Authenticated %s user: %s", p.Name(), user.Login)
+
+	data, err := provider.Collect(ctx, p, user, opts)
+	if err != nil {
+		return provider.User{}, nil, err
+	}
+	return user, data, nil
+}
+
+// fetchLeaderboard fetches the contributions of the team members over the leaderboard period and ranks them
+// Users whose contributions cannot be fetched are listed in Failed instead of failing the run
+func fetchLeaderboard(ctx context.Context, token string, config Config) *aggregator.Leaderboard {
+	days := config.TeamDays
+	if days <= 0 {
+		days = DefaultTeamDays
+	}
+
+	logger.Info("Fetching team leaderboard: %d users, %d days", len(config.Team), days)
+	until := time.Now()
+	contributors, failed := provider.TeamContributors(ctx, apiClientConfig(token, config), config.Team, until.AddDate(0, 0, -days), until)
+	if len(contributors) == 0 {
+		logger.Warning("No contributions could be fetched for the team leaderboard")
+	}
+
+	var stats []aggregator.ContributorStat
+	for _, contributor := range contributors {
+		stats = append(stats, aggregator.ContributorStat(contributor))
+	}
+
+	return &aggregator.Leaderboard{
+		Days:    days,
+		Entries: aggregator.RankContributors(stats, 0),
+		Failed:  failed,
+	}
+}
+
+// summaryRepositories converts provider repositories for aggregator.AggregateSummaryStats
+func summaryRepositories(repos []provider.Repository) []*github.Repository {
+	var summary []*github.Repository
+	for _, repo := range repos {
+		summary = append(summary, &github.Repository{
+			Name:            github.String(repo.Name),
+			StargazersCount: github.Int(repo.Stars),
+			Owner: &github.User{
+				Login: github.String(repo.Owner),
+			},
+		})
+	}
+	return summary
+}
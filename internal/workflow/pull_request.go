@@ -0,0 +1,91 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/watsumi/update-gh-profile/internal/git"
+	"github.com/watsumi/update-gh-profile/internal/logger"
+	"github.com/watsumi/update-gh-profile/internal/pullrequest"
+)
+
+// publishPullRequest commits changes to the pull request branch and opens or updates the pull request
+// The pushed commit and the pull request are recorded in report
+func publishPullRequest(ctx context.Context, token, repoPath, commitMsg string, changes []string, config Config, report *runReport) error {
+	owner, repo, err := resolveRepository(repoPath)
+	if err != nil {
+		return err
+	}
+
+	base := config.PullRequestBase
+	if base == "" {
+		base, err = git.GetCurrentBranch(repoPath)
+		if err != nil {
+			return err
+		}
+		if base == "HEAD" {
+			return fmt.Errorf("cannot determine base branch in detached HEAD state, specify it with --pull-request-base")
+		}
+	}
+
+	branch := config.PullRequestBranch
+	if branch == "" {
+		branch = pullrequest.DefaultBranch
+	}
+
+	logger.Info("Committing to branch %s and pushing...", branch)
+	err = git.CommitToBranch(repoPath, commitMsg, nil, "origin", branch, "")
+	if err != nil {
+		return fmt.Errorf("failed to commit to branch %s: %w", branch, err)
+	}
+	logger.Print("  ✅ Pushed branch %s", branch)
+
+	if sha, err := git.ResolveRevision(repoPath, branch); err == nil {
+		report.setCommit(repoPath, sha, config)
+	}
+
+	client, err := apiClientConfig(token, config).RESTClient()
+	if err != nil {
+		return err
+	}
+
+	pr, created, err := pullrequest.Ensure(ctx, client, pullrequest.Options{
+		Owner: owner,
+		Repo:  repo,
+		Head:  branch,
+		Base:  base,
+		Title: commitMsg,
+		Body:  pullrequest.BuildBody(changes),
+	})
+	if err != nil {
+		return err
+	}
+
+	if created {
+		logger.Info("Opened pull request #%d: %s", pr.GetNumber(), pr.GetHTMLURL())
+		logger.Print("  ✅ Opened pull request #%d %s", pr.GetNumber(), pr.GetHTMLURL())
+	} else {
+		logger.Info("Updated pull request #%d: %s", pr.GetNumber(), pr.GetHTMLURL())
+		logger.Print("  ✅ Updated pull request #%d %s", pr.GetNumber(), pr.GetHTMLURL())
+	}
+	report.pullRequestURL = pr.GetHTMLURL()
+	report.result = fmt.Sprintf("Pull request [#%d](%s)", pr.GetNumber(), pr.GetHTMLURL())
+
+	return nil
+}
+
+// resolveRepository determines the owner and name of the repository to open pull requests in
+// Uses GITHUB_REPOSITORY in GitHub Actions environment, otherwise the origin remote URL
+func resolveRepository(repoPath string) (string, string, error) {
+	if repository := os.Getenv("GITHUB_REPOSITORY"); repository != "" {
+		return pullrequest.ParseRepository(repository)
+	}
+
+	remoteURL, err := git.GetRemoteURL(repoPath, "origin")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to determine repository: %w", err)
+	}
+
+	return pullrequest.ParseRepository(remoteURL)
+}
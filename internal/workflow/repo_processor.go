@@ -169,7 +169,7 @@ func ProcessRepositoriesInParallel(ctx context.Context, client *github.Client, r
 			} else {
 				logger.Debug("[%d/%d] Completed processing %s/%s", idx+1, len(repos), data.Owner, data.RepoName)
 			}
-			fmt.Printf("  [%d/%d] Completed processing %s/%s\n", idx+1, len(repos), data.Owner, data.RepoName)
+			logger.Print("  [%d/%d] Completed processing %s/%s", idx+1, len(repos), data.Owner, data.RepoName)
 		}(i, repo)
 	}
 
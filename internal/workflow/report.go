@@ -0,0 +1,194 @@
+package workflow
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/watsumi/update-gh-profile/internal/actions"
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+	"github.com/watsumi/update-gh-profile/internal/logger"
+	"github.com/watsumi/update-gh-profile/internal/snapshot"
+)
+
+// runReport result of a run reported to GitHub Actions (job summary and step outputs)
+type runReport struct {
+	username       string
+	metrics        *aggregator.AggregatedMetrics
+	delta          *snapshot.Delta   // Changes since the previous snapshot (nil = unknown)
+	charts         map[string]string // Chart paths relative to the repository root, or URLs
+	chartBaseURL   string            // URL of the repository root at the pushed commit (empty = charts are not embedded)
+	result         string            // What happened to the changes (empty = the run didn't get that far)
+	changed        bool              // Whether a commit was pushed
+	commitSHA      string            // Pushed commit
+	pullRequestURL string            // Opened or updated pull request
+}
+
+// write writes the job summary and the step outputs
+// Failures are logged as warnings since the profile has already been updated
+func (r *runReport) write() {
+	if err := actions.SetOutputs(r.outputs()); err != nil {
+		logger.Warning("Failed to set step outputs: %v", err)
+	}
+	if err := actions.AppendSummary(r.summary(logger.Warnings())); err != nil {
+		logger.Warning("Failed to write job summary: %v", err)
+	}
+}
+
+// outputs returns the step outputs declared in action.yml
+func (r *runReport) outputs() []actions.Output {
+	outputs := []actions.Output{
+		{Name: "changed", Value: strconv.FormatBool(r.changed)},
+		{Name: "commit_sha", Value: r.commitSHA},
+		{Name: "pull_request_url", Value: r.pullRequestURL},
+	}
+	if r.metrics != nil {
+		topLanguage := ""
+		if len(r.metrics.Languages) > 0 {
+			topLanguage = r.metrics.Languages[0].Language
+		}
+		outputs = append(outputs,
+			actions.Output{Name: "total_stars", Value: strconv.Itoa(r.metrics.SummaryStats.TotalStars)},
+			actions.Output{Name: "total_commits", Value: strconv.Itoa(r.metrics.SummaryStats.TotalCommits)},
+			actions.Output{Name: "total_pull_requests", Value: strconv.Itoa(r.metrics.SummaryStats.TotalPullRequests)},
+			actions.Output{Name: "repository_count", Value: strconv.Itoa(r.metrics.SummaryStats.RepositoryCount)},
+			actions.Output{Name: "top_language", Value: topLanguage},
+		)
+	}
+	return outputs
+}
+
+// summary renders the job summary (Markdown)
+func (r *runReport) summary(warnings []logger.Entry) string {
+	var md strings.Builder
+
+	title := "GitHub profile metrics"
+	if r.username != "" {
+		title += " for @" + r.username
+	}
+	fmt.Fprintf(&md, "## %s\n\n", title)
+
+	if r.metrics != nil {
+		stats := r.metrics.SummaryStats
+		var delta snapshot.Delta
+		if r.delta != nil {
+			delta = *r.delta
+		}
+
+		md.WriteString("| Metric | Value | Change |\n| --- | ---: | ---: |\n")
+		for _, row := range []struct {
+			name   string
+			value  int
+			change int
+		}{
+			{"⭐ Stars", stats.TotalStars, delta.Stars},
+			{"📦 Repositories", stats.RepositoryCount, delta.Repositories},
+			{"📝 Commits", stats.TotalCommits, delta.Commits},
+			{"🔀 Pull requests", stats.TotalPullRequests, delta.PullRequests},
+		} {
+			change := "-"
+			if r.delta != nil {
+				change = formatChange(row.change)
+			}
+			fmt.Fprintf(&md, "| %s | %d | %s |\n", row.name, row.value, change)
+		}
+		md.WriteString("\n")
+
+		if len(r.metrics.Languages) > 0 {
+			languages := r.metrics.Languages
+			if len(languages) > topLanguageCount {
+				languages = languages[:topLanguageCount]
+			}
+			names := make([]string, len(languages))
+			for i, lang := range languages {
+				names[i] = fmt.Sprintf("%s (%.1f%%)", lang.Language, lang.Percentage)
+			}
+			fmt.Fprintf(&md, "**Top languages:** %s\n\n", strings.Join(names, ", "))
+		}
+	}
+
+	if r.result != "" {
+		fmt.Fprintf(&md, "**Result:** %s\n\n", r.result)
+	}
+
+	var images []string
+	for _, spec := range chartSpecs {
+		path, ok := r.charts[spec.Name]
+		if !ok {
+			continue
+		}
+		if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+			if r.chartBaseURL == "" {
+				continue
+			}
+			path = r.chartBaseURL + "/" + path
+		}
+		images = append(images, fmt.Sprintf("![%s](%s)", spec.Title, path))
+	}
+	if len(images) > 0 {
+		md.WriteString("### Charts\n\n" + strings.Join(images, "\n\n") + "\n\n")
+	}
+
+	if len(warnings) > 0 {
+		md.WriteString("### Warnings\n\n")
+		for _, warning := range warnings {
+			if warning.File != "" {
+				fmt.Fprintf(&md, "- ⚠️ `%s`: %s\n", warning.File, warning.Message)
+			} else {
+				fmt.Fprintf(&md, "- ⚠️ %s\n", warning.Message)
+			}
+		}
+		md.WriteString("\n")
+	}
+
+	return md.String()
+}
+
+// setCommit records a pushed commit, so that the charts of the commit can be embedded in the job summary
+func (r *runReport) setCommit(repoPath, sha string, config Config) {
+	r.changed = true
+	r.commitSHA = sha
+
+	owner, repo, err := resolveRepository(repoPath)
+	if err != nil {
+		logger.Debug("Charts are not embedded in the job summary: %v", err)
+		return
+	}
+	r.chartBaseURL = rawBaseURL(githubServerURL(config), owner, repo, sha)
+}
+
+// setCharts records the chart paths referenced from README.md
+// The job summary needs them relative to the repository root (URLs are kept as is)
+func (r *runReport) setCharts(paths outputPaths, chartPaths map[string]string) {
+	readmeDir, err := filepath.Rel(paths.repoRoot, paths.readmeBasePath)
+	if err != nil {
+		readmeDir = "."
+	}
+
+	r.charts = make(map[string]string, len(chartPaths))
+	for name, chartPath := range chartPaths {
+		if strings.Contains(chartPath, "://") {
+			r.charts[name] = chartPath
+			continue
+		}
+		r.charts[name] = path.Join(filepath.ToSlash(readmeDir), filepath.ToSlash(chartPath))
+	}
+}
+
+// formatChange formats a difference with its sign (e.g. +3, -1, 0)
+func formatChange(n int) string {
+	if n == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%+d", n)
+}
+
+// shortSHA abbreviates a commit hash
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -3,32 +3,92 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/watsumi/update-gh-profile/internal/aggregator"
 	"github.com/watsumi/update-gh-profile/internal/generator"
 	"github.com/watsumi/update-gh-profile/internal/git"
 	"github.com/watsumi/update-gh-profile/internal/logger"
+	"github.com/watsumi/update-gh-profile/internal/provider"
 	"github.com/watsumi/update-gh-profile/internal/readme"
 	"github.com/watsumi/update-gh-profile/internal/repository"
+	"github.com/watsumi/update-gh-profile/internal/snapshot"
 
-	"github.com/google/go-github/v76/github"
+	"golang.org/x/oauth2"
 )
 
 // Config workflow configuration
 type Config struct {
-	RepoPath          string          // Repository path (location of README.md)
-	SVGOutputDir      string          // Output directory for SVG files
-	Timezone          string          // Timezone (e.g., "Asia/Tokyo", "UTC")
-	CommitMessage     string          // Git commit message
-	MaxRepositories   int             // Maximum number of repositories to process (0 = all)
-	ExcludeForks      bool            // Whether to exclude forked repositories
-	ExcludedLanguages []string        // List of language names to exclude from ranking
-	LogLevel          logger.LogLevel // Log level
+	RepoPath          string              // Repository path (empty = GITHUB_WORKSPACE or current directory)
+	ReadmePath        string              // README.md path relative to the repository (empty = README.md, or profile/README.md in organization mode)
+	SVGOutputDir      string              // Output directory for SVG files (relative paths are relative to the directory of README.md)
+	Timezone          string              // Timezone (e.g., "Asia/Tokyo", "UTC")
+	CommitMessage     string              // Git commit message template (text/template, empty = DefaultCommitMessage)
+	MaxRepositories   int                 // Maximum number of repositories to process (0 = all)
+	ExcludeForks      bool                // Whether to exclude forked repositories
+	ExcludedLanguages []string            // List of language names to exclude from ranking
+	LogLevel          logger.LogLevel     // Log level
+	TemplatePath      string              // README template path (empty = use README.tmpl.md next to README.md if it exists)
+	OutputFormat      string              // Output format ("svg" or "text", empty = "svg")
+	PNGScale          float64             // Scale factor of PNG versions of charts (0 = only sections with format=png)
+	SkipUnchanged     bool                // Skip commit if metrics didn't change materially since the last snapshot
+	ChangeThresholds  snapshot.Thresholds // Minimum differences regarded as material changes
+	PullRequest       bool                // Commit to a dedicated branch and open/update a pull request instead of pushing directly
+	PullRequestBranch string              // Branch used for pull requests (empty = pullrequest.DefaultBranch)
+	PullRequestBase   string              // Base branch of pull requests (empty = current branch)
+	GitHubAPIURL      string              // GitHub REST API URL (empty = derived from GitHubGraphQLURL, or https://api.github.com/)
+	GitHubGraphQLURL  string              // GitHub GraphQL API URL (empty = derived from GitHubAPIURL, or https://api.github.com/graphql)
+	TokenSource       oauth2.TokenSource  // Refreshes the GitHub token during the run, e.g. GitHub App installation tokens (nil = token is static)
+	CABundle          string              // PEM file of CA certificates trusted for the GitHub API in addition to the system roots
+	Proxy             string              // Proxy URL for the GitHub API (empty = proxy environment variables)
+	GitBackend        string              // Git implementation ("auto", "exec" or "native", empty = "auto")
+	CommitOptions     git.CommitOptions   // Commit author, committer and signing
+	PushAttempts      int                 // Maximum push attempts when the remote branch moved (0 = git.DefaultPushAttempts)
+	AssetsBranch      string              // Branch where images are pushed instead of the README branch (empty = same branch as README.md)
+	AssetsRepository  string              // Repository of the assets branch ("owner/repo", empty = this repository)
+	GitHubServerURL   string              // GitHub server URL used for raw image URLs (empty = https://github.com)
+	GitLabURL         string              // GitLab REST API URL (empty = provider.DefaultGitLabURL)
+	GitLabToken       string              // GitLab token (empty = GitLab is not used)
+	Accounts          []Account           // Additional GitHub accounts merged into the profile
+	Organization      string              // Organization aggregated instead of the authenticated user (empty = authenticated user)
+	Team              []string            // GitHub users ranked on the team leaderboard (empty = no leaderboard)
+	TeamDays          int                 // Period of the team leaderboard in days (0 = DefaultTeamDays)
+	GiteaURL          string              // Gitea or Forgejo server URL (required with GiteaToken)
+	GiteaToken        string              // Gitea or Forgejo token (empty = Gitea is not used)
+	LocalRepositories string              // Directory of local git clones scanned for commits (empty = not scanned)
+	LocalEmails       []string            // Author emails of commits counted in local clones
+	Charts            []string            // Charts to generate (see ParseCharts, empty = all charts)
+	Theme             string              // Default chart theme ("dark" or "light", empty = dark), overridden by section attributes
+	DryRun            bool                // Generate charts and update README.md without committing or pushing
 }
 
-// Run executes the main workflow
+// Account additional GitHub account whose repositories and contributions are merged into the profile
+type Account struct {
+	Token      string // GitHub token of the account
+	APIURL     string // GitHub REST API URL (empty = derived from GraphQLURL, or https://api.github.com/)
+	GraphQLURL string // GitHub GraphQL API URL (empty = derived from APIURL, or https://api.github.com/graphql)
+}
+
+// Section format attribute values
+const (
+	// sectionFormatText embed text charts instead of images
+	sectionFormatText = "text"
+
+	// sectionFormatPNG embed PNG images instead of SVG
+	sectionFormatPNG = "png"
+)
+
+// topLanguageCount number of languages exposed as TopLanguages in README templates
+const topLanguageCount = 5
+
+// DefaultTeamDays default period of the team leaderboard in days
+const DefaultTeamDays = 30
+
+// Run executes the main workflow (fetch, render, readme and commit stages)
 //
 // Preconditions:
 // - ctx is a valid context.Context
@@ -43,50 +103,255 @@ type Config struct {
 // Invariants:
 // - Errors are handled appropriately when they occur
 func Run(ctx context.Context, token string, config Config) error {
+	if err := configure(token, config); err != nil {
+		return err
+	}
+
+	logger.Info("Starting workflow")
+
+	result, err := fetchMetrics(ctx, token, config)
+	if err != nil {
+		return err
+	}
+	metrics := result.Metrics
+
+	// Report the result to GitHub Actions (job summary and step outputs) when the run ends
+	report := &runReport{username: result.Username, metrics: metrics}
+	defer report.write()
+
+	// Text output mode: print charts to stdout without updating files
+	if config.OutputFormat == OutputFormatText {
+		printTextCharts(os.Stdout, metrics, config)
+		return nil
+	}
+
+	paths := resolvePaths(config)
+
+	// 4. Generate SVG charts
+	logger.Group("🎨 Generating SVG charts...")
+
+	// Assets branch mode: images are written to a separate branch and referenced by raw URLs
+	var assets *assetsTarget
+	if config.AssetsBranch != "" {
+		assets, err = prepareAssets(currentToken(token, config), paths.readmeBasePath, config)
+		if err != nil {
+			logger.LogError(err, "Failed to prepare assets branch")
+			return fmt.Errorf("failed to prepare assets branch: %w", err)
+		}
+		defer assets.cleanup()
+		paths.svgOutputDir = assets.dir
+		paths.imageBaseURL = assets.baseURL
+	}
+
+	chartPaths, err := writeOutputs(config, metrics, paths)
+	if err != nil {
+		return err
+	}
+	report.setCharts(paths, chartPaths)
+
+	return commitChanges(ctx, token, config, result, paths, assets, report)
+}
+
+// Fetch executes the fetch stage: fetches repository data and aggregates metrics
+//
+// Preconditions:
+// - ctx is a valid context.Context
+// - token is a GitHub token
+// - config is a valid Config struct
+//
+// Postconditions:
+// - Returns the metrics of the authenticated user
+//
+// Invariants:
+// - No files are modified
+func Fetch(ctx context.Context, token string, config Config) (*FetchResult, error) {
+	if err := configure(token, config); err != nil {
+		return nil, err
+	}
+	return fetchMetrics(ctx, token, config)
+}
+
+// Render executes the render stage: generates chart images from fetched metrics
+//
+// Preconditions:
+// - result contains metrics loaded with LoadFetchResult (or returned by Fetch)
+// - config is a valid Config struct
+//
+// Postconditions:
+// - SVG (and PNG) files of the selected charts are saved
+// - In text output mode, charts are printed to stdout instead
+//
+// Invariants:
+// - README.md is not modified (section attributes are only read)
+func Render(config Config, result *FetchResult) error {
+	if err := configure("", config); err != nil {
+		return err
+	}
+
+	if config.OutputFormat == OutputFormatText {
+		printTextCharts(os.Stdout, result.Metrics, config)
+		return nil
+	}
+
+	logger.Group("🎨 Generating SVG charts...")
+	_, err := renderCharts(config, result.Metrics, resolvePaths(config))
+	return err
+}
+
+// UpdateReadme executes the readme stage: embeds existing chart images in README.md
+//
+// Preconditions:
+// - Chart images were generated by Render (charts without an image are left as is)
+// - result contains fetched metrics, or is nil
+// - config is a valid Config struct
+//
+// Postconditions:
+// - README.md sections are updated, or README.md is rendered from the template
+//
+// Invariants:
+// - Sections with format=text and README templates need metrics (result must not be nil)
+func UpdateReadme(config Config, result *FetchResult) error {
+	if err := configure("", config); err != nil {
+		return err
+	}
+
+	var metrics *aggregator.AggregatedMetrics
+	if result != nil {
+		metrics = result.Metrics
+	}
+
+	paths := resolvePaths(config)
+	_, err := updateReadme(config, metrics, paths, existingImages(config, paths))
+	return err
+}
+
+// Commit executes the commit stage: commits and pushes the updated files (or opens a pull request)
+//
+// Preconditions:
+// - ctx is a valid context.Context
+// - token is a GitHub token
+// - result contains the metrics the files were generated from
+// - config is a valid Config struct (assets branch mode is not supported)
+//
+// Postconditions:
+// - Git commit and push are executed if there are material changes
+//
+// Invariants:
+// - Outputs are regenerated from result if the remote branch moved
+func Commit(ctx context.Context, token string, config Config, result *FetchResult) error {
+	if err := configure(token, config); err != nil {
+		return err
+	}
+	if config.AssetsBranch != "" {
+		return fmt.Errorf("assets branch mode is only supported by the run command")
+	}
+
+	report := &runReport{username: result.Username, metrics: result.Metrics}
+	defer report.write()
+
+	paths := resolvePaths(config)
+	report.setCharts(paths, chartReferences(paths, existingImages(config, paths)))
+
+	return commitChanges(ctx, token, config, result, paths, nil, report)
+}
+
+// configure applies the settings shared by all stages (log level, secrets, git backend and commit options)
+func configure(token string, config Config) error {
 	// Configure logger
 	if config.LogLevel != 0 {
 		logger.DefaultLogger.SetLevel(config.LogLevel)
 	}
 
-	logger.Info("Starting workflow")
+	// Mask credentials in every log message
+	logger.AddSecrets(token, config.GitLabToken, config.GiteaToken, config.CommitOptions.SigningPassphrase)
+	for _, account := range config.Accounts {
+		logger.AddSecrets(account.Token)
+	}
+
+	// Select git implementation
+	gitBackend, err := git.NewBackend(config.GitBackend)
+	if err != nil {
+		logger.LogError(err, "Invalid git backend")
+		return err
+	}
+	git.DefaultBackend = gitBackend
+
+	if err := config.CommitOptions.Validate(); err != nil {
+		logger.LogError(err, "Invalid commit signing configuration")
+		return err
+	}
+	git.DefaultCommitOptions = config.CommitOptions
+
+	return nil
+}
+
+// apiClientConfig returns the GitHub API connection settings
+func apiClientConfig(token string, config Config) repository.ClientConfig {
+	return repository.ClientConfig{
+		Token:       token,
+		TokenSource: config.TokenSource,
+		RESTURL:     config.GitHubAPIURL,
+		GraphQLURL:  config.GitHubGraphQLURL,
+		CABundle:    config.CABundle,
+		Proxy:       config.Proxy,
+	}
+}
+
+// currentToken returns the GitHub token to authenticate git with
+// A refreshed token is returned if the token source has one (e.g. the installation token expired during the run)
+func currentToken(token string, config Config) string {
+	if config.TokenSource == nil {
+		return token
+	}
+	refreshed, err := config.TokenSource.Token()
+	if err != nil {
+		logger.Warning("Failed to refresh GitHub token, using the initial token: %v", err)
+		return token
+	}
+	return refreshed.AccessToken
+}
 
+// fetchMetrics fetches repository data of the authenticated user and aggregates it
+func fetchMetrics(ctx context.Context, token string, config Config) (*FetchResult, error) {
 	// Validate token (already passed, but verify)
 	if token == "" {
 		logger.Error("GITHUB_TOKEN is not set")
-		return fmt.Errorf("GITHUB_TOKEN is not set")
+		return nil, fmt.Errorf("GITHUB_TOKEN is not set")
 	}
 
-	// Fetch authenticated user information via GraphQL (using generated types)
-	username, userID, err := repository.FetchViewerGenerated(ctx, token)
+	providers, err := newProviders(token, config)
 	if err != nil {
-		logger.LogError(err, "Failed to fetch authenticated user information")
-		return fmt.Errorf("failed to fetch authenticated user information: %w", err)
+		return nil, err
 	}
-	logger.Info("Authenticated user: %s", username)
 
-	// 1-2. Fetch and aggregate data using GraphQL
-	fmt.Println("\n📊 Fetching and aggregating repository data...")
+	// 1-2. Fetch and aggregate data of every provider
+	logger.Group("📊 Fetching and aggregating repository data...")
 	logger.Info("Fetching data")
 
-	languageTotals, commitHistories, timeDistributions, allCommitLanguages, totalCommits, totalPRs, repos, err := AggregateGraphQLData(
-		ctx, token, username, userID, config.ExcludeForks)
+	data, username, err := AggregateProviderData(ctx, providers, provider.Options{
+		ExcludeForks:    config.ExcludeForks,
+		MaxRepositories: config.MaxRepositories,
+	})
 	if err != nil {
-		logger.LogError(err, "Failed to fetch and aggregate GraphQL data")
-		return fmt.Errorf("failed to fetch and aggregate GraphQL data: %w", err)
+		logger.LogError(err, "Failed to fetch and aggregate repository data")
+		return nil, fmt.Errorf("failed to fetch and aggregate repository data: %w", err)
 	}
+	languageTotals := data.LanguageTotals
+	commitHistories := data.CommitHistories
+	totalCommits, totalPRs := data.TotalCommits, data.TotalPullRequests
 
 	if len(languageTotals) == 0 {
 		logger.Warning("No repository data found")
-		return fmt.Errorf("no repository data found")
+		return nil, fmt.Errorf("no repository data found")
 	}
 
 	logger.Info("Data fetch completed: languages=%d, commit histories=%d, total commits=%d, total PRs=%d",
 		len(languageTotals), len(commitHistories), totalCommits, totalPRs)
-	fmt.Printf("✅ Data fetched (languages: %d, commit histories: %d repositories)\n",
+	logger.Print("✅ Data fetched (languages: %d, commit histories: %d repositories)",
 		len(languageTotals), len(commitHistories))
 
 	// 3. Aggregate data and generate rankings
-	fmt.Println("\n📈 Aggregating data and generating rankings...")
+	logger.Group("📈 Aggregating data and generating rankings...")
 
 	// Language ranking (all languages, excluding specified ones)
 	var rankedLanguages []aggregator.LanguageStat
@@ -108,197 +373,65 @@ func Run(ctx context.Context, token string, config Config) error {
 
 	// Aggregate commit time distribution
 	logger.Info("Aggregating commit time distribution...")
-	aggregatedTimeDistMap := aggregator.AggregateCommitTimeDistribution(timeDistributions)
+	aggregatedTimeDistMap := aggregator.AggregateCommitTimeDistribution(data.TimeDistributions)
 	aggregatedTimeDist := aggregator.SortCommitTimeDistributionByHour(aggregatedTimeDistMap)
 	logger.Info("Commit time distribution aggregation completed: %d time slots", len(aggregatedTimeDist))
 
 	// Top 5 languages by commit (excluding excluded languages)
-	top5Languages := aggregator.AggregateCommitLanguages(allCommitLanguages, config.ExcludedLanguages)
+	top5Languages := aggregator.AggregateCommitLanguages(data.CommitLanguages, config.ExcludedLanguages)
 
 	// Summary statistics
-	var reposForSummary []*github.Repository
-	if len(repos) > 0 {
-		reposForSummary = repos
-	}
-	summaryStats := aggregator.AggregateSummaryStats(reposForSummary, totalCommits, totalPRs)
+	summaryStats := aggregator.AggregateSummaryStats(summaryRepositories(data.Repositories), totalCommits, totalPRs)
 
-	// 4. Generate SVG charts
-	fmt.Println("\n🎨 Generating SVG charts...")
-
-	// Determine SVG output directory (same directory as README.md)
-	var svgOutputDir string
-	if config.SVGOutputDir == "" || config.SVGOutputDir == "." {
-		// Use GITHUB_WORKSPACE in GitHub Actions environment (same path as README.md)
-		if config.RepoPath == "" || config.RepoPath == "." {
-			if workspace := os.Getenv("GITHUB_WORKSPACE"); workspace != "" {
-				svgOutputDir = workspace
-			} else {
-				svgOutputDir = "."
-			}
-		} else {
-			svgOutputDir = config.RepoPath
-		}
-	} else {
-		svgOutputDir = config.SVGOutputDir
+	// Top contributors (organization mode)
+	var contributors []aggregator.ContributorStat
+	for _, contributor := range data.Contributors {
+		contributors = append(contributors, aggregator.ContributorStat(contributor))
 	}
-
-	// Create output directory
-	err = os.MkdirAll(svgOutputDir, 0755)
-	if err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
-	}
-
-	svgs := make(map[string]string)
-
-	// Language ranking SVG
-	if len(rankedLanguages) > 0 {
-		langSVG, err := generator.GenerateLanguageChart(rankedLanguages, 10)
-		if err == nil {
-			langPath := filepath.Join(svgOutputDir, "language_chart.svg")
-			err = generator.SaveSVG(langSVG, langPath)
-			if err != nil {
-				logger.LogError(err, "Failed to save language ranking SVG")
-			} else {
-				svgs["language_chart.svg"] = langPath
-				logger.Info("Generated language ranking SVG: %s", langPath)
-				fmt.Printf("  ✅ Generated language ranking SVG: %s\n", langPath)
-			}
-		}
+	rankedContributors := aggregator.RankContributors(contributors, 0)
+
+	metrics := &aggregator.AggregatedMetrics{
+		Languages:              rankedLanguages,
+		RepositoryCount:        summaryStats.RepositoryCount,
+		CommitHistory:          aggregatedHistoryMap,
+		CommitTimeDistribution: aggregatedTimeDistMap,
+		CommitLanguages:        top5Languages,
+		SummaryStats:           summaryStats,
+		Contributors:           rankedContributors,
 	}
-
-	// Commit history SVG
-	if len(aggregatedHistory) > 0 {
-		// Convert DateCommitPair slice to map[string]int
-		historyMap := make(map[string]int)
-		for _, pair := range aggregatedHistory {
-			historyMap[pair.Date] = pair.Count
-		}
-		historySVG, err := generator.GenerateCommitHistoryChart(historyMap)
-		if err == nil {
-			historyPath := filepath.Join(svgOutputDir, "commit_history_chart.svg")
-			err = generator.SaveSVG(historySVG, historyPath)
-			if err == nil {
-				svgs["commit_history_chart.svg"] = historyPath
-				fmt.Printf("  ✅ Generated commit history SVG: %s\n", historyPath)
-			}
-		}
+	for _, lang := range rankedLanguages {
+		metrics.TotalBytes += lang.Bytes
 	}
 
-	// Commit time distribution SVG
-	if len(aggregatedTimeDist) > 0 {
-		// Convert HourCommitPair slice to map[int]int
-		timeDistMap := make(map[int]int)
-		for _, pair := range aggregatedTimeDist {
-			timeDistMap[pair.Hour] = pair.Count
-		}
-		timeSVG, err := generator.GenerateCommitTimeChart(timeDistMap)
-		if err == nil {
-			timePath := filepath.Join(svgOutputDir, "commit_time_chart.svg")
-			err = generator.SaveSVG(timeSVG, timePath)
-			if err == nil {
-				svgs["commit_time_chart.svg"] = timePath
-				fmt.Printf("  ✅ Generated commit time distribution SVG: %s\n", timePath)
-			}
-		}
+	// Team leaderboard (failures of individual users are skipped)
+	if len(config.Team) > 0 {
+		metrics.Leaderboard = fetchLeaderboard(ctx, token, config)
 	}
 
-	// Top 5 languages by commit SVG
-	if len(top5Languages) > 0 {
-		commitLangSVG, err := generator.GenerateCommitLanguagesChart(top5Languages)
-		if err == nil {
-			commitLangPath := filepath.Join(svgOutputDir, "commit_languages_chart.svg")
-			err = generator.SaveSVG(commitLangSVG, commitLangPath)
-			if err == nil {
-				svgs["commit_languages_chart.svg"] = commitLangPath
-				fmt.Printf("  ✅ Generated top 5 languages by commit SVG: %s\n", commitLangPath)
-			}
-		}
-	}
-
-	// Summary card SVG
-	if summaryStats.RepositoryCount > 0 {
-		summarySVG, err := generator.GenerateSummaryCard(summaryStats)
-		if err == nil {
-			summaryPath := filepath.Join(svgOutputDir, "summary_card.svg")
-			err = generator.SaveSVG(summarySVG, summaryPath)
-			if err == nil {
-				svgs["summary_card.svg"] = summaryPath
-				fmt.Printf("  ✅ Generated summary card SVG: %s\n", summaryPath)
-			}
-		}
-	}
-
-	// 5. Update README.md
-	fmt.Println("\n📝 Updating README.md...")
-
-	// Determine README.md path (align with RepoPath and Git operation path)
-	var readmeBasePath string
-	if config.RepoPath == "" || config.RepoPath == "." {
-		// Use GITHUB_WORKSPACE in GitHub Actions environment
-		if workspace := os.Getenv("GITHUB_WORKSPACE"); workspace != "" {
-			readmeBasePath = workspace
-		} else {
-			readmeBasePath = "."
-		}
-	} else {
-		readmeBasePath = config.RepoPath
-	}
-	readmePath := filepath.Join(readmeBasePath, "README.md")
-
-	// Create README if it doesn't exist
-	if _, err := os.Stat(readmePath); os.IsNotExist(err) {
-		err = os.WriteFile(readmePath, []byte("# GitHub Profile\n\n"), 0644)
-		if err != nil {
-			return fmt.Errorf("failed to create README.md: %w", err)
-		}
-		fmt.Printf("  ℹ️  Created README.md\n")
-	}
+	return &FetchResult{
+		Version:   metricsFileVersion,
+		Username:  username,
+		FetchedAt: time.Now().UTC(),
+		Metrics:   metrics,
+	}, nil
+}
 
-	// Embed SVG charts
-	svgSections := map[string]string{
-		"LANGUAGE_STATS":   "language_chart.svg",
-		"COMMIT_HISTORY":   "commit_history_chart.svg",
-		"COMMIT_TIME":      "commit_time_chart.svg",
-		"COMMIT_LANGUAGES": "commit_languages_chart.svg",
-		"SUMMARY_STATS":    "summary_card.svg",
-	}
-
-	for sectionTag, svgFile := range svgSections {
-		if svgPath, ok := svgs[svgFile]; ok {
-			// Convert to relative path (using README.md base path)
-			relPath, err := filepath.Rel(readmeBasePath, svgPath)
-			if err != nil {
-				relPath = svgFile
-			}
-
-			err = readme.EmbedSVGWithCustomPath(readmePath, relPath, sectionTag, "")
-			if err != nil {
-				logger.LogErrorWithContext(err, sectionTag, "Failed to update section")
-				fmt.Printf("  ⚠️  Failed to update section %s: %v\n", sectionTag, err)
-			} else {
-				logger.Info("Updated section %s", sectionTag)
-				fmt.Printf("  ✅ Updated section %s\n", sectionTag)
-			}
-		}
-	}
+// commitChanges commits and pushes the files written by the previous stages (or opens a pull request)
+// If the remote branch moved, the outputs are regenerated from result on top of it
+func commitChanges(ctx context.Context, token string, config Config, result *FetchResult, paths outputPaths, assets *assetsTarget, report *runReport) error {
+	metrics := result.Metrics
 
 	// 6. Git commit and push
-	fmt.Println("\n🔀 Executing Git operations...")
-
-	repoPath := config.RepoPath
-	// Use GITHUB_WORKSPACE in GitHub Actions environment (when RepoPath is empty or ".")
-	if repoPath == "" || repoPath == "." {
-		if workspace := os.Getenv("GITHUB_WORKSPACE"); workspace != "" {
-			repoPath = workspace
-			logger.Info("Using GITHUB_WORKSPACE: %s", workspace)
-		} else {
-			if repoPath == "" {
-				repoPath = "."
-			}
-			logger.Info("GITHUB_WORKSPACE not set, using current directory: %s", repoPath)
-		}
+	logger.Group("🔀 Executing Git operations...")
+
+	if config.DryRun {
+		logger.Info("Dry run, skipping commit and push")
+		logger.Print("  ℹ️  Dry run, skipping commit and push")
+		report.result = "Dry run, changes were not committed"
+		return nil
 	}
 
+	repoPath := paths.repoRoot
 	// Convert to absolute path for logging
 	absRepoPath, err := filepath.Abs(repoPath)
 	if err == nil {
@@ -316,10 +449,61 @@ func Run(ctx context.Context, token string, config Config) error {
 			logger.Warning(".git directory exists but not recognized as Git repository: %s", gitDir)
 		}
 		logger.Warning("Not a Git repository, skipping commit and push (path: %s)", repoPath)
-		fmt.Printf("  ℹ️  Not a Git repository, skipping commit and push (path: %s)\n", repoPath)
+		logger.Print("  ℹ️  Not a Git repository, skipping commit and push (path: %s)", repoPath)
+		report.result = "Not a Git repository, changes were not committed"
 		return nil
 	}
 
+	// Skip commit if only timestamps or cosmetic output changed
+	snapshotPath := filepath.Join(paths.readmeBasePath, snapshot.DefaultFile)
+	currentSnapshot := snapshot.FromMetrics(metrics)
+	previousSnapshot := loadPreviousSnapshot(snapshotPath)
+
+	delta := snapshot.Deltas(previousSnapshot, currentSnapshot)
+	if previousSnapshot != nil {
+		report.delta = &delta
+	}
+
+	var metricChanges []string
+	if config.SkipUnchanged {
+		metricChanges, err = checkMaterialChanges(snapshotPath, previousSnapshot, currentSnapshot, config.ChangeThresholds)
+		if err != nil {
+			logger.Warning("Failed to save metrics snapshot, continuing: %v", err)
+		} else if len(metricChanges) == 0 {
+			// Leave a clean working tree, so that later steps don't see outputs that don't match the snapshot
+			if err := restoreOutputs(paths); err != nil {
+				logger.Warning("Failed to restore generated files: %v", err)
+			}
+			logger.Info("No material metric changes, skipping commit and push")
+			logger.Print("  ℹ️  No material metric changes, skipping commit and push")
+			report.result = "No material metric changes, commit skipped"
+			return nil
+		}
+	} else if err := snapshot.Save(snapshotPath, currentSnapshot); err != nil {
+		// The snapshot is the baseline of the deltas of the next commit, so it is committed on every change
+		logger.Warning("Failed to save metrics snapshot, continuing: %v", err)
+	}
+
+	// Commit message (deltas are relative to the previous snapshot)
+	commitMsg, err := renderCommitMessage(config.CommitMessage, newCommitMessageData(
+		result.Username, timezoneOrDefault(config.Timezone), delta, metrics.SummaryStats))
+	if err != nil {
+		logger.LogError(err, "Failed to render commit message")
+		return err
+	}
+	logger.Info("Commit message: %s", commitMsg)
+
+	// Publish images first so that README.md never references missing files
+	if assets != nil {
+		logger.Info("Pushing assets branch %s...", assets.branch)
+		if err := assets.publish(currentToken(token, config), commitMsg, config.PushAttempts); err != nil {
+			logger.LogError(err, "Failed to publish assets")
+			return err
+		}
+		logger.Info("Assets branch %s pushed", assets.branch)
+		logger.Print("  ✅ Pushed images to branch %s", assets.branch)
+	}
+
 	// Check for changes
 	hasChanges, err := git.HasChanges(repoPath)
 	if err != nil {
@@ -329,30 +513,153 @@ func Run(ctx context.Context, token string, config Config) error {
 
 	if !hasChanges {
 		logger.Info("No changes, skipping commit and push")
-		fmt.Println("  ℹ️  No changes, skipping commit and push")
+		logger.Print("  ℹ️  No changes, skipping commit and push")
+		report.result = "No changes"
 		return nil
 	}
 
-	// Commit message
-	commitMsg := config.CommitMessage
-	if commitMsg == "" {
-		commitMsg = "chore: update GitHub profile metrics"
+	if config.PullRequest {
+		// Pull request mode: commit to a dedicated branch instead of pushing to a protected branch
+		err = publishPullRequest(ctx, token, repoPath, commitMsg, metricChanges, config, report)
+		if err != nil {
+			logger.LogError(err, "Failed to publish pull request")
+			return fmt.Errorf("failed to publish pull request: %w", err)
+		}
+	} else {
+		// Commit and push
+		// In GitHub Actions environment, credentials are automatically configured, so token is not needed (pass empty string)
+		logger.Info("Executing Git commit and push...")
+		// If the remote branch moved (e.g. README.md was edited during the run), the outputs are regenerated on top of it
+		regenerate := func() error {
+			if _, err := writeOutputs(config, metrics, paths); err != nil {
+				return err
+			}
+			return snapshot.Save(snapshotPath, currentSnapshot)
+		}
+		previousHead, _ := git.ResolveRevision(repoPath, "HEAD")
+		err = git.CommitAndPushWithRetry(repoPath, commitMsg, nil, "origin", "", "", regenerate, git.RetryOptions{Attempts: config.PushAttempts})
+		if err != nil {
+			logger.LogError(err, "Failed to commit and push")
+			return fmt.Errorf("failed to commit and push: %w", err)
+		}
+
+		logger.Info("Git commit and push completed")
+		logger.Print("  ✅ Git commit and push completed")
+
+		// The remote branch may already have had the same content after a retry
+		if head, err := git.ResolveRevision(repoPath, "HEAD"); err == nil && head != previousHead {
+			report.setCommit(repoPath, head, config)
+			report.result = fmt.Sprintf("Committed and pushed `%s`", shortSHA(head))
+		} else {
+			report.result = "No changes"
+		}
 	}
 
-	// Commit and push
-	// In GitHub Actions environment, credentials are automatically configured, so token is not needed (pass empty string)
-	logger.Info("Executing Git commit and push...")
-	err = git.CommitAndPush(repoPath, commitMsg, nil, "origin", "", "")
+	logger.Info("All processing completed")
+	logger.EndGroup()
+
+	return nil
+}
+
+// loadPreviousSnapshot loads the metrics snapshot of the previous commit
+// An unreadable or corrupt snapshot is treated as missing, so that this run overwrites it
+func loadPreviousSnapshot(path string) *snapshot.Snapshot {
+	previous, err := snapshot.Load(path)
 	if err != nil {
-		logger.LogError(err, "Failed to commit and push")
-		return fmt.Errorf("failed to commit and push: %w", err)
+		logger.Warning("Failed to load metrics snapshot, it will be overwritten: %v", err)
+		return nil
 	}
+	return previous
+}
 
-	logger.Info("Git commit and push completed")
-	fmt.Println("  ✅ Git commit and push completed")
+// checkMaterialChanges compares metrics with the previous snapshot and returns material changes
+// If the change is material, the snapshot is updated so that it is committed with the charts
+func checkMaterialChanges(snapshotPath string, previous, current *snapshot.Snapshot, thresholds snapshot.Thresholds) ([]string, error) {
+	changes := snapshot.Compare(previous, current, thresholds)
+	if len(changes) == 0 {
+		return nil, nil
+	}
 
-	logger.Info("All processing completed")
-	fmt.Println("\n✅ All processing completed!")
+	for _, change := range changes {
+		logger.Info("Material change: %s", change)
+	}
 
-	return nil
+	if err := snapshot.Save(snapshotPath, current); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// restoreOutputs restores the changed README.md and chart images to their committed contents
+// Other changes in the working tree are kept
+func restoreOutputs(paths outputPaths) error {
+	outputs := map[string]bool{paths.readmePath: true}
+	for _, spec := range chartSpecs {
+		svgPath := filepath.Join(paths.svgOutputDir, spec.File)
+		outputs[svgPath] = true
+		outputs[generator.PNGPath(svgPath)] = true
+	}
+
+	absRoot, err := filepath.Abs(paths.repoRoot)
+	if err != nil {
+		return err
+	}
+	changed, err := git.DetectChanges(paths.repoRoot)
+	if err != nil {
+		return err
+	}
+
+	var files []string
+	for _, file := range changed {
+		for output := range outputs {
+			if absOutput, err := filepath.Abs(output); err == nil && absOutput == filepath.Join(absRoot, file) {
+				files = append(files, file)
+				break
+			}
+		}
+	}
+	if len(files) > 0 {
+		logger.Info("Restoring %d generated files", len(files))
+	}
+	return git.RestoreFiles(paths.repoRoot, files)
+}
+
+// pngScale determines the PNG scale factor (scale section attribute > configuration > default)
+// Scales above generator.MaxPNGScale are clamped, and infinite or NaN values are ignored
+func pngScale(configScale float64, attrs map[string]string) float64 {
+	if scale, err := strconv.ParseFloat(attrs["scale"], 64); err == nil && scale > 0 && !math.IsInf(scale, 0) {
+		if scale > generator.MaxPNGScale {
+			logger.Warning("PNG scale %g exceeds the maximum, using %g", scale, generator.MaxPNGScale)
+			return generator.MaxPNGScale
+		}
+		return scale
+	}
+	if configScale > 0 {
+		return min(configScale, generator.MaxPNGScale)
+	}
+	return generator.DefaultPNGScale
+}
+
+// resolveTemplatePath determines the README template path
+// Returns empty string if template mode is not used
+func resolveTemplatePath(templatePath, readmeBasePath string) string {
+	if templatePath != "" {
+		return templatePath
+	}
+
+	defaultPath := filepath.Join(readmeBasePath, readme.DefaultTemplateFile)
+	if _, err := os.Stat(defaultPath); err == nil {
+		return defaultPath
+	}
+
+	return ""
+}
+
+// timezoneOrDefault returns the timezone, or UTC if not set
+func timezoneOrDefault(timezone string) string {
+	if timezone == "" {
+		return "UTC"
+	}
+	return timezone
 }
@@ -0,0 +1,134 @@
+package workflow
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/watsumi/update-gh-profile/internal/aggregator"
+	"github.com/watsumi/update-gh-profile/internal/generator"
+	"github.com/watsumi/update-gh-profile/internal/git"
+	"github.com/watsumi/update-gh-profile/internal/snapshot"
+)
+
+// TestPNGScale verifies that the scale attribute is clamped and invalid values fall back to the configuration
+func TestPNGScale(t *testing.T) {
+	tests := []struct {
+		name        string
+		configScale float64
+		attrs       map[string]string
+		want        float64
+	}{
+		{name: "default", want: generator.DefaultPNGScale},
+		{name: "configuration", configScale: 3, want: 3},
+		{name: "attribute overrides configuration", configScale: 3, attrs: map[string]string{"scale": "1.5"}, want: 1.5},
+		{name: "huge attribute is clamped", attrs: map[string]string{"scale": "1e9"}, want: generator.MaxPNGScale},
+		{name: "infinite attribute is ignored", configScale: 3, attrs: map[string]string{"scale": "Inf"}, want: 3},
+		{name: "NaN attribute is ignored", attrs: map[string]string{"scale": "NaN"}, want: generator.DefaultPNGScale},
+		{name: "negative attribute is ignored", attrs: map[string]string{"scale": "-2"}, want: generator.DefaultPNGScale},
+		{name: "huge configuration is clamped", configScale: 100, want: generator.MaxPNGScale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pngScale(tt.configScale, tt.attrs); got != tt.want {
+				t.Errorf("pngScale() = %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLoadPreviousSnapshot_Corrupt verifies that a corrupt snapshot is treated as missing and overwritten
+func TestLoadPreviousSnapshot_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), snapshot.DefaultFile)
+	if err := os.WriteFile(path, []byte(`{"version": 1, "summary": {`), 0644); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+
+	previous := loadPreviousSnapshot(path)
+	if previous != nil {
+		t.Fatalf("loadPreviousSnapshot() = %+v, want nil for a corrupt file", previous)
+	}
+
+	current := snapshot.FromMetrics(&aggregator.AggregatedMetrics{SummaryStats: aggregator.SummaryStats{TotalStars: 3}})
+	changes, err := checkMaterialChanges(path, previous, current, snapshot.DefaultThresholds())
+	if err != nil {
+		t.Fatalf("checkMaterialChanges() error = %v", err)
+	}
+	if len(changes) == 0 {
+		t.Error("checkMaterialChanges() = no changes, want a material change without a previous snapshot")
+	}
+
+	// The corrupt file was replaced, so the next run can skip unchanged metrics again
+	saved, err := snapshot.Load(path)
+	if err != nil || saved == nil {
+		t.Fatalf("snapshot.Load() = %v, %v, want the overwritten snapshot", saved, err)
+	}
+	if changes := snapshot.Compare(saved, current, snapshot.DefaultThresholds()); len(changes) != 0 {
+		t.Errorf("Compare() = %v, want no changes against the overwritten snapshot", changes)
+	}
+}
+
+// TestCommitChanges_SkipRestoresOutputs verifies that a skipped commit restores the generated files and keeps other changes
+func TestCommitChanges_SkipRestoresOutputs(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	runGit := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+
+	metrics := &aggregator.AggregatedMetrics{
+		Languages:    []aggregator.LanguageStat{{Language: "Go", Bytes: 100, Percentage: 100}},
+		SummaryStats: aggregator.SummaryStats{TotalStars: 2, RepositoryCount: 1},
+	}
+	paths := outputPaths{repoRoot: dir, readmeBasePath: dir, readmePath: filepath.Join(dir, "README.md"), svgOutputDir: dir}
+	config := Config{SkipUnchanged: true, ChangeThresholds: snapshot.DefaultThresholds(), Charts: []string{"language", "summary"}}
+
+	// The last commit has the README and the snapshot of the same metrics, but no summary card yet
+	readme := "# Profile\n\n<!-- START_LANGUAGE_STATS -->\n<!-- END_LANGUAGE_STATS -->\n\n<!-- START_SUMMARY_STATS -->\n<!-- END_SUMMARY_STATS -->\n"
+	if err := os.WriteFile(paths.readmePath, []byte(readme), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := snapshot.Save(filepath.Join(dir, snapshot.DefaultFile), snapshot.FromMetrics(metrics)); err != nil {
+		t.Fatal(err)
+	}
+	runGit("init", "-q")
+	runGit("add", "-A")
+	runGit("commit", "-q", "-m", "initial")
+
+	if _, err := writeOutputs(config, metrics, paths); err != nil {
+		t.Fatalf("writeOutputs() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not generated\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report := &runReport{}
+	if err := commitChanges(context.Background(), "", config, &FetchResult{Username: "octo", Metrics: metrics}, paths, nil, report); err != nil {
+		t.Fatalf("commitChanges() error = %v", err)
+	}
+	if !strings.Contains(report.result, "skipped") {
+		t.Fatalf("result = %q, want the commit skipped", report.result)
+	}
+
+	changed, err := git.DetectChanges(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 1 || changed[0] != "notes.txt" {
+		t.Errorf("changes after skip = %v, want only notes.txt", changed)
+	}
+	if content, _ := os.ReadFile(paths.readmePath); string(content) != readme {
+		t.Errorf("README.md = %q, want the committed contents", content)
+	}
+}